@@ -0,0 +1,79 @@
+package colorize
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// regex for hex color code with alpha (the # prefix is required)
+	alphaRegex = regexp.MustCompile(`^#([0-9a-fA-F]{2})([0-9a-fA-F]{2})([0-9a-fA-F]{2})([0-9a-fA-F]{2})$`)
+
+	// color translucent colors are blended against
+	alphaBase = detectBackground()
+)
+
+/*
+detectBackground detects the background color of the terminal from the COLORFGBG environment
+variable (e.g., "15;0", set by rxvt and Konsole), whose last field is the index of one of the 16
+basic colors.
+
+Return:
+  - color: The background color, or black if it can't be detected.
+*/
+func detectBackground() color {
+	fields := strings.Split(os.Getenv("COLORFGBG"), ";")
+	if index, err := strconv.Atoi(fields[len(fields)-1]); err == nil && index >= 0 && index < len(ansi16Palette) {
+		return ansi16Palette[index]
+	}
+	return color{0, 0, 0}
+}
+
+/*
+blendAlpha composites a translucent color over the base color (see SetAlphaBase).
+
+Parameters:
+  - col: A pointer to the color struct representing the RGB color.
+  - alpha: The opacity of the color (0 is transparent, 255 is opaque).
+
+Return:
+  - *color: A pointer to the blended color.
+*/
+func blendAlpha(col *color, alpha uint8) *color {
+	blend := func(c uint8, base uint8) uint8 {
+		return uint8((int(c)*int(alpha) + int(base)*(255-int(alpha)) + 127) / 255)
+	}
+	return &color{blend(col.r, alphaBase.r), blend(col.g, alphaBase.g), blend(col.b, alphaBase.b)}
+}
+
+/*
+SetAlphaBase sets the color translucent colors ("#RRGGBBAA") are blended against, since terminals
+can't render transparency. By default, it's the background of the terminal as reported by the
+COLORFGBG environment variable, or black.
+
+Parameters:
+  - hex: The base color (e.g., "#FFFFFF" for a light terminal), or an empty string to restore the
+    default.
+
+Return:
+  - error: An error if the color is invalid.
+
+Example:
+
+	c.SetAlphaBase("#FDF6E3")
+	text, err := c.FormatText("muted", &c.Options{FgColor: "#00000080"}) // 50% black over the base
+*/
+func SetAlphaBase(hex string) error {
+	if hex == "" {
+		alphaBase = detectBackground()
+		return nil
+	}
+	col, err := getColor(hex)
+	if err != nil {
+		return err
+	}
+	alphaBase = *col
+	return nil
+}
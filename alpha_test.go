@@ -0,0 +1,55 @@
+package colorize
+
+import "testing"
+
+/* TestAlpha tests the blending of "#RRGGBBAA" colors */
+func TestAlpha(t *testing.T) {
+	defer SetAlphaBase("")
+	t.Setenv("COLORFGBG", "")
+	_ = SetAlphaBase("")
+
+	tests := map[string]color{
+		"#FF0000FF": {255, 0, 0},
+		"#FF000000": {0, 0, 0},
+		"#FF000080": {128, 0, 0},
+		"#ffffff40": {64, 64, 64},
+	}
+	for hex, expected := range tests {
+		col, err := getColor(hex)
+		if err != nil || *col != expected {
+			t.Errorf("%s: expected %v but got %v, %v", hex, expected, col, err)
+		}
+	}
+
+	// custom base
+	if err := SetAlphaBase("#FFFFFF"); err != nil {
+		t.Fatal("Expected no error but got", err)
+	}
+	if col, _ := getColor("#00000080"); *col != (color{127, 127, 127}) {
+		t.Errorf("Expected a gray but got %v", col)
+	}
+	if err := SetAlphaBase("#FFFF"); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+
+	// the # prefix is required
+	if err := validateHex("FF000080"); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+}
+
+/* TestDetectBackground tests the detectBackground function */
+func TestDetectBackground(t *testing.T) {
+	tests := map[string]color{
+		"15;0":         ansi16Palette[0],
+		"0;default;15": ansi16Palette[15],
+		"":             {0, 0, 0},
+		"15;99":        {0, 0, 0},
+	}
+	for value, expected := range tests {
+		t.Setenv("COLORFGBG", value)
+		if bg := detectBackground(); bg != expected {
+			t.Errorf("%q: expected %v but got %v", value, expected, bg)
+		}
+	}
+}
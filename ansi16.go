@@ -0,0 +1,91 @@
+package colorize
+
+import "fmt"
+
+const (
+	// first codes of the basic colors (foreground, background) and of their bright variants
+	fgAnsi16       = 30
+	bgAnsi16       = 40
+	fgAnsi16Bright = 90
+	bgAnsi16Bright = 100
+)
+
+// ansi16Palette holds the RGB values of the 16 basic colors (Xterm defaults), 8-15 being the bright
+// variants
+var ansi16Palette = [16]color{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+/*
+rgbToAnsi16 converts an RGB color to the closest of the 16 basic colors.
+
+Parameters:
+  - col: A pointer to the color struct representing the RGB color.
+
+Return:
+  - uint8: The index of the basic color (0-15).
+*/
+func rgbToAnsi16(col *color) uint8 {
+	best, bestDist := 0, -1
+	for i, p := range ansi16Palette {
+		if dist := colorDistance(col, &p); bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return uint8(best)
+}
+
+/*
+colorDistance returns the squared Euclidean distance between two RGB colors.
+
+Parameters:
+  - a: A pointer to the first color.
+  - b: A pointer to the second color.
+
+Return:
+  - int: The squared distance.
+*/
+func colorDistance(a *color, b *color) int {
+	dr := int(a.r) - int(b.r)
+	dg := int(a.g) - int(b.g)
+	db := int(a.b) - int(b.b)
+	return dr*dr + dg*dg + db*db
+}
+
+/*
+getAnsi16Code returns the ANSI escape code for setting one of the 16 basic colors in the terminal.
+
+Parameters:
+  - col: A pointer to the color struct representing the RGB color.
+  - ctx: The color context (background or foreground).
+
+Return:
+  - string: The ANSI escape code for setting the closest basic color.
+*/
+func getAnsi16Code(col *color, ctx ColorContext) string {
+	return ansi16IndexCode(int(rgbToAnsi16(col)), ctx)
+}
+
+/*
+ansi16IndexCode returns the ANSI escape code for setting the basic color of the given index.
+
+Parameters:
+  - index: The index of the basic color (0-15).
+  - ctx: The color context (background or foreground).
+
+Return:
+  - string: The ANSI escape code for setting the basic color.
+*/
+func ansi16IndexCode(index int, ctx ColorContext) string {
+	base, bright := fgAnsi16, fgAnsi16Bright
+	if ctx == background {
+		base, bright = bgAnsi16, bgAnsi16Bright
+	}
+	if index < 8 {
+		return fmt.Sprintf("\033[%dm", base+index)
+	}
+	return fmt.Sprintf("\033[%dm", bright+index-8)
+}
@@ -0,0 +1,60 @@
+package colorize
+
+import "testing"
+
+/* TestRgbToAnsi16 tests the rgbToAnsi16 and getAnsi16Code functions */
+func TestRgbToAnsi16(t *testing.T) {
+	tests := []struct {
+		col   color
+		index uint8
+		fg    string
+		bg    string
+	}{
+		{color{0, 0, 0}, 0, "\033[30m", "\033[40m"},
+		{color{200, 10, 10}, 1, "\033[31m", "\033[41m"},
+		{color{255, 40, 30}, 9, "\033[91m", "\033[101m"},
+		{color{30, 30, 250}, 4, "\033[34m", "\033[44m"},
+		{color{128, 128, 128}, 8, "\033[90m", "\033[100m"},
+		{color{250, 250, 250}, 15, "\033[97m", "\033[107m"},
+	}
+	for _, test := range tests {
+		if index := rgbToAnsi16(&test.col); index != test.index {
+			t.Errorf("%v: expected %d but got %d", test.col, test.index, index)
+		}
+		if code := getAnsi16Code(&test.col, foreground); code != test.fg {
+			t.Errorf("%v: expected %q but got %q", test.col, test.fg, code)
+		}
+		if code := getAnsi16Code(&test.col, background); code != test.bg {
+			t.Errorf("%v: expected %q but got %q", test.col, test.bg, code)
+		}
+	}
+}
+
+/* TestAnsi16Tier tests the rendering with the 16 ANSI colors only */
+func TestAnsi16Tier(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = true
+
+	text, err := FormatText("hello", &Options{FgColor: "#FF0000", BgColor: "#000000", Styles: []string{"bold"}})
+	if expected := "\033[1m\033[40m\033[91mhello" + reset; err != nil || text != expected {
+		t.Errorf("Expected %q but got %q, %v", expected, text, err)
+	}
+	if code, err := GetColor("#00FF00", foreground); err != nil || code != "\033[92m" {
+		t.Errorf("Unexpected code %q, %v", code, err)
+	}
+
+	exp := Explain("hello", &Options{FgColor: "#FF0000"})
+	if len(exp.Colors) != 1 || !exp.Colors[0].Downgraded || exp.Colors[0].Ansi16 != 9 || exp.Colors[0].Xterm != -1 {
+		t.Errorf("Unexpected resolution: %+v", exp.Colors)
+	}
+
+	// strict mode reports the downgrade
+	SetStrictMode(true)
+	defer SetStrictMode(false)
+	if _, err := FormatText("hello", &Options{FgColor: "#FF0000"}); err == nil {
+		t.Error("Expected a downgrade warning")
+	}
+}
@@ -0,0 +1,58 @@
+package colorize
+
+import (
+	"strings"
+)
+
+/* formatAudit neutralizes suspicious format strings in the Printf helpers (see SetFormatAudit) */
+var formatAudit = false
+
+/*
+SetFormatAudit enables or disables the format string audit mode.
+
+Format strings should be constants, but colored logging paths sometimes end up passing user input
+as the format (e.g., p.Printf(opts, msg)). In audit mode, the Printf helpers neutralize such
+format strings before formatting them:
+  - escape sequences and control characters (except tabs and newlines) are removed, so input can't
+    inject terminal commands or fake colors.
+  - if no operands are given, "%" is printed literally instead of producing "%!v(MISSING)" noise.
+
+Parameters:
+  - enabled: Whether audit mode is enabled.
+
+Example:
+
+	c.SetFormatAudit(true)
+	p.Printf(nil, "100% \033[31mdone\n") // 100% done
+*/
+func SetFormatAudit(enabled bool) {
+	formatAudit = enabled
+}
+
+/*
+auditFormat neutralizes the given format string if audit mode is enabled.
+
+Parameters:
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - string: The format specifier, safe to use with fmt.Sprintf.
+*/
+func auditFormat(format string, a []any) string {
+	if !formatAudit {
+		return format
+	}
+
+	format = strings.Map(func(r rune) rune {
+		if (r < ' ' && r != '\t' && r != '\n') || r == 0x7f {
+			return -1
+		}
+		return r
+	}, stripANSI(format))
+
+	if len(a) == 0 {
+		format = strings.ReplaceAll(format, "%", "%%")
+	}
+	return format
+}
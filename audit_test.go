@@ -0,0 +1,48 @@
+package colorize
+
+import (
+	"bytes"
+	"testing"
+)
+
+/* TestFormatAudit tests the SetFormatAudit function and its effect on the Printf helpers */
+func TestFormatAudit(t *testing.T) {
+	defer SetFormatAudit(false)
+
+	buf := &bytes.Buffer{}
+	p := NewPrinter(buf)
+
+	// disabled
+	p.Printf(nil, "100%")
+	if buf.String() != "100%!(NOVERB)" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	SetFormatAudit(true)
+	tests := []struct {
+		format   string
+		args     []any
+		expected string
+	}{
+		{"100% done %s\n", nil, "100% done %s\n"},
+		{"\033[31mred\033[0m\a\tok\n", nil, "red\tok\n"},
+		{"\033]8;;http://evil\033\\link", nil, "link"},
+		{"%d%%", []any{5}, "5%"},
+		{"\033[1m%s", []any{"\033[1mx"}, "\033[1mx"},
+	}
+	for _, test := range tests {
+		buf.Reset()
+		p.Printf(nil, test.format, test.args...)
+		if buf.String() != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, buf.String())
+		}
+	}
+
+	// regions
+	buf.Reset()
+	region, _ := p.Begin(nil)
+	region.Printf("50%\033[2J")
+	if buf.String() != "50%" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
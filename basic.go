@@ -0,0 +1,151 @@
+package colorize
+
+import "os"
+
+/*
+The AnsiColor type represents one of the 16 basic ANSI colors. Unlike hex colors, they're rendered
+with the basic color codes at every color level, so they follow the palette of the terminal theme.
+*/
+type AnsiColor uint8
+
+const (
+	/* The 16 basic colors, 8-15 being the bright variants */
+	AnsiBlack AnsiColor = iota
+	AnsiRed
+	AnsiGreen
+	AnsiYellow
+	AnsiBlue
+	AnsiMagenta
+	AnsiCyan
+	AnsiWhite
+	AnsiBrightBlack
+	AnsiBrightRed
+	AnsiBrightGreen
+	AnsiBrightYellow
+	AnsiBrightBlue
+	AnsiBrightMagenta
+	AnsiBrightCyan
+	AnsiBrightWhite
+)
+
+/*
+Format formats the given text with the basic color as foreground.
+
+Since the basic colors are supported by every color level, no error is returned: the text is
+returned unmodified if colors are disabled (e.g., NO_COLOR) or unsupported.
+
+Parameters:
+  - text: The text to be formatted.
+
+Return:
+  - string: The formatted text.
+
+Example:
+
+	fmt.Println(c.AnsiRed.Format("error"))
+	fmt.Println(c.Red("error")) // equivalent
+*/
+func (a AnsiColor) Format(text string) string {
+	record(MetricFormatCalls, 1)
+	if a > AnsiBrightWhite || text == "" || ColorLevel() == LevelNone || !terminalAllows(os.Stdout) {
+		return text
+	}
+	formatted := ansi16IndexCode(int(a), foreground) + text + reset
+	record(MetricEscapeBytes, len(formatted)-len(text))
+	return formatted
+}
+
+/*
+Hex returns the hexadecimal color code of the basic color (Xterm defaults), to be used in Options.
+
+Return:
+  - string: The hexadecimal color code (e.g., "#CD0000"), or an empty string if the color is
+    invalid.
+*/
+func (a AnsiColor) Hex() string {
+	if a > AnsiBrightWhite {
+		return ""
+	}
+	col := ansi16Palette[a]
+	return RGB(col.r, col.g, col.b)
+}
+
+/* Black formats the given text with the black basic color (see AnsiColor.Format) */
+func Black(text string) string {
+	return AnsiBlack.Format(text)
+}
+
+/* Red formats the given text with the red basic color (see AnsiColor.Format) */
+func Red(text string) string {
+	return AnsiRed.Format(text)
+}
+
+/* Green formats the given text with the green basic color (see AnsiColor.Format) */
+func Green(text string) string {
+	return AnsiGreen.Format(text)
+}
+
+/* Yellow formats the given text with the yellow basic color (see AnsiColor.Format) */
+func Yellow(text string) string {
+	return AnsiYellow.Format(text)
+}
+
+/* Blue formats the given text with the blue basic color (see AnsiColor.Format) */
+func Blue(text string) string {
+	return AnsiBlue.Format(text)
+}
+
+/* Magenta formats the given text with the magenta basic color (see AnsiColor.Format) */
+func Magenta(text string) string {
+	return AnsiMagenta.Format(text)
+}
+
+/* Cyan formats the given text with the cyan basic color (see AnsiColor.Format) */
+func Cyan(text string) string {
+	return AnsiCyan.Format(text)
+}
+
+/* White formats the given text with the white basic color (see AnsiColor.Format) */
+func White(text string) string {
+	return AnsiWhite.Format(text)
+}
+
+/* BrightBlack formats the given text with the bright black basic color (see AnsiColor.Format) */
+func BrightBlack(text string) string {
+	return AnsiBrightBlack.Format(text)
+}
+
+/* BrightRed formats the given text with the bright red basic color (see AnsiColor.Format) */
+func BrightRed(text string) string {
+	return AnsiBrightRed.Format(text)
+}
+
+/* BrightGreen formats the given text with the bright green basic color (see AnsiColor.Format) */
+func BrightGreen(text string) string {
+	return AnsiBrightGreen.Format(text)
+}
+
+/* BrightYellow formats the given text with the bright yellow basic color (see AnsiColor.Format) */
+func BrightYellow(text string) string {
+	return AnsiBrightYellow.Format(text)
+}
+
+/* BrightBlue formats the given text with the bright blue basic color (see AnsiColor.Format) */
+func BrightBlue(text string) string {
+	return AnsiBrightBlue.Format(text)
+}
+
+/* BrightMagenta formats the given text with the bright magenta basic color (see AnsiColor.Format) */
+func BrightMagenta(text string) string {
+	return AnsiBrightMagenta.Format(text)
+}
+
+/* BrightCyan formats the given text with the bright cyan basic color (see AnsiColor.Format) */
+func BrightCyan(text string) string {
+	return AnsiBrightCyan.Format(text)
+}
+
+/* BrightWhite formats the given text with the bright white basic color (see AnsiColor.Format) */
+func BrightWhite(text string) string {
+	return AnsiBrightWhite.Format(text)
+}
@@ -0,0 +1,38 @@
+package colorize
+
+import "testing"
+
+/* TestAnsiColor tests the AnsiColor type and its helpers */
+func TestAnsiColor(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{AnsiRed.Format("error"), "\033[31merror" + reset},
+		{Red("error"), "\033[31merror" + reset},
+		{BrightGreen("ok"), "\033[92mok" + reset},
+		{Black("x"), "\033[30mx" + reset},
+		{BrightWhite("x"), "\033[97mx" + reset},
+		{AnsiColor(16).Format("x"), "x"},
+		{Red(""), ""},
+	}
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, test.got)
+		}
+	}
+
+	if AnsiRed.Hex() != "#CD0000" || AnsiBrightWhite.Hex() != "#FFFFFF" || AnsiColor(16).Hex() != "" {
+		t.Error("Expected the hex codes of the Xterm defaults")
+	}
+
+	// no color support
+	trueColor, xTerm, ansi16 = false, false, false
+	if got := Red("error"); got != "error" {
+		t.Errorf("Expected the text unmodified but got %q", got)
+	}
+}
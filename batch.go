@@ -0,0 +1,90 @@
+package colorize
+
+import (
+	"errors"
+	"fmt"
+)
+
+/* The Item type represents a text to be formatted in a batch (see FormatAll) */
+type Item struct {
+	Text    string   // the text to be formatted
+	Options *Options // the formatting options
+}
+
+/*
+ItemError describes the failure of a single item of a batch.
+
+Fields:
+
+	Index int: The index of the item in the batch.
+	Input string: The text of the item.
+	Err   error: The error returned by FormatText.
+*/
+type ItemError struct {
+	Index int
+	Input string
+	Err   error
+}
+
+/*
+Error returns the string representation of the ItemError, with the pattern
+"item <index> (<input>): <error>".
+
+Return:
+  - string: The string representation of the error.
+*/
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("item %d (%q): %v", e.Index, e.Input, e.Err)
+}
+
+/*
+Unwrap returns the error returned by FormatText, for use with errors.Is and errors.As.
+
+Return:
+  - error: The underlying error.
+*/
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+/*
+FormatAll formats every item of a batch, without stopping at the first failure.
+
+Following the package convention, the text of failed items is returned unmodified. The returned
+error joins (with errors.Join) an *ItemError for each failed item, describing its index, input and
+cause; it's nil if every item was formatted.
+
+Parameters:
+  - items: The items to be formatted.
+
+Return:
+  - []string: The formatted texts, in the same order as the items.
+  - error: The aggregated errors of the failed items, or nil.
+
+Example:
+
+	texts, err := c.FormatAll([]c.Item{
+		{Text: "ok", Options: &c.Options{FgColor: "#00FF00"}},
+		{Text: "bad", Options: &c.Options{FgColor: "#GG0000"}},
+	})
+	if err != nil {
+		var itemErr *c.ItemError
+		if errors.As(err, &itemErr) {
+			fmt.Println("first failed item:", itemErr.Index)
+		}
+	}
+*/
+func FormatAll(items []Item) ([]string, error) {
+	texts := make([]string, len(items))
+	errs := []error{}
+
+	for i, item := range items {
+		text, err := FormatText(item.Text, item.Options)
+		if err != nil {
+			errs = append(errs, &ItemError{Index: i, Input: item.Text, Err: err})
+		}
+		texts[i] = text
+	}
+
+	return texts, errors.Join(errs...)
+}
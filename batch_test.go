@@ -0,0 +1,55 @@
+package colorize
+
+import (
+	"errors"
+	"testing"
+)
+
+/* TestFormatAll tests the FormatAll function */
+func TestFormatAll(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	// valid items
+	texts, err := FormatAll([]Item{
+		{Text: "a", Options: &Options{Styles: []string{"bold"}}},
+		{Text: "b", Options: &Options{FgColor: "#FF0000"}},
+	})
+	if err != nil {
+		t.Error("Expected no error but got", err)
+	}
+	if len(texts) != 2 || texts[0] != styles["bold"]+"a"+reset {
+		t.Errorf("Unexpected texts: %q", texts)
+	}
+
+	// invalid items don't stop the batch
+	texts, err = FormatAll([]Item{
+		{Text: "a", Options: &Options{FgColor: "#FF00000"}},
+		{Text: "b", Options: &Options{Styles: []string{"bold"}}},
+		{Text: "c", Options: nil},
+	})
+	if err == nil {
+		t.Fatal("Expected an error but got nil")
+	}
+	if texts[0] != "a" || texts[1] != styles["bold"]+"b"+reset || texts[2] != "c" {
+		t.Errorf("Unexpected texts: %q", texts)
+	}
+
+	var itemErr *ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 0 || itemErr.Input != "a" {
+		t.Errorf("Expected the first item to fail but got '%v'", err)
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 2 {
+		t.Error("Expected 2 joined errors")
+	}
+	if itemErr.Error() != `item 0 ("a"): HEXERR: invalid hex code: #FF00000` {
+		t.Errorf("Unexpected error message: '%s'", itemErr.Error())
+	}
+
+	// empty batch
+	texts, err = FormatAll(nil)
+	if err != nil || len(texts) != 0 {
+		t.Error("Expected no texts and no error")
+	}
+}
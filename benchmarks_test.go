@@ -1,9 +1,45 @@
 package colorize
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 )
 
+/* benchProfile is a color profile of the benchmark suite */
+type benchProfile struct {
+	name      string
+	trueColor bool
+	xTerm     bool
+	ansi16    bool
+}
+
+var (
+	// color profiles of the benchmark suite
+	benchProfiles = []benchProfile{
+		{"truecolor", true, false, false},
+		{"256", false, true, false},
+		{"16", false, false, true},
+		{"off", false, false, false},
+	}
+
+	// text lengths of the benchmark suite
+	benchLengths = []int{10, 1000}
+
+	// options of increasing complexity
+	benchOptions = []struct {
+		name string
+		opts *Options
+	}{
+		{"style", &Options{Styles: []string{"bold"}}},
+		{"fg", &Options{FgColor: "#FF0000"}},
+		{"full", &Options{FgColor: "#FF0000", BgColor: "#00FF00", Styles: []string{"bold", "italic", "underline"}}},
+	}
+
+	// maximum allocations of a FormatText call, by options (see TestFormatTextAllocBudget)
+	allocBudgets = map[string]float64{"style": 4, "fg": 6, "full": 12}
+)
+
 /* BenchmarkGetColor benchmarks the GetColor function */
 func BenchmarkGetColor(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -66,3 +102,86 @@ func BenchmarkBackgroundText(b *testing.B) {
 		}
 	}
 }
+
+/* BenchmarkTableWidthCached benchmarks measuring a large table with the width cache */
+func BenchmarkTableWidthCached(b *testing.B) {
+	cells := tableCells()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, cell := range cells {
+			_ = visibleWidth(cell)
+		}
+	}
+}
+
+/* BenchmarkTableWidthUncached benchmarks measuring a large table without the width cache */
+func BenchmarkTableWidthUncached(b *testing.B) {
+	cells := tableCells()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, cell := range cells {
+			_ = measureWidth(cell)
+		}
+	}
+}
+
+/* BenchmarkFormatTextSuite benchmarks FormatText by profile, text length and options complexity */
+func BenchmarkFormatTextSuite(b *testing.B) {
+	defer restore()
+
+	for _, profile := range benchProfiles {
+		trueColor, xTerm, ansi16 = profile.trueColor, profile.xTerm, profile.ansi16
+		for _, length := range benchLengths {
+			text := strings.Repeat("x", length)
+			for _, bench := range benchOptions {
+				b.Run(profile.name+"/"+strconv.Itoa(length)+"/"+bench.name, func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						_, _ = FormatText(text, bench.opts)
+					}
+				})
+			}
+		}
+	}
+}
+
+/* BenchmarkStylerSuite benchmarks precompiled Stylers by profile, text length and options complexity */
+func BenchmarkStylerSuite(b *testing.B) {
+	defer restore()
+
+	for _, profile := range benchProfiles[:3] {
+		trueColor, xTerm, ansi16 = profile.trueColor, profile.xTerm, profile.ansi16
+		for _, length := range benchLengths {
+			text := strings.Repeat("x", length)
+			for _, bench := range benchOptions {
+				styler, _ := NewStyler(bench.opts)
+				b.Run(profile.name+"/"+strconv.Itoa(length)+"/"+bench.name, func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						_ = styler.Style(text)
+					}
+				})
+			}
+		}
+	}
+}
+
+/* TestFormatTextAllocBudget fails if the FormatText hot path exceeds its allocation budget */
+func TestFormatTextAllocBudget(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector instruments allocations")
+	}
+	defer restore()
+
+	for _, profile := range benchProfiles {
+		trueColor, xTerm, ansi16 = profile.trueColor, profile.xTerm, profile.ansi16
+		for _, bench := range benchOptions {
+			allocs := testing.AllocsPerRun(100, func() {
+				_, _ = FormatText("Hello, world!", bench.opts)
+			})
+			if allocs > allocBudgets[bench.name] {
+				t.Errorf("%s/%s: %.0f allocations exceed the budget of %.0f", profile.name, bench.name, allocs, allocBudgets[bench.name])
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package colorize
+
+import "slices"
+
+/*
+The Builder type builds formatting options with chainable calls, for one-liners and composition:
+every call returns a new Builder, leaving the receiver unchanged, so that a base style can be
+shared and extended.
+*/
+type Builder struct {
+	opts Options
+}
+
+/*
+New returns an empty Builder.
+
+Return:
+  - *Builder: The builder.
+
+Example:
+
+	fmt.Println(c.New().Fg("#FF0000").Bg("#000").Bold().Underline().Sprint("hi"))
+
+	title := c.New().Bold()
+	fmt.Println(title.Fg("#5FD700").Sprint("passed"), title.Fg("#FF5F5F").Sprint("failed"))
+*/
+func New() *Builder {
+	return &Builder{}
+}
+
+/*
+with returns a copy of the builder modified by fn.
+
+Parameters:
+  - fn: The function modifying the options of the copy.
+
+Return:
+  - *Builder: The new builder.
+*/
+func (b *Builder) with(fn func(opts *Options)) *Builder {
+	copied := &Builder{opts: b.opts}
+	copied.opts.Styles = slices.Clone(b.opts.Styles)
+	fn(&copied.opts)
+	return copied
+}
+
+/*
+Fg sets the foreground color.
+
+Parameters:
+  - col: The color, in any format accepted by FormatText (e.g., "#FF0000").
+
+Return:
+  - *Builder: The new builder.
+*/
+func (b *Builder) Fg(col string) *Builder {
+	return b.with(func(opts *Options) { opts.FgColor = col })
+}
+
+/*
+Bg sets the background color.
+
+Parameters:
+  - col: The color, in any format accepted by FormatText (e.g., "#000").
+
+Return:
+  - *Builder: The new builder.
+*/
+func (b *Builder) Bg(col string) *Builder {
+	return b.with(func(opts *Options) { opts.BgColor = col })
+}
+
+/*
+Style adds text styles (e.g., "bold"). Styles already set are not repeated.
+
+Parameters:
+  - names: The styles.
+
+Return:
+  - *Builder: The new builder.
+*/
+func (b *Builder) Style(names ...string) *Builder {
+	return b.with(func(opts *Options) {
+		for _, name := range names {
+			if !slices.Contains(opts.Styles, name) {
+				opts.Styles = append(opts.Styles, name)
+			}
+		}
+	})
+}
+
+/* Bold adds the bold style. */
+func (b *Builder) Bold() *Builder {
+	return b.Style("bold")
+}
+
+/* Italic adds the italic style. */
+func (b *Builder) Italic() *Builder {
+	return b.Style("italic")
+}
+
+/* Underline adds the underline style. */
+func (b *Builder) Underline() *Builder {
+	return b.Style("underline")
+}
+
+/* Blink adds the blink style. */
+func (b *Builder) Blink() *Builder {
+	return b.Style("blink")
+}
+
+/* Reverse adds the reverse style. */
+func (b *Builder) Reverse() *Builder {
+	return b.Style("reverse")
+}
+
+/* Hidden adds the hidden style. */
+func (b *Builder) Hidden() *Builder {
+	return b.Style("hidden")
+}
+
+/* Stroke adds the stroke style. */
+func (b *Builder) Stroke() *Builder {
+	return b.Style("stroke")
+}
+
+/*
+Options returns a copy of the built options, to be used with the rest of the package (e.g., as a
+Theme role).
+
+Return:
+  - *Options: The options.
+*/
+func (b *Builder) Options() *Options {
+	copied := b.with(func(*Options) {})
+	return &copied.opts
+}
+
+/*
+Sprint formats its operands like fmt.Sprint and applies the built options. If the text can't be
+formatted (e.g., the system does not support colors), it's returned unformatted.
+
+Parameters:
+  - a: The operands.
+
+Return:
+  - string: The formatted text.
+*/
+func (b *Builder) Sprint(a ...any) string {
+	return b.opts.Sprint(a...)
+}
+
+/*
+Sprintf formats according to a format specifier like fmt.Sprintf and applies the built options. If
+the text can't be formatted, it's returned unformatted.
+
+Parameters:
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - string: The formatted text.
+*/
+func (b *Builder) Sprintf(format string, a ...any) string {
+	return b.opts.Sprintf(format, a...)
+}
+
+/*
+Sprintln formats its operands like fmt.Sprintln and applies the built options, keeping the trailing
+newline out of the formatted region. If the text can't be formatted, it's returned unformatted.
+
+Parameters:
+  - a: The operands.
+
+Return:
+  - string: The formatted text.
+*/
+func (b *Builder) Sprintln(a ...any) string {
+	return b.opts.Sprintln(a...)
+}
@@ -0,0 +1,62 @@
+package colorize
+
+import (
+	"slices"
+	"testing"
+)
+
+/* TestBuilder tests the options built by chained calls */
+func TestBuilder(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	b := New().Fg("#ff0000").Bg("#000").Bold().Underline().Bold()
+	opts := b.Options()
+	if opts.FgColor != "#ff0000" || opts.BgColor != "#000" || !slices.Equal(opts.Styles, []string{"bold", "underline"}) {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+
+	expected, _ := FormatText("hi 42", opts)
+	if got := b.Sprint("hi ", 42); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+	if got := b.Sprintf("hi %d", 42); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	// the options returned are a copy
+	opts.Styles[0] = "italic"
+	if b.Options().Styles[0] != "bold" {
+		t.Error("Expected the builder to be unchanged by the returned options")
+	}
+}
+
+/* TestBuilderComposition tests that builders are immutable and can share a base */
+func TestBuilderComposition(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	base := New().Bold()
+	passed := base.Fg("#5FD700").Italic()
+	failed := base.Fg("#FF5F5F")
+	if opts := base.Options(); opts.FgColor != "" || len(opts.Styles) != 1 {
+		t.Errorf("Expected the base to be unchanged but got %+v", opts)
+	}
+	if opts := passed.Options(); opts.FgColor != "#5FD700" || !slices.Equal(opts.Styles, []string{"bold", "italic"}) {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+	if opts := failed.Options(); opts.FgColor != "#FF5F5F" || !slices.Equal(opts.Styles, []string{"bold"}) {
+		t.Errorf("Unexpected options %+v", opts)
+	}
+
+	// without options or colors, the text is returned unformatted
+	if got := New().Sprint("plain"); got != "plain" {
+		t.Errorf("Expected %q but got %q", "plain", got)
+	}
+	trueColor, xTerm, ansi16 = false, false, false
+	if got := failed.Sprint("plain"); got != "plain" {
+		t.Errorf("Expected %q but got %q", "plain", got)
+	}
+}
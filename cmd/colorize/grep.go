@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	c "github.com/dan-almenar/colorize"
+)
+
+/* patternsFlag collects the additional patterns given on the command line */
+type patternsFlag []string
+
+func (f *patternsFlag) String() string {
+	return ""
+}
+
+func (f *patternsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+/* runGrep runs the grep subcommand */
+func runGrep(args []string) error {
+	var extra patternsFlag
+	fs := flag.NewFlagSet("grep", flag.ContinueOnError)
+	fs.Var(&extra, "e", "an additional pattern (repeatable)")
+	ignoreCase := fs.Bool("i", false, "ignore case")
+	lineNumbers := fs.Bool("n", false, "print line numbers")
+	before := fs.Int("B", 0, "print `N` lines of context before each match")
+	after := fs.Int("A", 0, "print `N` lines of context after each match")
+	around := fs.Int("C", 0, "print `N` lines of context around each match")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: colorize grep [-i] [-n] [-A N] [-B N] [-C N] [-e PATTERN]... PATTERN [FILE]...")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	exprs := []string(extra)
+	if len(exprs) == 0 {
+		if len(positional) == 0 {
+			fs.Usage()
+			return errUsage
+		}
+		exprs, positional = positional[:1], positional[1:]
+	}
+
+	patterns := make([]*regexp.Regexp, len(exprs))
+	for i, expr := range exprs {
+		if *ignoreCase {
+			expr = "(?i)" + expr
+		}
+		if patterns[i], err = regexp.Compile(expr); err != nil {
+			return err
+		}
+	}
+	opts := &c.GrepOptions{Before: max(*before, *around), After: max(*after, *around), LineNumbers: *lineNumbers}
+
+	if len(positional) == 0 {
+		positional = []string{"-"}
+	}
+	matches := 0
+	for _, path := range positional {
+		var r io.Reader = os.Stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		if len(positional) > 1 {
+			fmt.Println(c.DefaultTheme.Format("inline.path", path))
+		}
+		n, err := c.Grep(r, patterns, opts, os.Stdout)
+		if err != nil {
+			return err
+		}
+		matches += n
+	}
+
+	// like grep, the exit status is 1 without matches
+	if matches == 0 {
+		os.Exit(1)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	c "github.com/dan-almenar/colorize"
+)
+
+/* runJSONLog runs the jsonlog subcommand */
+func runJSONLog(args []string) error {
+	fs := flag.NewFlagSet("jsonlog", flag.ContinueOnError)
+	levelKey := fs.String("level", "level", "the `KEY` of the level field")
+	timeKey := fs.String("time", "time", "the `KEY` of the time field")
+	messageKey := fs.String("msg", "msg", "the `KEY` of the message field")
+	spec := fs.String("layout", c.DefaultLogLayout, "the `LAYOUT` of the lines")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: colorize jsonlog [-level KEY] [-time KEY] [-msg KEY] [-layout LAYOUT] [FILE]...")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	layout, err := c.CompileLayout(*spec, nil)
+	if err != nil {
+		return err
+	}
+	formatter := c.NewJSONLogFormatter(os.Stdout, &c.JSONLogOptions{
+		LevelKey:   *levelKey,
+		TimeKey:    *timeKey,
+		MessageKey: *messageKey,
+		Layout:     layout,
+	})
+
+	if len(positional) == 0 {
+		positional = []string{"-"}
+	}
+	for _, path := range positional {
+		var r io.Reader = os.Stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		if _, err := io.Copy(formatter, r); err != nil {
+			return err
+		}
+		// a last line without line break ends with its file
+		if err := formatter.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
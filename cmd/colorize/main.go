@@ -0,0 +1,69 @@
+/*
+Colorize is a command-line tool built on the colorize package.
+
+Usage:
+
+	colorize <command> [arguments]
+
+The commands are:
+
+	grep    print the lines matching patterns, highlighting the matches
+	jsonlog pretty-print NDJSON log lines
+	tail    print (and follow) a file, highlighting the lines matching patterns
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+/* errUsage reports invalid arguments, whose usage was already printed */
+var errUsage = errors.New("invalid arguments")
+
+/* command is a subcommand of the tool */
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+// subcommands of the tool
+var commands = []command{
+	{"grep", "print the lines matching patterns, highlighting the matches", runGrep},
+	{"jsonlog", "pretty-print NDJSON log lines", runJSONLog},
+	{"tail", "print (and follow) a file, highlighting the lines matching patterns", runTail},
+}
+
+/* usage prints the usage of the tool */
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: colorize <command> [arguments]\n\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s%s\n", cmd.name, cmd.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			err := cmd.run(os.Args[2:])
+			switch {
+			case errors.Is(err, flag.ErrHelp):
+			case errors.Is(err, errUsage):
+				os.Exit(2)
+			case err != nil:
+				fmt.Fprintln(os.Stderr, "colorize "+cmd.name+":", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	usage()
+	os.Exit(2)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"slices"
+	"strings"
+
+	c "github.com/dan-almenar/colorize"
+)
+
+// names of the basic colors accepted in highlight rules
+var colorNames = map[string]c.AnsiColor{
+	"black": c.AnsiBlack, "red": c.AnsiRed, "green": c.AnsiGreen, "yellow": c.AnsiYellow,
+	"blue": c.AnsiBlue, "magenta": c.AnsiMagenta, "cyan": c.AnsiCyan, "white": c.AnsiWhite,
+	"gray": c.AnsiBrightBlack, "brightred": c.AnsiBrightRed, "brightgreen": c.AnsiBrightGreen,
+	"brightyellow": c.AnsiBrightYellow, "brightblue": c.AnsiBrightBlue,
+	"brightmagenta": c.AnsiBrightMagenta, "brightcyan": c.AnsiBrightCyan, "brightwhite": c.AnsiBrightWhite,
+}
+
+// styles accepted in highlight rules
+var styleNames = []string{"bold", "italic", "underline", "blink", "reverse", "hidden", "stroke"}
+
+/* ruleFlag collects the highlight rules given on the command line */
+type ruleFlag struct {
+	rules *[]c.HighlightRule
+	line  bool
+}
+
+func (f ruleFlag) String() string {
+	return ""
+}
+
+/*
+Set parses a "PATTERN:COLOR" rule. The color is a basic color name (e.g., "red") or any color
+accepted by the package (e.g., "#FF8000"), optionally followed by styles (e.g., "red+bold").
+*/
+func (f ruleFlag) Set(value string) error {
+	i := strings.LastIndex(value, ":")
+	if i < 0 {
+		return fmt.Errorf("expected PATTERN:COLOR, got %q", value)
+	}
+	pattern, err := regexp.Compile(value[:i])
+	if err != nil {
+		return err
+	}
+	opts, err := parseStyle(value[i+1:])
+	if err != nil {
+		return err
+	}
+	*f.rules = append(*f.rules, c.HighlightRule{Pattern: pattern, Options: opts, Line: f.line})
+	return nil
+}
+
+/*
+parseStyle parses a "COLOR[+STYLE...]" specification into formatting options.
+
+Parameters:
+  - spec: The specification (e.g., "red+bold", "#FF8000", "bold").
+
+Return:
+  - *c.Options: The formatting options.
+  - error: An error if the color is invalid.
+*/
+func parseStyle(spec string) (*c.Options, error) {
+	opts := &c.Options{}
+	for _, part := range strings.Split(spec, "+") {
+		if ansi, ok := colorNames[strings.ToLower(part)]; ok {
+			opts.FgColor = ansi.Hex()
+			continue
+		}
+		if slices.Contains(styleNames, strings.ToLower(part)) {
+			opts.Styles = append(opts.Styles, strings.ToLower(part))
+			continue
+		}
+		color, err := c.ParseColor(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color or style %q: %w", part, err)
+		}
+		opts.FgColor = color.Hex()
+	}
+	return opts, nil
+}
+
+/*
+parseInterspersed parses flags placed before or after the positional arguments.
+
+Parameters:
+  - fs: The flag set.
+  - args: The arguments.
+
+Return:
+  - []string: The positional arguments.
+  - error: flag.ErrHelp if help was requested, errUsage if the flags are invalid (the error and
+    the usage are printed by the flag set).
+*/
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		} else if err != nil {
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+/* runTail runs the tail subcommand */
+func runTail(args []string) error {
+	var rules []c.HighlightRule
+	fs := flag.NewFlagSet("tail", flag.ContinueOnError)
+	follow := fs.Bool("f", false, "follow the file, across rotations")
+	fs.Var(ruleFlag{rules: &rules}, "match", "highlight the matches of a pattern (PATTERN:COLOR, e.g. ERROR:red+bold)")
+	fs.Var(ruleFlag{rules: &rules, line: true}, "line", "highlight the lines matching a pattern (PATTERN:COLOR)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: colorize tail [-f] [--match PATTERN:COLOR]... [--line PATTERN:COLOR]... [FILE]")
+		fs.PrintDefaults()
+	}
+
+	files, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(files) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var r io.Reader = os.Stdin
+	switch {
+	case len(files) == 0 || files[0] == "-":
+	case *follow:
+		f, err := c.FollowFile(files[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	default:
+		f, err := os.Open(files[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := c.Tail(ctx, r, rules, os.Stdout); err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
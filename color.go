@@ -0,0 +1,142 @@
+package colorize
+
+import "os"
+
+/*
+The Color type represents an opaque RGB color, parsed once (see ParseColor) and reused without
+re-parsing. It implements the color.Color interface of the image/color package, so it can be used
+with the standard library and image-processing code.
+*/
+type Color struct {
+	R uint8
+	G uint8
+	B uint8
+}
+
+/*
+ParseColor parses a color in any of the formats accepted by FormatText (e.g., "#FF8000", "#f80",
+"hsl(30, 100%, 50%)").
+
+Parameters:
+  - s: The color.
+
+Return:
+  - Color: The parsed color.
+  - error: An error if the color is invalid.
+
+Example:
+
+	orange, err := c.ParseColor("#FF8000")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(orange.Fg() + "warning" + c.Reset)
+*/
+func ParseColor(s string) (Color, error) {
+	col, err := getColor(s)
+	if err != nil {
+		return Color{}, err
+	}
+	return Color{col.r, col.g, col.b}, nil
+}
+
+/*
+internal returns the color struct the rendering functions work with.
+
+Return:
+  - *color: A pointer to the color struct representing the RGB color.
+*/
+func (c Color) internal() *color {
+	return &color{c.R, c.G, c.B}
+}
+
+/*
+Hex returns the hexadecimal color code of the color.
+
+Return:
+  - string: The hexadecimal color code (e.g., "#FF8000").
+*/
+func (c Color) Hex() string {
+	return RGB(c.R, c.G, c.B)
+}
+
+/*
+RGB returns the components of the color.
+
+Return:
+  - uint8: The red component.
+  - uint8: The green component.
+  - uint8: The blue component.
+*/
+func (c Color) RGB() (uint8, uint8, uint8) {
+	return c.R, c.G, c.B
+}
+
+/*
+Xterm returns the closest Xterm (256-color) palette index of the color.
+
+Return:
+  - uint8: The Xterm color code.
+*/
+func (c Color) Xterm() uint8 {
+	return rgbToXterm(c.internal())
+}
+
+/*
+code returns the ANSI escape code of the color at the current color level.
+
+Parameters:
+  - ctx: The color context (background or foreground).
+
+Return:
+  - string: The ANSI escape code, or an empty string if colors are disabled or unsupported, or if
+    stdout isn't a terminal in terminal-only mode (see SetTerminalOnly).
+*/
+func (c Color) code(ctx ColorContext) string {
+	if !terminalAllows(os.Stdout) {
+		return ""
+	}
+	switch ColorLevel() {
+	case LevelTrueColor:
+		return getTCCode(c.internal(), ctx)
+	case LevelAnsi256:
+		record(MetricDowngrades, 1)
+		return getXTCode(c.internal(), ctx)
+	case LevelAnsi16:
+		record(MetricDowngrades, 1)
+		return getAnsi16Code(c.internal(), ctx)
+	}
+	return ""
+}
+
+/*
+Fg returns the ANSI escape code setting the color as foreground, at the current color level (the
+color is approximated to the Xterm palette or the 16 ANSI colors when needed). Unlike GetColor, no
+error is returned: the code is empty if colors are disabled or unsupported.
+
+Return:
+  - string: The ANSI escape code. Append Reset to the colored text.
+*/
+func (c Color) Fg() string {
+	return c.code(foreground)
+}
+
+/*
+Bg returns the ANSI escape code setting the color as background (see Fg).
+
+Return:
+  - string: The ANSI escape code. Append Reset to the colored text.
+*/
+func (c Color) Bg() string {
+	return c.code(background)
+}
+
+/*
+String returns the hexadecimal color code of the color, so that it prints as such.
+
+Return:
+  - string: The hexadecimal color code (e.g., "#FF8000").
+*/
+func (c Color) String() string {
+	return c.Hex()
+}
@@ -0,0 +1,51 @@
+package colorize
+
+import (
+	"fmt"
+	"testing"
+)
+
+/* TestParseColor tests the ParseColor function */
+func TestParseColor(t *testing.T) {
+	for _, s := range []string{"#FF8000", "ff8000", "hsl(30, 100%, 50%)"} {
+		if col, err := ParseColor(s); err != nil || col != (Color{255, 128, 0}) {
+			t.Errorf("%s: expected {255 128 0} but got %v, %v", s, col, err)
+		}
+	}
+	if _, err := ParseColor("#FF00"); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+}
+
+/* TestColorMethods tests the methods of Color */
+func TestColorMethods(t *testing.T) {
+	// defer restore
+	defer restore()
+
+	col := Color{255, 0, 0}
+	if col.Hex() != "#FF0000" || fmt.Sprint(col) != "#FF0000" {
+		t.Errorf("Expected #FF0000 but got %s", col.Hex())
+	}
+	if r, g, b := col.RGB(); r != 255 || g != 0 || b != 0 {
+		t.Errorf("Expected 255 0 0 but got %d %d %d", r, g, b)
+	}
+	if col.Xterm() != rgbToXterm(&color{255, 0, 0}) {
+		t.Errorf("Expected %d but got %d", rgbToXterm(&color{255, 0, 0}), col.Xterm())
+	}
+
+	// the codes match GetColor at every level
+	for _, profile := range benchProfiles[:3] {
+		trueColor, xTerm, ansi16 = profile.trueColor, profile.xTerm, profile.ansi16
+		fg, _ := GetColor("#FF0000", foreground)
+		bg, _ := GetColor("#FF0000", background)
+		if col.Fg() != fg || col.Bg() != bg {
+			t.Errorf("%s: expected %q and %q but got %q and %q", profile.name, fg, bg, col.Fg(), col.Bg())
+		}
+	}
+
+	// no color support
+	trueColor, xTerm, ansi16 = false, false, false
+	if col.Fg() != "" || col.Bg() != "" {
+		t.Error("Expected empty codes")
+	}
+}
@@ -1,5 +1,5 @@
 /*
-Package colorize provides functions for formatting text in true color or Xterm approximations depending on the system support.
+Package colorize provides functions for formatting text in true color, or Xterm and 16-color ANSI approximations, depending on the system support.
 
 When importing this package, it's recommended to use the alias "c" for brevity:
 
@@ -15,6 +15,7 @@ package colorize
 
 import (
 	"fmt"
+	imagecolor "image/color"
 	"math"
 	"os"
 	"regexp"
@@ -25,7 +26,7 @@ import (
 /* Package specific error type and functions */
 
 /*
-colorizeErr represents a non-fatal error specific to the colorize package.
+Error represents a non-fatal error specific to the colorize package.
 
 This type is used to encapsulate errors that occur within the colorize package.
 It provides a name for categorizing the error and a message describing the error.
@@ -35,18 +36,29 @@ Note that whenever an error occurs, the original text string is returned unmodif
 During development, it's recommended to handle these errors appropriately to ensure the integrity of the formatted text.
 In production environments, omitting error handling or simply logging them out in favor of displaying the unformatted text may be acceptable, depending on the application's requirements.
 
+Errors match the sentinel errors of their category with errors.Is (e.g., errors.Is(err,
+ErrInvalidHex)), and errors.As retrieves them to inspect their name and message.
+
 Fields:
 
-	name string: A name categorizing the error.
-	msg  string: A message describing the error.
+	Name string: A name categorizing the error (e.g., "HEXERR").
+	Msg  string: A message describing the error.
 */
-type colorizeErr struct {
-	name string
-	msg  string
+type Error struct {
+	Name string
+	Msg  string
 }
 
+var (
+	/* Sentinel errors, matching the errors of their category with errors.Is */
+	ErrInvalidHex     = &Error{Name: "HEXERR", Msg: "invalid hex code"}
+	ErrNoColorSupport = &Error{Name: "SYSNOCOLOR", Msg: "System does not support true color, xterm or ansi colors"}
+	ErrInvalidStyle   = &Error{Name: "UNKNOWNSTYLE", Msg: "unknown style"}
+	ErrNoOptions      = &Error{Name: "NOOPTIONS", Msg: "No options provided"}
+)
+
 /*
-newColorizeErr creates a new instance of colorizeErr with the provided name and message.
+newColorizeErr creates a new instance of Error with the provided name and message.
 
 Parameters:
 
@@ -55,14 +67,14 @@ Parameters:
 
 Returns:
 
-	*colorizeErr: A pointer to the newly created colorizeErr instance.
+	*Error: A pointer to the newly created Error instance.
 */
-func newColorizeErr(name string, msg string) *colorizeErr {
-	return &colorizeErr{name: name, msg: msg}
+func newColorizeErr(name string, msg string) *Error {
+	return &Error{Name: name, Msg: msg}
 }
 
 /*
-Error returns the string representation of the colorizeErr.
+Error returns the string representation of the Error.
 
 This method formats the error with the following pattern: "<name>: <message>".
 
@@ -70,8 +82,28 @@ Returns:
 
 	string: The string representation of the error.
 */
-func (e *colorizeErr) Error() string {
-	return fmt.Sprintf("%s: %s", e.name, e.msg)
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, e.Msg)
+}
+
+/*
+Is reports whether the error belongs to the category of the target, so that errors.Is matches
+errors of the same name (e.g., any invalid hex code matches ErrInvalidHex).
+
+Parameters:
+  - target: The error to compare with.
+
+Return:
+  - bool: true if the target is an Error or a Warning of the same name, false otherwise.
+*/
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case *Error:
+		return t != nil && t.Name == e.Name
+	case Warning:
+		return t.Name == e.Name
+	}
+	return false
 }
 
 /* The ColorContext type represents the context of the color (background or foreground) */
@@ -88,6 +120,16 @@ type Options struct {
 	BgColor string   // background color
 	FgColor string   // foreground color
 	Styles  []string // text style(s): bold, italic, underline, blink, reverse, hidden and stroke
+
+	BgColor256 *uint8 // background Xterm palette index, overriding BgColor (see Index256)
+	FgColor256 *uint8 // foreground Xterm palette index, overriding FgColor (see Index256)
+
+	Bg imagecolor.Color // background color of the image/color package, overriding BgColor (see ColorOf)
+	Fg imagecolor.Color // foreground color of the image/color package, overriding FgColor (see ColorOf)
+
+	PromptMode PromptMode // wraps escape sequences for embedding in a shell prompt (see RenderPrompt)
+	Graceful   bool       // returns the plain text without an error when the system has no color support
+	Lenient    bool       // skips invalid colors instead of returning an error (see SetValidationMode)
 }
 
 /* The color type represents an RGB color */
@@ -120,8 +162,9 @@ const (
 
 var (
 	/* System color support */
-	trueColor = os.Getenv("COLORTERM") == "truecolor"
-	xTerm     = os.Getenv("TERM") == "xterm"
+	trueColor = detectTrueColor()
+	xTerm     = detectXTerm()
+	ansi16    = detectAnsi16()
 
 	styles = map[string]string{
 		"bold":      "\033[1m",
@@ -135,9 +178,8 @@ var (
 
 	// regex for hex color code
 	regex = regexp.MustCompile(`^#?([0-9a-fA-F]{2})([0-9a-fA-F]{2})([0-9a-fA-F]{2})$`)
-
-	// color pointer
-	colorPtr *color
+	// regex for shorthand hex color code (the # prefix is required, so that words like "bad" aren't colors)
+	shortRegex = regexp.MustCompile(`^#([0-9a-fA-F])([0-9a-fA-F])([0-9a-fA-F])$`)
 )
 
 /*
@@ -146,12 +188,16 @@ validateHex validates the provided hexadecimal color code.
 If the hex string is invalid, an error is returned.
 
 Parameters:
-  - hex: The hexadecimal color code, either with or without the # prefix (e.g., "#RRGGBB").
+  - hex: The hexadecimal color code, either with or without the # prefix (e.g., "#RRGGBB"), the
+    CSS shorthand with the # prefix (e.g., "#RGB"), with an alpha channel and the # prefix (e.g.,
+    "#RRGGBBAA"), or a CSS hsl() color (e.g., "hsl(210, 50%, 40%)").
 */
 func validateHex(hex string) error {
-	if !regex.MatchString(hex) {
-		err := newColorizeErr("HEXERR", fmt.Sprintf("invalid hex code: %s", hex))
-		return fmt.Errorf(err.Error())
+	if _, ok := parseHSL(hex); ok {
+		return nil
+	}
+	if !regex.MatchString(hex) && !shortRegex.MatchString(hex) && !alphaRegex.MatchString(hex) {
+		return newColorizeErr("HEXERR", fmt.Sprintf("invalid hex code: %s", hex))
 	}
 	return nil
 }
@@ -160,7 +206,9 @@ func validateHex(hex string) error {
 getColor converts a hexadecimal color code to RGB representation.
 
 Parameters:
-  - hex: The hexadecimal color code (e.g., "#RRGGBB").
+  - hex: The hexadecimal color code (e.g., "#RRGGBB", "#RGB" whose digits are doubled as in CSS, or
+    "#RRGGBBAA" whose color is blended against the base color, see SetAlphaBase), or a CSS hsl()
+    color (e.g., "hsl(210, 50%, 40%)").
 
 Return:
   - *color: A pointer to the color struct representing the RGB color.
@@ -172,15 +220,29 @@ func getColor(hex string) (*color, error) {
 		return nil, err
 	}
 
+	if col, ok := parseHSL(hex); ok {
+		return col, nil
+	}
+
+	// alpha: the color is blended against the base color
+	if match := alphaRegex.FindStringSubmatch(hex); match != nil {
+		alpha, _ := strconv.ParseUint(match[4], 16, 8)
+		col, _ := getColor(match[1] + match[2] + match[3])
+		return blendAlpha(col, uint8(alpha)), nil
+	}
+
+	// shorthand: each digit is doubled ("#f00" is "#ff0000")
+	if match := shortRegex.FindStringSubmatch(hex); match != nil {
+		hex = match[1] + match[1] + match[2] + match[2] + match[3] + match[3]
+	}
+
 	// errors are omitted due to regex
 	match := regex.FindStringSubmatch(hex)
 	r, _ := strconv.ParseUint(match[1], 16, 8)
 	g, _ := strconv.ParseUint(match[2], 16, 8)
 	b, _ := strconv.ParseUint(match[3], 16, 8)
 
-	colorPtr = &color{uint8(r), uint8(g), uint8(b)}
-
-	return colorPtr, nil
+	return &color{uint8(r), uint8(g), uint8(b)}, nil
 }
 
 /*
@@ -197,7 +259,7 @@ Parameters:
 
 Return:
   - string: The ANSI escape code for setting true color.
-  - error: An error if the provided hex code is invalid or the system does not support true color or xterm.
+  - error: An error if the provided hex code is invalid (unless the validation mode is lenient) or the system does not support true color or xterm.
 
 Example:
 
@@ -217,18 +279,33 @@ func GetColor(hex string, ctx ColorContext) (string, error) {
 	var code string = ""
 
 	// get color
-	colorPtr, err := getColor(hex)
+	col, err := getColor(hex)
 	if err != nil {
+		if lenientMode {
+			return code, nil
+		}
 		return code, err
 	}
 
 	// set code based on system support
-	if trueColor {
-		code = getTCCode(colorPtr, ctx)
+	if noColor || !terminalAllows(os.Stdout) {
+		return code, nil
+	} else if trueColor {
+		code = getTCCode(col, ctx)
 	} else if xTerm {
-		code = getXTCode(colorPtr, ctx)
-	} else {
-		err = newColorizeErr("SYSNOCOLOR", "System does not support true color or xterm")
+		code = getXTCode(col, ctx)
+		record(MetricDowngrades, 1)
+		if strictMode {
+			return "", Warnings{newWarning("DOWNGRADE", fmt.Sprintf("%s color %s approximated to xterm %d", ctx, hex, rgbToXterm(col)))}
+		}
+	} else if ansi16 {
+		code = getAnsi16Code(col, ctx)
+		record(MetricDowngrades, 1)
+		if strictMode {
+			return "", Warnings{newWarning("DOWNGRADE", fmt.Sprintf("%s color %s approximated to ansi %d", ctx, hex, rgbToAnsi16(col)))}
+		}
+	} else if !graceful {
+		err = newColorizeErr("SYSNOCOLOR", "System does not support true color, xterm or ansi colors")
 	}
 
 	return code, err
@@ -320,7 +397,7 @@ Parameters:
 
 Return:
   - string: The formatted text.
-  - error: An error if the provided options are invalid or the system does not support true color or Xterm.
+  - error: An error if the provided options are invalid or the system does not support true color, Xterm or the 16 ANSI colors.
 
 Example:
 
@@ -335,28 +412,99 @@ Example:
 Note: Valid styles include: bold, italic, underline, blink, reverse, hidden and stroke.
 */
 func FormatText(text string, options *Options) (string, error) {
-	builder := strings.Builder{}
+	return formatText(text, options, terminalAllows(os.Stdout))
+}
+
+/*
+hasOptions reports whether the given options set a color or a style.
+
+Parameters:
+  - options: The formatting options, or nil.
+
+Return:
+  - bool: true if the options set a color or a style, false otherwise.
+*/
+func hasOptions(options *Options) bool {
+	return options != nil && (options.BgColor != "" || options.FgColor != "" || len(options.Styles) > 0 ||
+		options.BgColor256 != nil || options.FgColor256 != nil || options.Bg != nil || options.Fg != nil)
+}
+
+/*
+formatText formats the given text with the specified options (see FormatText).
+
+Parameters:
+  - text: The text to be formatted.
+  - options: The formatting options.
+  - allowed: false if the output isn't a terminal in terminal-only mode, returning the text
+    unmodified.
+
+Return:
+  - string: The formatted text.
+  - error: An error if the provided options are invalid or the system does not support true color, Xterm or the 16 ANSI colors.
+*/
+func formatText(text string, options *Options, allowed bool) (string, error) {
+	record(MetricFormatCalls, 1)
 
 	// no options provided
-	if options == nil || (options.BgColor == "" && options.FgColor == "" && len(options.Styles) == 0) {
-		err := fmt.Errorf("No options provided")
-		return text, err
+	if !hasOptions(options) {
+		return text, newColorizeErr("NOOPTIONS", "No options provided")
+	}
+
+	// colors disabled by the user (NO_COLOR)
+	if noColor {
+		return text, nil
+	}
+
+	// output isn't a terminal (terminal-only mode)
+	if !allowed {
+		return text, nil
 	}
 
 	// no system support
-	if !trueColor && !xTerm {
-		err := newColorizeErr("SYSNOCOLOR", "System does not support true color or xterm")
-		return text, fmt.Errorf(err.Error())
+	if !trueColor && !xTerm && !ansi16 {
+		if graceful || options.Graceful {
+			return text, nil
+		}
+		return text, newColorizeErr("SYSNOCOLOR", "System does not support true color, xterm or ansi colors")
+	}
+
+	return renderText(text, options, ColorLevel())
+}
+
+/*
+renderText formats the given text with the specified options at the given color level, downgrading
+the colors to the palette of the level.
+
+Parameters:
+  - text: The text to be formatted.
+  - options: The formatting options.
+  - level: The color level (LevelTrueColor, LevelAnsi256 or LevelAnsi16).
+
+Return:
+  - string: The formatted text.
+  - error: An error if the provided options are invalid, or the downgrades in strict mode.
+*/
+func renderText(text string, options *Options, level Level) (string, error) {
+	options = options.withImageColors()
+	if lenientMode || options.Lenient {
+		options = options.withoutInvalidColors()
 	}
+	builder := strings.Builder{}
+
+	// warnings collected in strict mode
+	var warnings Warnings
 
 	// options provided
 	if len(options.Styles) > 0 {
 		for _, s := range options.Styles {
+			if (strictMode || strictValidation) && styles[s] == "" {
+				warnings = append(warnings, newWarning("UNKNOWNSTYLE", fmt.Sprintf("unknown style: %s", s)))
+			}
 			builder.WriteString(styles[s])
 		}
 	}
-	if trueColor {
-		if options.BgColor != "" {
+	if level == LevelTrueColor {
+		if options.BgColor != "" && options.BgColor256 == nil {
 			bgColor, err := getColor(options.BgColor)
 			if err != nil {
 				// HEXERR
@@ -364,29 +512,81 @@ func FormatText(text string, options *Options) (string, error) {
 			}
 			builder.WriteString(getTCCode(bgColor, background))
 		}
-		if options.FgColor != "" {
+		if options.FgColor != "" && options.FgColor256 == nil {
 			fgColor, err := getColor(options.FgColor)
 			if err != nil {
 				return text, err
 			}
 			builder.WriteString(getTCCode(fgColor, foreground))
 		}
-	} else {
-		// xTerm
-		if options.BgColor != "" {
+	} else if level == LevelAnsi256 {
+		if options.BgColor != "" && options.BgColor256 == nil {
 			bgColor, err := getColor(options.BgColor)
 			if err != nil {
 				return text, err
 			}
 			builder.WriteString(getXTCode(bgColor, background))
+			record(MetricDowngrades, 1)
+			if strictMode {
+				warnings = append(warnings, newWarning("DOWNGRADE", fmt.Sprintf("background color %s approximated to xterm %d", options.BgColor, rgbToXterm(bgColor))))
+			}
 		}
-		if options.FgColor != "" {
+		if options.FgColor != "" && options.FgColor256 == nil {
 			fgColor, err := getColor(options.FgColor)
 			if err != nil {
 				return text, err
 			}
 			builder.WriteString(getXTCode(fgColor, foreground))
+			record(MetricDowngrades, 1)
+			if strictMode {
+				warnings = append(warnings, newWarning("DOWNGRADE", fmt.Sprintf("foreground color %s approximated to xterm %d", options.FgColor, rgbToXterm(fgColor))))
+			}
+		}
+	} else {
+		// 16 basic colors
+		if options.BgColor != "" && options.BgColor256 == nil {
+			bgColor, err := getColor(options.BgColor)
+			if err != nil {
+				return text, err
+			}
+			builder.WriteString(getAnsi16Code(bgColor, background))
+			record(MetricDowngrades, 1)
+			if strictMode {
+				warnings = append(warnings, newWarning("DOWNGRADE", fmt.Sprintf("background color %s approximated to ansi %d", options.BgColor, rgbToAnsi16(bgColor))))
+			}
 		}
+		if options.FgColor != "" && options.FgColor256 == nil {
+			fgColor, err := getColor(options.FgColor)
+			if err != nil {
+				return text, err
+			}
+			builder.WriteString(getAnsi16Code(fgColor, foreground))
+			record(MetricDowngrades, 1)
+			if strictMode {
+				warnings = append(warnings, newWarning("DOWNGRADE", fmt.Sprintf("foreground color %s approximated to ansi %d", options.FgColor, rgbToAnsi16(fgColor))))
+			}
+		}
+	}
+
+	// Xterm palette indexes
+	if options.BgColor256 != nil {
+		code, warning := getIndexCode(*options.BgColor256, background, level)
+		if warning != nil && strictMode {
+			warnings = append(warnings, *warning)
+		}
+		builder.WriteString(code)
+	}
+	if options.FgColor256 != nil {
+		code, warning := getIndexCode(*options.FgColor256, foreground, level)
+		if warning != nil && strictMode {
+			warnings = append(warnings, *warning)
+		}
+		builder.WriteString(code)
+	}
+
+	// strict mode: degraded rendering is reported instead of returned
+	if len(warnings) > 0 {
+		return text, warnings
 	}
 
 	builder.WriteString(text)
@@ -395,8 +595,10 @@ func FormatText(text string, options *Options) (string, error) {
 		return builder.String(), nil
 	}
 	builder.WriteString(reset)
+	formatted := RenderPrompt(builder.String(), options.PromptMode)
+	record(MetricEscapeBytes, len(formatted)-len(text))
 
-	return builder.String(), nil
+	return formatted, nil
 }
 
 /*
@@ -408,7 +610,7 @@ Parameters:
 
 Return:
   - string: The formatted text.
-  - error: An error if the provided color is invalid or the system does not support true color or Xterm.
+  - error: An error if the provided color is invalid or the system does not support true color, Xterm or the 16 ANSI colors.
 
 Example:
 
@@ -433,7 +635,7 @@ Parameters:
 
 Return:
   - string: The formatted text.
-  - error: An error if the provided color is invalid or the system does not support true color or Xterm.
+  - error: An error if the provided color is invalid or the system does not support true color, Xterm or the 16 ANSI colors.
 
 Example:
 
@@ -454,6 +656,7 @@ StyleText formats the given text with the specified styles.
 
 Unlike ForegroundText and BackgroundText, StyleText, if the system does not support any of the
 provided styles, no error is returned, since no escape sequences are generated for the invalid styles.
+In strict mode (see SetStrictMode), the text is returned unmodified if any of the styles is invalid.
 
 Parameters:
   - text: The string to be formatted.
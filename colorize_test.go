@@ -1,6 +1,8 @@
 package colorize
 
 import (
+	"errors"
+	"os"
 	"testing"
 )
 
@@ -12,6 +14,9 @@ var (
 		"#FF00000",
 		"#FF00",
 		"#FF000H",
+		"FFF",
+		"#FFFF",
+		"#FG0",
 	}
 	validHex = []string{
 		"#FFFFFF",
@@ -22,6 +27,8 @@ var (
 		"ABCDEF",
 		"#12abAB",
 		"12abAB",
+		"#FFF",
+		"#f0a",
 	}
 	validOpts = []*Options{
 		{FgColor: "#FF0000"},
@@ -40,14 +47,31 @@ var (
 		{BgColor: "#0000FF0", Styles: []string{"bold-italic"}},
 		{FgColor: "#FF00000", BgColor: "#0000FF0", Styles: []string{"bold-italic"}},
 	}
-	prevTrueColor = trueColor
-	prevXTerm     = xTerm
 )
 
+/* TestMain runs the tests in a true color terminal, whatever the environment of the test run */
+func TestMain(m *testing.M) {
+	restore()
+	os.Exit(m.Run())
+}
+
 // defer func
 func restore() {
-	trueColor = prevTrueColor
-	xTerm = prevXTerm
+	// the detected environment (NO_COLOR, FORCE_COLOR, CLICOLOR_FORCE, TERM, CI) is pinned
+	forceLevel, forceSource = -1, ""
+	termColorLevel, termSource = LevelTrueColor, `COLORTERM="truecolor"`
+	trueColor = true
+	xTerm = true
+	ansi16 = true
+	noColor = false
+	motionPolicy = MotionFull
+	terminalOnly = false
+	strictMode = false
+	strictValidation = false
+	levelOverride = false
+	debugGrid = false
+	translator = nil
+	lenientMode = false
 }
 
 /* TestValidateHex tests the validateHex function */
@@ -69,6 +93,21 @@ func TestValidateHex(t *testing.T) {
 	}
 }
 
+/* TestGetColorShorthand tests the expansion of shorthand hex codes */
+func TestGetColorShorthand(t *testing.T) {
+	tests := map[string]color{
+		"#f00": {255, 0, 0},
+		"#FFF": {255, 255, 255},
+		"#1a9": {0x11, 0xAA, 0x99},
+	}
+	for hex, expected := range tests {
+		col, err := getColor(hex)
+		if err != nil || *col != expected {
+			t.Errorf("%s: expected %v but got %v, %v", hex, expected, col, err)
+		}
+	}
+}
+
 /* TestNewColorizeErr tests the newColorizeErr function */
 func TestNewColorizeErr(t *testing.T) {
 	name := "test"
@@ -117,6 +156,7 @@ func TestGetColor(t *testing.T) {
 
 	// valid hex, no color support
 	xTerm = false
+	ansi16 = false
 	for _, hex := range validHex {
 		_, err := GetColor(hex, foreground)
 		if err == nil {
@@ -189,6 +229,7 @@ func TestFormatText(t *testing.T) {
 	// test for non-supported true color and xterm
 	trueColor = false
 	xTerm = false
+	ansi16 = false
 	for _, opt := range validOpts {
 		_, err = FormatText("", opt)
 		if err == nil {
@@ -279,6 +320,7 @@ func TestForegroundText(t *testing.T) {
 
 	// valid colors with no xterm support
 	xTerm = false
+	ansi16 = false
 	for _, color := range validColors {
 		_, err := ForegroundText("", color)
 		if err == nil {
@@ -331,6 +373,7 @@ func TestBackgroundText(t *testing.T) {
 
 	// valid colors with no xterm support
 	xTerm = false
+	ansi16 = false
 	for _, color := range validColors {
 		_, err := BackgroundText("", color)
 		if err == nil {
@@ -338,3 +381,33 @@ func TestBackgroundText(t *testing.T) {
 		}
 	}
 }
+
+/* TestErrorIs tests that the errors match their sentinel errors with errors.Is and errors.As */
+func TestErrorIs(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	_, err := FormatText("text", &Options{FgColor: "#GG0000"})
+	var colorizeErr *Error
+	if !errors.Is(err, ErrInvalidHex) || errors.Is(err, ErrNoColorSupport) || !errors.As(err, &colorizeErr) || colorizeErr.Name != "HEXERR" {
+		t.Errorf("Expected an invalid hex error but got %v", err)
+	}
+	if _, err := FormatText("text", &Options{}); !errors.Is(err, ErrNoOptions) {
+		t.Errorf("Expected a no options error but got %v", err)
+	}
+
+	// strict mode warnings
+	SetStrictMode(true)
+	defer SetStrictMode(false)
+	_, err = FormatText("text", &Options{Styles: []string{"shiny"}})
+	if !errors.Is(err, ErrInvalidStyle) || errors.Is(err, ErrInvalidHex) {
+		t.Errorf("Expected an invalid style warning but got %v", err)
+	}
+
+	trueColor, xTerm, ansi16 = false, false, false
+	SetStrictMode(false)
+	if _, err := FormatText("text", &Options{FgColor: "#FF0000"}); !errors.Is(err, ErrNoColorSupport) {
+		t.Errorf("Expected a no color support error but got %v", err)
+	}
+}
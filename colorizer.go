@@ -0,0 +1,240 @@
+package colorize
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+The Colorizer type formats and prints text for a given writer with its own color level, so that a
+process can write true color to stdout, the Xterm palette to a PTY and plain text to a log file at
+the same time, independently of the package-level detection.
+
+Like Printer, it writes through a SyncWriter, each call writing the whole formatted text at once,
+and formatting failures are written unformatted without an error.
+*/
+type Colorizer struct {
+	w     *SyncWriter
+	level Level
+}
+
+/*
+DetectLevel returns the color level for output written to the given writer: the level of the
+package (see ColorLevel) if the writer is a terminal or colors are forced (FORCE_COLOR,
+CLICOLOR_FORCE or SetColorLevel), LevelNone otherwise.
+
+Parameters:
+  - w: The writer.
+
+Return:
+  - Level: The color level.
+*/
+func DetectLevel(w io.Writer) Level {
+	if forceLevel > 0 || levelOverride || writerIsTerminal(w) {
+		return ColorLevel()
+	}
+	return LevelNone
+}
+
+/*
+NewColorizer returns a Colorizer writing to the given writer, with the color level detected for it
+(see DetectLevel): files and pipes get plain text unless colors are forced.
+
+Parameters:
+  - w: The writer to print to.
+
+Return:
+  - *Colorizer: The newly created colorizer.
+
+Example:
+
+	stdout := c.NewColorizer(os.Stdout)
+	logs := c.NewColorizer(logFile) // plain text
+	pty := c.NewColorizer(ptmx).WithLevel(c.LevelAnsi256)
+*/
+func NewColorizer(w io.Writer) *Colorizer {
+	return &Colorizer{w: NewSyncWriter(w), level: DetectLevel(w)}
+}
+
+/*
+WithLevel returns a Colorizer writing to the same writer with the given color level.
+
+Parameters:
+  - level: The color level.
+
+Return:
+  - *Colorizer: The new colorizer.
+*/
+func (c *Colorizer) WithLevel(level Level) *Colorizer {
+	return &Colorizer{w: c.w, level: level}
+}
+
+/*
+Level returns the color level of the Colorizer.
+
+Return:
+  - Level: The color level.
+*/
+func (c *Colorizer) Level() Level {
+	return c.level
+}
+
+/*
+Writer returns the SyncWriter the Colorizer writes to.
+
+Return:
+  - *SyncWriter: The synchronized writer.
+*/
+func (c *Colorizer) Writer() *SyncWriter {
+	return c.w
+}
+
+/*
+FormatText formats the given text with the specified options at the color level of the Colorizer
+(see the FormatText function). At LevelNone, the text is returned unmodified without an error.
+
+Parameters:
+  - text: The text to be formatted.
+  - options: The formatting options.
+
+Return:
+  - string: The formatted text.
+  - error: An error if the provided options are invalid.
+*/
+func (c *Colorizer) FormatText(text string, options *Options) (string, error) {
+	record(MetricFormatCalls, 1)
+	if !hasOptions(options) {
+		return text, newColorizeErr("NOOPTIONS", "No options provided")
+	}
+	if c.level <= LevelNone {
+		return text, nil
+	}
+	return renderText(text, options, min(c.level, LevelTrueColor))
+}
+
+/*
+format formats the given text with the given options, returning the text unmodified if the
+options are nil or formatting fails. Trailing newlines are kept out of the formatted region.
+
+Parameters:
+  - text: The text to be formatted.
+  - opts: The formatting options, or nil.
+
+Return:
+  - string: The formatted text.
+*/
+func (c *Colorizer) format(text string, opts *Options) string {
+	if opts == nil {
+		return text
+	}
+	trimmed := strings.TrimRight(text, "\n")
+	formatted, _ := c.FormatText(trimmed, opts)
+	return formatted + text[len(trimmed):]
+}
+
+/*
+Print formats its operands like fmt.Print, applies the given options and writes the result.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (c *Colorizer) Print(opts *Options, a ...any) (int, error) {
+	return io.WriteString(c.w, c.format(fmt.Sprint(a...), opts))
+}
+
+/*
+Printf formats according to a format specifier like fmt.Printf, applies the given options and
+writes the result.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (c *Colorizer) Printf(opts *Options, format string, a ...any) (int, error) {
+	return io.WriteString(c.w, c.format(fmt.Sprintf(auditFormat(format, a), a...), opts))
+}
+
+/*
+Println formats its operands like fmt.Println, applies the given options and writes the result.
+The trailing newline is written after the reset code.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (c *Colorizer) Println(opts *Options, a ...any) (int, error) {
+	return io.WriteString(c.w, c.format(fmt.Sprintln(a...), opts))
+}
+
+/*
+Fprint formats its operands like fmt.Fprint, applies the given options and writes the result to w,
+with the color level detected for w (see DetectLevel): files, buffers and sockets get plain text
+unless colors are forced.
+
+Parameters:
+  - w: The writer.
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func Fprint(w io.Writer, opts *Options, a ...any) (int, error) {
+	return NewColorizer(w).Print(opts, a...)
+}
+
+/*
+Fprintf formats according to a format specifier like fmt.Fprintf, applies the given options and
+writes the result to w, with the color level detected for w (see DetectLevel).
+
+Parameters:
+  - w: The writer.
+  - opts: The formatting options, or nil for plain text.
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+
+Example:
+
+	c.Fprintf(os.Stderr, &c.Options{FgColor: "#FF5F5F"}, "error: %v\n", err)
+*/
+func Fprintf(w io.Writer, opts *Options, format string, a ...any) (int, error) {
+	return NewColorizer(w).Printf(opts, format, a...)
+}
+
+/*
+Fprintln formats its operands like fmt.Fprintln, applies the given options and writes the result to
+w, with the color level detected for w (see DetectLevel). The trailing newline is written after the
+reset code.
+
+Parameters:
+  - w: The writer.
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func Fprintln(w io.Writer, opts *Options, a ...any) (int, error) {
+	return NewColorizer(w).Println(opts, a...)
+}
@@ -0,0 +1,110 @@
+package colorize
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+/* TestColorizer tests Colorizers with different color levels at the same time */
+func TestColorizer(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+	red := &Options{FgColor: "#FF0000", Styles: []string{"bold"}}
+
+	var tc, x256, a16, plain bytes.Buffer
+	colorizers := []*Colorizer{
+		NewColorizer(&tc).WithLevel(LevelTrueColor),
+		NewColorizer(&x256).WithLevel(LevelAnsi256),
+		NewColorizer(&a16).WithLevel(LevelAnsi16),
+		NewColorizer(&plain),
+	}
+	for _, c := range colorizers {
+		if _, err := c.Printf(red, "%d errors\n", 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{tc.String(), "\033[1m\033[38;2;255;0;0m3 errors" + reset + "\n"},
+		{x256.String(), "\033[1m\033[38;5;196m3 errors" + reset + "\n"},
+		{a16.String(), "\033[1m\033[91m3 errors" + reset + "\n"},
+		{plain.String(), "3 errors\n"},
+	}
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, test.got)
+		}
+	}
+
+	// buffers aren't terminals
+	if level := colorizers[3].Level(); level != LevelNone {
+		t.Errorf("Expected level none but got %s", level)
+	}
+	if _, err := colorizers[3].FormatText("hello", nil); err == nil {
+		t.Error("Expected an error without options")
+	}
+	if colorizers[0].Writer() != colorizers[0].WithLevel(LevelNone).Writer() {
+		t.Error("Expected WithLevel to share the writer")
+	}
+}
+
+/* TestDetectLevel tests the DetectLevel function */
+func TestDetectLevel(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer func(prev func(io.Writer) bool) { writerIsTerminal = prev }(writerIsTerminal)
+	defer func(level int) { forceLevel = level }(forceLevel)
+	writerIsTerminal = func(w io.Writer) bool { return w == os.Stdout }
+	forceLevel = -1
+	SetColorLevel(LevelAnsi256)
+	levelOverride = false
+
+	if level := DetectLevel(os.Stdout); level != LevelAnsi256 {
+		t.Errorf("Expected level ansi256 for a terminal but got %s", level)
+	}
+	var buf bytes.Buffer
+	if level := DetectLevel(&buf); level != LevelNone {
+		t.Errorf("Expected level none for a buffer but got %s", level)
+	}
+	forceLevel = 1
+	if level := DetectLevel(&buf); level != LevelAnsi256 {
+		t.Errorf("Expected forced colors for a buffer but got %s", level)
+	}
+}
+
+/* TestFprint tests the Fprint, Fprintf and Fprintln functions */
+func TestFprint(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer func(prev func(io.Writer) bool) { writerIsTerminal = prev }(writerIsTerminal)
+	defer func(level int) { forceLevel = level }(forceLevel)
+	terminal := &bytes.Buffer{}
+	writerIsTerminal = func(w io.Writer) bool { return w == terminal }
+	forceLevel = -1
+	trueColor = true
+	SetColorLevel(LevelTrueColor)
+	levelOverride = false
+	bold := &Options{Styles: []string{"bold"}}
+
+	// detection against the writer
+	plain := &bytes.Buffer{}
+	for _, w := range []*bytes.Buffer{terminal, plain} {
+		_, _ = Fprint(w, bold, "a", 1)
+		_, _ = Fprintf(w, bold, "%s=%d\n", "a", 1)
+		_, _ = Fprintln(w, nil, "a", 1)
+	}
+	if expected := styles["bold"] + "a1" + reset + styles["bold"] + "a=1" + reset + "\na 1\n"; terminal.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, terminal.String())
+	}
+	if plain.String() != "a1a=1\na 1\n" {
+		t.Errorf("Expected %q but got %q", "a1a=1\na 1\n", plain.String())
+	}
+}
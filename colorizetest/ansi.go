@@ -0,0 +1,226 @@
+/*
+Package colorizetest provides helpers for testing styled terminal output, such as the output of the
+colorize package.
+
+Styled strings are compared on a cell model (each character along with the style it's rendered
+with) rather than on raw bytes, so that tests don't break when equivalent escape sequences are
+emitted in a different order.
+*/
+package colorizetest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// regex for ANSI escape sequences (CSI and OSC)
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+/*
+The Style type represents the graphic rendition a character is rendered with.
+
+Colors are empty for the terminal default, "#RRGGBB" for true colors, "256:N" for the Xterm 256
+palette and "16:N" for the 16 basic colors (8-15 being the bright variants).
+*/
+type Style struct {
+	Fg        string
+	Bg        string
+	Bold      bool
+	Dim       bool
+	Italic    bool
+	Underline bool
+	Blink     bool
+	Reverse   bool
+	Hidden    bool
+	Strike    bool
+}
+
+/*
+String returns a human-readable description of the style (e.g., "fg=#FF0000 bold").
+
+Return:
+  - string: The description, "plain" for the default style.
+*/
+func (s Style) String() string {
+	parts := []string{}
+	if s.Fg != "" {
+		parts = append(parts, "fg="+s.Fg)
+	}
+	if s.Bg != "" {
+		parts = append(parts, "bg="+s.Bg)
+	}
+	for _, attr := range []struct {
+		on   bool
+		name string
+	}{
+		{s.Bold, "bold"}, {s.Dim, "dim"}, {s.Italic, "italic"}, {s.Underline, "underline"},
+		{s.Blink, "blink"}, {s.Reverse, "reverse"}, {s.Hidden, "hidden"}, {s.Strike, "strike"},
+	} {
+		if attr.on {
+			parts = append(parts, attr.name)
+		}
+	}
+	if len(parts) == 0 {
+		return "plain"
+	}
+	return strings.Join(parts, " ")
+}
+
+/*
+parseColor parses the color of an extended color parameter (38 or 48).
+
+Parameters:
+  - params: The parameters following 38 or 48.
+
+Return:
+  - string: The color, empty if it's malformed.
+  - int: The number of parameters consumed.
+*/
+func parseColor(params []int) (string, int) {
+	if len(params) >= 2 && params[0] == 5 {
+		return fmt.Sprintf("256:%d", params[1]), 2
+	}
+	if len(params) >= 4 && params[0] == 2 {
+		return fmt.Sprintf("#%02X%02X%02X", params[1], params[2], params[3]), 4
+	}
+	return "", len(params)
+}
+
+/*
+Apply applies the parameters of an SGR sequence (e.g., "1;38;2;255;0;0") to the style.
+
+Parameters:
+  - params: The parameters, as found between "\033[" and "m".
+*/
+func (s *Style) Apply(params string) {
+	values := []int{}
+	for _, field := range strings.Split(params, ";") {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			value = 0 // empty parameters mean 0
+		}
+		values = append(values, value)
+	}
+
+	for i := 0; i < len(values); i++ {
+		switch v := values[i]; {
+		case v == 0:
+			*s = Style{}
+		case v == 1:
+			s.Bold = true
+		case v == 2:
+			s.Dim = true
+		case v == 3:
+			s.Italic = true
+		case v == 4:
+			s.Underline = true
+		case v == 5:
+			s.Blink = true
+		case v == 7:
+			s.Reverse = true
+		case v == 8:
+			s.Hidden = true
+		case v == 9:
+			s.Strike = true
+		case v == 22:
+			s.Bold, s.Dim = false, false
+		case v == 23:
+			s.Italic = false
+		case v == 24:
+			s.Underline = false
+		case v == 25:
+			s.Blink = false
+		case v == 27:
+			s.Reverse = false
+		case v == 28:
+			s.Hidden = false
+		case v == 29:
+			s.Strike = false
+		case v >= 30 && v <= 37:
+			s.Fg = fmt.Sprintf("16:%d", v-30)
+		case v >= 90 && v <= 97:
+			s.Fg = fmt.Sprintf("16:%d", v-90+8)
+		case v >= 40 && v <= 47:
+			s.Bg = fmt.Sprintf("16:%d", v-40)
+		case v >= 100 && v <= 107:
+			s.Bg = fmt.Sprintf("16:%d", v-100+8)
+		case v == 38 || v == 48:
+			color, n := parseColor(values[i+1:])
+			if v == 38 {
+				s.Fg = color
+			} else {
+				s.Bg = color
+			}
+			i += n
+		case v == 39:
+			s.Fg = ""
+		case v == 49:
+			s.Bg = ""
+		}
+	}
+}
+
+/*
+The Cell type represents a character of a styled string, or an escape sequence other than SGR
+(e.g., an OSC 8 hyperlink), which is kept verbatim with Escape set.
+*/
+type Cell struct {
+	Text   string
+	Style  Style
+	Escape bool
+}
+
+/*
+String returns a human-readable description of the cell (e.g., "'E' fg=#FF0000 bold").
+
+Return:
+  - string: The description.
+*/
+func (c Cell) String() string {
+	if c.Escape {
+		return fmt.Sprintf("escape %q", c.Text)
+	}
+	return fmt.Sprintf("%q %s", c.Text, c.Style)
+}
+
+/*
+Parse splits a styled string into cells: one per character, with the style in effect, plus the
+escape sequences other than SGR. SGR sequences themselves don't produce cells, so equivalent
+sequences (e.g., "\033[1m\033[31m" and "\033[31;1m") produce the same cells.
+
+Parameters:
+  - s: The styled string.
+
+Return:
+  - []Cell: The cells.
+*/
+func Parse(s string) []Cell {
+	cells := []Cell{}
+	style := Style{}
+
+	addText := func(text string) {
+		for text != "" {
+			_, size := utf8.DecodeRuneInString(text)
+			cells = append(cells, Cell{Text: text[:size], Style: style})
+			text = text[size:]
+		}
+	}
+
+	pos := 0
+	for _, loc := range ansiRegex.FindAllStringIndex(s, -1) {
+		addText(s[pos:loc[0]])
+		seq := s[loc[0]:loc[1]]
+		if strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+			style.Apply(seq[2 : len(seq)-1])
+		} else {
+			cells = append(cells, Cell{Text: seq, Escape: true})
+		}
+		pos = loc[1]
+	}
+	addText(s[pos:])
+
+	return cells
+}
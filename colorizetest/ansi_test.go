@@ -0,0 +1,57 @@
+package colorizetest
+
+import (
+	"testing"
+)
+
+/* TestParse tests the Parse function */
+func TestParse(t *testing.T) {
+	cells := Parse("a\033[1;38;2;255;0;0mb\033[22;39;48;5;21mc\033[0m\033]8;;http://x\033\\d")
+	expected := []Cell{
+		{Text: "a"},
+		{Text: "b", Style: Style{Fg: "#FF0000", Bold: true}},
+		{Text: "c", Style: Style{Bg: "256:21"}},
+		{Text: "\033]8;;http://x\033\\", Escape: true},
+		{Text: "d"},
+	}
+	if len(cells) != len(expected) {
+		t.Fatalf("Expected %d cells but got %d: %v", len(expected), len(cells), cells)
+	}
+	for i := range cells {
+		if cells[i] != expected[i] {
+			t.Errorf("Cell %d: expected %v but got %v", i, expected[i], cells[i])
+		}
+	}
+}
+
+/* TestStyleApply tests the Apply method of the Style type */
+func TestStyleApply(t *testing.T) {
+	tests := []struct {
+		params   string
+		expected Style
+	}{
+		{"", Style{}},
+		{"2;3;4;5;7;8;9", Style{Dim: true, Italic: true, Underline: true, Blink: true, Reverse: true, Hidden: true, Strike: true}},
+		{"31;42", Style{Fg: "16:1", Bg: "16:2"}},
+		{"91;102", Style{Fg: "16:9", Bg: "16:10"}},
+		{"1;3;23", Style{Bold: true}},
+		{"38;5", Style{}},
+	}
+	for _, test := range tests {
+		style := Style{}
+		style.Apply(test.params)
+		if style != test.expected {
+			t.Errorf("%q: expected %v but got %v", test.params, test.expected, style)
+		}
+	}
+}
+
+/* TestStyleString tests the String method of the Style type */
+func TestStyleString(t *testing.T) {
+	if s := (Style{}).String(); s != "plain" {
+		t.Errorf("Expected plain but got %s", s)
+	}
+	if s := (Style{Fg: "#FF0000", Bg: "16:1", Bold: true, Strike: true}).String(); s != "fg=#FF0000 bg=16:1 bold strike" {
+		t.Errorf("Unexpected description: %s", s)
+	}
+}
@@ -0,0 +1,100 @@
+package colorizetest
+
+import (
+	"strings"
+	"testing"
+)
+
+// number of cells of context shown around the first difference
+const diffContext = 10
+
+/*
+plainText returns the text of the given cells, without escape sequences.
+
+Parameters:
+  - cells: The cells.
+
+Return:
+  - string: The text.
+*/
+func plainText(cells []Cell) string {
+	builder := strings.Builder{}
+	for _, cell := range cells {
+		if !cell.Escape {
+			builder.WriteString(cell.Text)
+		}
+	}
+	return builder.String()
+}
+
+/*
+EqualANSI reports whether two styled strings render the same, comparing their cells (see Parse)
+rather than their bytes.
+
+Parameters:
+  - want: The expected string.
+  - got: The actual string.
+
+Return:
+  - bool: true if both strings render the same, false otherwise.
+  - int: The index of the first differing cell, or -1.
+*/
+func EqualANSI(want string, got string) (bool, int) {
+	wantCells := Parse(want)
+	gotCells := Parse(got)
+
+	for i := 0; i < max(len(wantCells), len(gotCells)); i++ {
+		if i >= len(wantCells) || i >= len(gotCells) || wantCells[i] != gotCells[i] {
+			return false, i
+		}
+	}
+	return true, -1
+}
+
+/*
+AssertEqualANSI reports a test error if two styled strings don't render the same. Equivalent
+escape sequences (e.g., the same styles in a different order) are considered equal.
+
+The error shows the plain texts and the first differing cell, with its style in a human-readable
+form (e.g., cell 3: want "E" fg=#FF0000 bold, got "E" fg=#FF0000).
+
+Parameters:
+  - t: The test.
+  - want: The expected string.
+  - got: The actual string.
+
+Return:
+  - bool: true if both strings render the same, false otherwise.
+
+Example:
+
+	func TestBanner(t *testing.T) {
+		colorizetest.AssertEqualANSI(t, "\033[1m\033[38;2;255;0;0mError\033[0m", banner())
+	}
+*/
+func AssertEqualANSI(t testing.TB, want string, got string) bool {
+	t.Helper()
+
+	equal, index := EqualANSI(want, got)
+	if equal {
+		return true
+	}
+
+	wantCells := Parse(want)
+	gotCells := Parse(got)
+	describe := func(cells []Cell) string {
+		if index >= len(cells) {
+			return "end of string"
+		}
+		return cells[index].String()
+	}
+	context := func(cells []Cell) string {
+		start := min(max(index-diffContext, 0), len(cells))
+		return plainText(cells[start:min(index+diffContext, len(cells))])
+	}
+
+	t.Errorf("styled strings differ at cell %d: want %s, got %s\nwant text: %q\n got text: %q\n near: want %q, got %q",
+		index, describe(wantCells), describe(gotCells),
+		plainText(wantCells), plainText(gotCells), context(wantCells), context(gotCells))
+	return false
+}
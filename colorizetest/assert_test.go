@@ -0,0 +1,61 @@
+package colorizetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+/* recorder is a testing.TB recording the errors instead of failing */
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+/* TestEqualANSI tests the EqualANSI function */
+func TestEqualANSI(t *testing.T) {
+	tests := []struct {
+		want, got string
+		equal     bool
+		index     int
+	}{
+		{"\033[1m\033[31mab\033[0m", "\033[31;1mab\033[0m", true, -1},
+		{"ab", "\033[0mab", true, -1},
+		{"\033[1mab\033[0m", "\033[1ma\033[0mb", false, 1},
+		{"ab", "abc", false, 2},
+	}
+	for _, test := range tests {
+		equal, index := EqualANSI(test.want, test.got)
+		if equal != test.equal || index != test.index {
+			t.Errorf("%q vs %q: expected %v, %d but got %v, %d", test.want, test.got, test.equal, test.index, equal, index)
+		}
+	}
+}
+
+/* TestAssertEqualANSI tests the AssertEqualANSI function */
+func TestAssertEqualANSI(t *testing.T) {
+	r := &recorder{}
+	if !AssertEqualANSI(r, "\033[1;31mError\033[0m", "\033[31m\033[1mError\033[0m") || len(r.errors) != 0 {
+		t.Error("Expected equivalent strings to be equal")
+	}
+
+	if AssertEqualANSI(r, "\033[1;31mError\033[0m", "\033[31mError\033[0m") {
+		t.Error("Expected the strings to differ")
+	}
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], `want "E" fg=16:1 bold, got "E" fg=16:1`) {
+		t.Errorf("Unexpected error: %v", r.errors)
+	}
+
+	// shorter strings
+	r.errors = nil
+	AssertEqualANSI(r, "abc", "a")
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "got end of string") {
+		t.Errorf("Unexpected error: %v", r.errors)
+	}
+}
@@ -0,0 +1,332 @@
+package colorizetest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// width of a tab stop
+const tabWidth = 8
+
+var (
+	// regex for a complete escape sequence at the start of a string (CSI or OSC)
+	escapeRegex = regexp.MustCompile(`^(?:\x1b\[([0-9;?]*)[ -/]*([@-~])|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\))`)
+	// regex for an escape sequence cut at the end of a write
+	partialRegex = regexp.MustCompile(`^\x1b(?:\[[0-9;?]*[ -/]*|\][^\x07\x1b]*\x1b?)?$`)
+)
+
+/*
+The Screen type is a minimal in-memory terminal emulator, applying the sequences written to it to a
+grid of cells so that tests can assert what the user would see (e.g., "cell (2,5) is red bold 'E'").
+
+It supports printable characters (one cell each), line feeds (as "\r\n", like a terminal in cooked
+mode), carriage returns, backspaces, tabs, SGR sequences, cursor movement (CUU, CUD, CUF, CUB, CHA,
+CUP) and erasing (ED, EL). Other sequences are ignored. Writing past the last line scrolls the
+screen up. It's safe for concurrent use.
+*/
+type Screen struct {
+	mu       sync.Mutex
+	width    int
+	height   int
+	cells    [][]Cell
+	row, col int
+	style    Style
+	pending  string // incomplete sequence or character of the previous write
+}
+
+/*
+NewScreen creates a new blank screen.
+
+Parameters:
+  - width: The number of columns.
+  - height: The number of rows.
+
+Return:
+  - *Screen: The newly created screen.
+
+Example:
+
+	screen := colorizetest.NewScreen(80, 24)
+	fmt.Fprint(screen, c.StyleText("Error", []string{"bold"}))
+	if !screen.Cell(0, 0).Style.Bold {
+		t.Error("Expected a bold title")
+	}
+*/
+func NewScreen(width int, height int) *Screen {
+	s := &Screen{width: max(width, 1), height: max(height, 1)}
+	s.cells = make([][]Cell, s.height)
+	for i := range s.cells {
+		s.cells[i] = s.blankLine()
+	}
+	return s
+}
+
+/*
+blankLine returns a line of blank cells.
+
+Return:
+  - []Cell: The line.
+*/
+func (s *Screen) blankLine() []Cell {
+	line := make([]Cell, s.width)
+	for i := range line {
+		line[i] = Cell{Text: " "}
+	}
+	return line
+}
+
+/*
+Write applies the given bytes to the screen. Sequences cut between two writes are completed by the
+next write. This makes the Screen an io.Writer.
+
+Parameters:
+  - p: The bytes to apply.
+
+Return:
+  - int: The number of bytes of p (always len(p)).
+  - error: Always nil.
+*/
+func (s *Screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	input := s.pending + string(p)
+	s.pending = ""
+
+	for i := 0; i < len(input); {
+		if input[i] == '\x1b' {
+			loc := escapeRegex.FindStringSubmatchIndex(input[i:])
+			if loc == nil {
+				if partialRegex.MatchString(input[i:]) {
+					s.pending = input[i:]
+					break
+				}
+				// other escapes (e.g., ESC 7) are dropped
+				if i+1 < len(input) && input[i+1] >= '0' && input[i+1] <= '~' {
+					i++
+				}
+				i++
+				continue
+			}
+			if loc[2] >= 0 {
+				s.applyCSI(input[i+loc[2]:i+loc[3]], input[i+loc[4]])
+			}
+			i += loc[1]
+			continue
+		}
+
+		if !utf8.FullRuneInString(input[i:]) {
+			s.pending = input[i:]
+			break
+		}
+		r, size := utf8.DecodeRuneInString(input[i:])
+		s.put(r, input[i:i+size])
+		i += size
+	}
+
+	return len(p), nil
+}
+
+/*
+put applies a character to the screen.
+
+Parameters:
+  - r: The character.
+  - text: Its encoding.
+*/
+func (s *Screen) put(r rune, text string) {
+	switch {
+	case r == '\n':
+		s.col = 0
+		s.lineFeed()
+	case r == '\r':
+		s.col = 0
+	case r == '\b':
+		s.col = max(s.col-1, 0)
+	case r == '\t':
+		s.col = min((s.col/tabWidth+1)*tabWidth, s.width-1)
+	case r < ' ' || r == 0x7f:
+		// other control characters are ignored
+	default:
+		if s.col >= s.width {
+			// autowrap
+			s.col = 0
+			s.lineFeed()
+		}
+		s.cells[s.row][s.col] = Cell{Text: text, Style: s.style}
+		s.col++
+	}
+}
+
+/* lineFeed moves the cursor down, scrolling the screen up from the last line */
+func (s *Screen) lineFeed() {
+	if s.row < s.height-1 {
+		s.row++
+		return
+	}
+	s.cells = append(s.cells[1:], s.blankLine())
+}
+
+/*
+applyCSI applies a CSI sequence.
+
+Parameters:
+  - params: The parameters of the sequence.
+  - final: The final byte of the sequence.
+*/
+func (s *Screen) applyCSI(params string, final byte) {
+	if final == 'm' {
+		s.style.Apply(params)
+		return
+	}
+	if strings.HasPrefix(params, "?") {
+		return // private modes (cursor visibility, alternate screen...) are ignored
+	}
+
+	// numeric parameters, with their defaults
+	values := strings.Split(params, ";")
+	param := func(i int, def int) int {
+		if i < len(values) {
+			if v, err := strconv.Atoi(values[i]); err == nil {
+				return v
+			}
+		}
+		return def
+	}
+
+	switch final {
+	case 'A':
+		s.row = max(s.row-max(param(0, 1), 1), 0)
+	case 'B':
+		s.row = min(s.row+max(param(0, 1), 1), s.height-1)
+	case 'C':
+		s.col = min(s.col+max(param(0, 1), 1), s.width-1)
+	case 'D':
+		s.col = max(min(s.col, s.width-1)-max(param(0, 1), 1), 0)
+	case 'G':
+		s.col = min(max(param(0, 1), 1), s.width) - 1
+	case 'H', 'f':
+		s.row = min(max(param(0, 1), 1), s.height) - 1
+		s.col = min(max(param(1, 1), 1), s.width) - 1
+	case 'J':
+		s.eraseDisplay(param(0, 0))
+	case 'K':
+		s.eraseLine(s.row, param(0, 0))
+	}
+}
+
+/*
+eraseLine erases part of a line.
+
+Parameters:
+  - row: The row of the line.
+  - mode: 0 erases from the cursor to the end, 1 from the start to the cursor, 2 the whole line.
+*/
+func (s *Screen) eraseLine(row int, mode int) {
+	start, end := 0, s.width
+	switch mode {
+	case 0:
+		start = min(s.col, s.width)
+	case 1:
+		end = min(s.col+1, s.width)
+	}
+	for i := start; i < end; i++ {
+		s.cells[row][i] = Cell{Text: " "}
+	}
+}
+
+/*
+eraseDisplay erases part of the screen.
+
+Parameters:
+  - mode: 0 erases from the cursor to the end, 1 from the start to the cursor, 2 (or 3) the whole
+    screen.
+*/
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(s.row, 0)
+		for row := s.row + 1; row < s.height; row++ {
+			s.cells[row] = s.blankLine()
+		}
+	case 1:
+		s.eraseLine(s.row, 1)
+		for row := 0; row < s.row; row++ {
+			s.cells[row] = s.blankLine()
+		}
+	default:
+		for row := range s.cells {
+			s.cells[row] = s.blankLine()
+		}
+	}
+}
+
+/*
+Cell returns the cell at the given position. Positions outside the screen return a blank cell.
+
+Parameters:
+  - row: The row, starting at 0.
+  - col: The column, starting at 0.
+
+Return:
+  - Cell: The cell.
+*/
+func (s *Screen) Cell(row int, col int) Cell {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if row < 0 || row >= s.height || col < 0 || col >= s.width {
+		return Cell{Text: " "}
+	}
+	return s.cells[row][col]
+}
+
+/*
+Line returns the text of the given row, without trailing spaces.
+
+Parameters:
+  - row: The row, starting at 0.
+
+Return:
+  - string: The text of the row.
+*/
+func (s *Screen) Line(row int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if row < 0 || row >= s.height {
+		return ""
+	}
+	return strings.TrimRight(plainText(s.cells[row]), " ")
+}
+
+/*
+String returns the text of the screen, one line per row, without trailing spaces nor trailing
+blank lines.
+
+Return:
+  - string: The text of the screen.
+*/
+func (s *Screen) String() string {
+	lines := make([]string, s.height)
+	for row := range lines {
+		lines[row] = s.Line(row)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+/*
+Cursor returns the position of the cursor.
+
+Return:
+  - int: The row, starting at 0.
+  - int: The column, starting at 0.
+*/
+func (s *Screen) Cursor() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.row, min(s.col, s.width-1)
+}
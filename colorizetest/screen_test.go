@@ -0,0 +1,82 @@
+package colorizetest
+
+import (
+	"fmt"
+	"testing"
+)
+
+/* TestScreen tests the Write method of the Screen type */
+func TestScreen(t *testing.T) {
+	screen := NewScreen(10, 3)
+	fmt.Fprint(screen, "ab\033[1;31mE\033[0mc\nline 2\r\033[2C\033[4m_\033[0m")
+
+	if cell := screen.Cell(0, 2); cell.Text != "E" || cell.Style != (Style{Fg: "16:1", Bold: true}) {
+		t.Errorf("Unexpected cell: %v", cell)
+	}
+	if cell := screen.Cell(0, 3); cell.Text != "c" || cell.Style != (Style{}) {
+		t.Errorf("Unexpected cell: %v", cell)
+	}
+	if line := screen.Line(1); line != "li_e 2" {
+		t.Errorf("Unexpected line: %q", line)
+	}
+	if row, col := screen.Cursor(); row != 1 || col != 3 {
+		t.Errorf("Unexpected cursor: %d, %d", row, col)
+	}
+
+	// out of range
+	if screen.Cell(5, 0).Text != " " || screen.Line(-1) != "" {
+		t.Error("Expected blank cells outside the screen")
+	}
+}
+
+/* TestScreenSplitWrites tests sequences and characters cut between writes */
+func TestScreenSplitWrites(t *testing.T) {
+	screen := NewScreen(10, 2)
+	for _, chunk := range []string{"\033", "[1", "mé"[:2], "mé"[2:], "\033]8;;x", "\033\\", "\0337z"} {
+		screen.Write([]byte(chunk))
+	}
+	if cell := screen.Cell(0, 0); cell.Text != "é" || !cell.Style.Bold {
+		t.Errorf("Unexpected cell: %v", cell)
+	}
+	if screen.String() != "éz" {
+		t.Errorf("Unexpected screen: %q", screen.String())
+	}
+}
+
+/* TestScreenCursorAndErase tests the cursor movement and erase sequences */
+func TestScreenCursorAndErase(t *testing.T) {
+	screen := NewScreen(6, 3)
+	fmt.Fprint(screen, "aaaaaa\nbbbbbb\ncccccc")
+
+	fmt.Fprint(screen, "\033[2;3H\033[K")
+	if screen.Line(1) != "bb" {
+		t.Errorf("Unexpected line: %q", screen.Line(1))
+	}
+	fmt.Fprint(screen, "\033[A\033[1K")
+	if screen.Line(0) != "   aaa" {
+		t.Errorf("Unexpected line: %q", screen.Line(0))
+	}
+	fmt.Fprint(screen, "\033[B\033[B\033[D\033[5G\033[0J")
+	if screen.Line(2) != "cccc" {
+		t.Errorf("Unexpected line: %q", screen.Line(2))
+	}
+	fmt.Fprint(screen, "\033[2J")
+	if screen.String() != "" {
+		t.Errorf("Expected a blank screen but got %q", screen.String())
+	}
+
+	// tabs, backspaces and private modes
+	fmt.Fprint(screen, "\033[H\033[?25la\tb\bc")
+	if screen.Line(0) != "a    c" {
+		t.Errorf("Unexpected line: %q", screen.Line(0))
+	}
+}
+
+/* TestScreenScroll tests autowrap and scrolling */
+func TestScreenScroll(t *testing.T) {
+	screen := NewScreen(3, 2)
+	fmt.Fprint(screen, "abcdef\ng")
+	if screen.String() != "def\ng" {
+		t.Errorf("Unexpected screen: %q", screen.String())
+	}
+}
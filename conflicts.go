@@ -0,0 +1,118 @@
+package colorize
+
+import (
+	"strings"
+)
+
+const (
+	// merge conflict markers
+	markerOurs   = "<<<<<<<"
+	markerBase   = "|||||||"
+	markerSep    = "======="
+	markerTheirs = ">>>>>>>"
+)
+
+/* The conflict type represents a merge conflict being parsed */
+type conflict struct {
+	start, base, sep string // marker lines (base is empty without diff3 style)
+	ours, theirs     []string
+	baseLines        []string
+	section          *[]string // section the next lines belong to
+}
+
+/*
+render styles the conflict. Lines of ours and theirs are paired by position, and the words that
+differ within a pair are highlighted with the inline variants of the roles.
+
+Parameters:
+  - theme: The theme styling the conflict.
+  - end: The closing marker line, or an empty string for an unterminated conflict.
+
+Return:
+  - []string: The styled lines.
+*/
+func (c *conflict) render(theme Theme, end string) []string {
+	ours := make([]string, len(c.ours))
+	theirs := make([]string, len(c.theirs))
+	for i := range ours {
+		ours[i] = theme.Format("conflict.ours", c.ours[i])
+	}
+	for i := range theirs {
+		theirs[i] = theme.Format("conflict.theirs", c.theirs[i])
+	}
+	if end != "" {
+		for i := 0; i < min(len(ours), len(theirs)); i++ {
+			if c.ours[i] != c.theirs[i] {
+				ours[i], theirs[i] = highlightChange(c.ours[i], c.theirs[i], "conflict.ours", "conflict.theirs", theme)
+			}
+		}
+	}
+
+	lines := []string{theme.Format("conflict.marker", c.start)}
+	lines = append(lines, ours...)
+	if c.base != "" {
+		lines = append(lines, theme.Format("conflict.marker", c.base))
+		for _, line := range c.baseLines {
+			lines = append(lines, theme.Format("conflict.base", line))
+		}
+	}
+	if c.sep != "" {
+		lines = append(lines, theme.Format("conflict.marker", c.sep))
+	}
+	lines = append(lines, theirs...)
+	if end != "" {
+		lines = append(lines, theme.Format("conflict.marker", end))
+	}
+	return lines
+}
+
+/*
+ColorizeConflicts styles the merge conflicts found in the given content: the markers
+("<<<<<<<", "|||||||", "=======" and ">>>>>>>"), and the ours, base (diff3 style) and theirs
+sections are styled with the "conflict.*" roles of DefaultTheme, and the words that differ between
+ours and theirs are highlighted. Lines outside conflicts are returned unmodified.
+
+Parameters:
+  - content: The content with merge conflicts (e.g., a file after a failed merge).
+
+Return:
+  - string: The styled content.
+
+Example:
+
+	content, _ := os.ReadFile("main.go")
+	fmt.Print(c.ColorizeConflicts(string(content)))
+*/
+func ColorizeConflicts(content string) string {
+	theme := DefaultTheme
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+
+	var current *conflict
+	for _, line := range lines {
+		marker := strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(marker, markerOurs) && current == nil:
+			current = &conflict{start: line}
+			current.section = &current.ours
+		case current == nil:
+			out = append(out, line)
+		case strings.HasPrefix(marker, markerBase) && current.sep == "":
+			current.base = line
+			current.section = &current.baseLines
+		case marker == markerSep && current.sep == "":
+			current.sep = line
+			current.section = &current.theirs
+		case strings.HasPrefix(marker, markerTheirs) && current.sep != "":
+			out = append(out, current.render(theme, line)...)
+			current = nil
+		default:
+			*current.section = append(*current.section, line)
+		}
+	}
+	if current != nil {
+		out = append(out, current.render(theme, "")...)
+	}
+
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,67 @@
+package colorize
+
+import (
+	"strings"
+	"testing"
+)
+
+/* TestColorizeConflicts tests the ColorizeConflicts function */
+func TestColorizeConflicts(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	content := strings.Join([]string{
+		"package main",
+		"<<<<<<< HEAD",
+		"x := 1",
+		"||||||| base",
+		"x := 0",
+		"=======",
+		"x := 2",
+		">>>>>>> feature",
+		"",
+	}, "\n")
+
+	// without color support the content is unmodified
+	if got := ColorizeConflicts(content); got != content {
+		t.Errorf("Expected %q but got %q", content, got)
+	}
+
+	trueColor = true
+	theme := DefaultTheme
+	got := ColorizeConflicts(content)
+	expected := strings.Join([]string{
+		"package main",
+		theme.Format("conflict.marker", "<<<<<<< HEAD"),
+		theme.Format("conflict.ours", "x := ") + theme.Format("conflict.ours.inline", "1"),
+		theme.Format("conflict.marker", "||||||| base"),
+		theme.Format("conflict.base", "x := 0"),
+		theme.Format("conflict.marker", "======="),
+		theme.Format("conflict.theirs", "x := ") + theme.Format("conflict.theirs.inline", "2"),
+		theme.Format("conflict.marker", ">>>>>>> feature"),
+		"",
+	}, "\n")
+	if got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	// unterminated conflict
+	got = ColorizeConflicts("<<<<<<< HEAD\na\n=======\nb")
+	expected = strings.Join([]string{
+		theme.Format("conflict.marker", "<<<<<<< HEAD"),
+		theme.Format("conflict.ours", "a"),
+		theme.Format("conflict.marker", "======="),
+		theme.Format("conflict.theirs", "b"),
+	}, "\n")
+	if got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	// no conflicts
+	if got := ColorizeConflicts("a ======= b\n"); got != "a ======= b\n" {
+		t.Errorf("Unexpected output: %q", got)
+	}
+}
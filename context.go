@@ -0,0 +1,47 @@
+package colorize
+
+import (
+	"context"
+)
+
+/* themeKey is the context key of the theme (unexported to avoid collisions) */
+type themeKey struct{}
+
+/*
+WithStyleContext returns a copy of the given context carrying the given theme, so that
+request-scoped output (e.g., per-tenant theming in a multi-user server) can carry its theme
+through existing call chains.
+
+Parameters:
+  - ctx: The parent context.
+  - theme: The theme to carry.
+
+Return:
+  - context.Context: The derived context.
+
+Example:
+
+	ctx = c.WithStyleContext(ctx, tenantTheme)
+	// ... deep in the call chain
+	fmt.Println(c.FromContext(ctx).Format("git.branch", branch))
+*/
+func WithStyleContext(ctx context.Context, theme Theme) context.Context {
+	return context.WithValue(ctx, themeKey{}, theme)
+}
+
+/*
+FromContext returns the theme carried by the given context, or DefaultTheme if the context carries
+none.
+
+Parameters:
+  - ctx: The context.
+
+Return:
+  - Theme: The theme carried by the context.
+*/
+func FromContext(ctx context.Context) Theme {
+	if theme, ok := ctx.Value(themeKey{}).(Theme); ok && theme != nil {
+		return theme
+	}
+	return DefaultTheme
+}
@@ -0,0 +1,31 @@
+package colorize
+
+import (
+	"context"
+	"testing"
+)
+
+/* TestStyleContext tests the WithStyleContext and FromContext functions */
+func TestStyleContext(t *testing.T) {
+	theme := Theme{"role": {Styles: []string{"bold"}}}
+
+	ctx := WithStyleContext(context.Background(), theme)
+	if _, ok := FromContext(ctx)["role"]; !ok {
+		t.Error("Expected the theme carried by the context")
+	}
+
+	// derived contexts keep the theme
+	derived, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if _, ok := FromContext(derived)["role"]; !ok {
+		t.Error("Expected the theme to be inherited")
+	}
+
+	// no theme
+	if _, ok := FromContext(context.Background())["git.branch"]; !ok {
+		t.Error("Expected the default theme")
+	}
+	if _, ok := FromContext(WithStyleContext(context.Background(), nil))["git.branch"]; !ok {
+		t.Error("Expected the default theme for a nil theme")
+	}
+}
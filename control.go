@@ -0,0 +1,81 @@
+package colorize
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+escapeControlRune returns the readable notation of a control character: "\x1b" for the escape
+character (the start of terminal sequences), caret notation for the other C0 characters and DEL
+(e.g., "^C", "^?"), and "\u0085" notation for the C1 characters.
+
+Parameters:
+  - r: The rune.
+
+Return:
+  - string: The notation of the rune, or an empty string if it isn't a control character.
+*/
+func escapeControlRune(r rune) string {
+	switch {
+	case r == 0x1b:
+		return `\x1b`
+	case r < 0x20:
+		return "^" + string(r+'@')
+	case r == 0x7f:
+		return "^?"
+	case r >= 0x80 && r < 0xa0:
+		return fmt.Sprintf(`\u%04x`, r)
+	}
+	return ""
+}
+
+/*
+EscapeControl converts the control characters of a text (e.g., captured from a subprocess) to a
+readable notation styled with the "control" role of DefaultTheme, so that it can be displayed
+without the terminal interpreting them: "\x1b" for the escape character, caret notation for the
+other C0 characters and DEL (e.g., "^C", "^?"), "\u0085" notation for the C1 characters and "\xff"
+notation for invalid UTF-8 bytes. Line breaks and tabs are kept.
+
+Parameters:
+  - s: The text.
+
+Return:
+  - string: The text with its control characters escaped.
+
+Example:
+
+	fmt.Println(c.EscapeControl("\x1b[31mred\x1b[0m\a"))
+	// \x1b[31mred\x1b[0m^G
+*/
+func EscapeControl(s string) string {
+	builder := strings.Builder{}
+	run := strings.Builder{}
+	// consecutive notations are styled at once
+	flush := func() {
+		if run.Len() > 0 {
+			builder.WriteString(DefaultTheme.Format("control", run.String()))
+			run.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			run.WriteString(fmt.Sprintf(`\x%02x`, s[i]))
+		case r == '\n' || r == '\t':
+			flush()
+			builder.WriteRune(r)
+		case escapeControlRune(r) != "":
+			run.WriteString(escapeControlRune(r))
+		default:
+			flush()
+			builder.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	flush()
+	return builder.String()
+}
@@ -0,0 +1,31 @@
+package colorize
+
+import "testing"
+
+/* TestEscapeControl tests the EscapeControl function */
+func TestEscapeControl(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	tests := map[string]string{
+		"\x1b[31mred\x1b[0m\a": `\x1b[31mred\x1b[0m^G`,
+		"^C\x03 del\x7f":       `^C^C del^?`,
+		"nul\x00":              `nul^@`,
+		"c1\u009b":             `c1\u009b`,
+		"bad\xff utf8":         `bad\xff utf8`,
+		"keep\n\ttabs":         "keep\n\ttabs",
+		"héllo ✓":              "héllo ✓",
+	}
+	for s, expected := range tests {
+		if got := stripANSI(EscapeControl(s)); got != expected {
+			t.Errorf("%q: expected %q but got %q", s, expected, got)
+		}
+	}
+
+	// consecutive notations are styled at once
+	expected := "a" + DefaultTheme.Format("control", `^C^D`) + "b"
+	if got := EscapeControl("a\x03\x04b"); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
@@ -0,0 +1,69 @@
+package colorize
+
+import "strconv"
+
+/* The CountOptions type represents the severity thresholds of a counter (see Count) */
+type CountOptions struct {
+	Warning int   // counts from which the number uses the "count.warning" role (0 disables it)
+	Error   int   // counts from which the number uses the "count.error" role (0 disables it)
+	Theme   Theme // theme styling the number (DefaultTheme if nil)
+}
+
+/*
+countRole returns the role styling a count.
+
+Parameters:
+  - n: The count.
+  - opts: The counter options.
+
+Return:
+  - string: The role.
+*/
+func countRole(n int, opts CountOptions) string {
+	switch {
+	case n == 0:
+		return "count.zero"
+	case opts.Error > 0 && n >= opts.Error:
+		return "count.error"
+	case opts.Warning > 0 && n >= opts.Warning:
+		return "count.warning"
+	}
+	return "count.normal"
+}
+
+/*
+Count renders a count followed by the singular or plural form of a noun (e.g., "1 error",
+"3 errors"), the number being styled by severity: the "count.zero" role for zero, "count.error"
+and "count.warning" from the thresholds of the options, and "count.normal" otherwise. It's meant
+to compose summary lines.
+
+Parameters:
+  - n: The count.
+  - singular: The singular form of the noun (used for 1 and -1).
+  - plural: The plural form of the noun, or an empty string to append an "s" to the singular.
+  - opts: The counter options, or nil for no thresholds.
+
+Return:
+  - string: The count and the noun.
+
+Example:
+
+	fmt.Println(c.Count(failed, "test", "", &c.CountOptions{Error: 1}) + " failed, " +
+		c.Count(skipped, "test", "", &c.CountOptions{Warning: 1}) + " skipped")
+	// 0 tests failed, 2 tests skipped
+*/
+func Count(n int, singular string, plural string, opts *CountOptions) string {
+	if opts == nil {
+		opts = &CountOptions{}
+	}
+
+	noun := singular
+	if n != 1 && n != -1 {
+		noun = plural
+		if noun == "" {
+			noun = singular + "s"
+		}
+	}
+
+	return opts.Theme.orDefault().Format(countRole(n, *opts), strconv.Itoa(n)) + " " + noun
+}
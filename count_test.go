@@ -0,0 +1,42 @@
+package colorize
+
+import (
+	"strconv"
+	"testing"
+)
+
+/* TestCount tests the Count function */
+func TestCount(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	thresholds := &CountOptions{Warning: 5, Error: 10}
+	tests := []struct {
+		n        int
+		singular string
+		plural   string
+		opts     *CountOptions
+		role     string
+		noun     string
+	}{
+		{0, "error", "", nil, "count.zero", "errors"},
+		{1, "error", "", nil, "count.normal", "error"},
+		{-1, "degree", "", nil, "count.normal", "degree"},
+		{3, "entry", "entries", thresholds, "count.normal", "entries"},
+		{5, "entry", "entries", thresholds, "count.warning", "entries"},
+		{12, "child", "children", thresholds, "count.error", "children"},
+	}
+	for _, test := range tests {
+		got := Count(test.n, test.singular, test.plural, test.opts)
+		if expected := DefaultTheme.Format(test.role, strconv.Itoa(test.n)) + " " + test.noun; got != expected {
+			t.Errorf("Expected %q but got %q", expected, got)
+		}
+	}
+
+	// custom theme
+	theme := Theme{"count.error": {Styles: []string{"underline"}}}
+	if got := Count(1, "failure", "", &CountOptions{Error: 1, Theme: theme}); got != styles["underline"]+"1"+reset+" failure" {
+		t.Errorf("Unexpected count: %q", got)
+	}
+}
@@ -0,0 +1,215 @@
+package colorize
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+var (
+	/* color level forced by the user (FORCE_COLOR or CLICOLOR_FORCE), -1 if not forced */
+	forceLevel, forceSource = detectForceColor()
+
+	/* noColor disables colors and styles, following the NO_COLOR convention (https://no-color.org) */
+	noColor = detectNoColor()
+
+	/* color level of the terminal, and the variable it was detected from */
+	termColorLevel, termSource = detectTermLevel()
+
+	/* terminalOnly restricts escape codes to outputs that are terminals (see SetTerminalOnly) */
+	terminalOnly = false
+
+	// reports whether the writer is a terminal (a variable so tests can replace it)
+	writerIsTerminal = IsTerminal
+)
+
+/*
+detectForceColor detects the color level forced by the user:
+  - FORCE_COLOR: "0" or "false" disable colors, "2" forces the Xterm palette, "3" forces true
+    color, and any other non-empty value forces the 16 ANSI colors (or better, when detected).
+  - CLICOLOR_FORCE: any non-empty value other than "0" forces the 16 ANSI colors (or better, when
+    detected).
+
+Return:
+  - int: The forced level (0: disabled, 1: 16 colors, 2: Xterm, 3: true color), or -1 if not forced.
+  - string: The variable forcing the level (e.g., FORCE_COLOR="3").
+*/
+func detectForceColor() (int, string) {
+	return forceColorFrom(os.Getenv)
+}
+
+/*
+forceColorFrom detects the color level forced by the given environment (see detectForceColor).
+
+Parameters:
+  - getenv: The function looking up an environment variable (e.g., os.Getenv).
+
+Return:
+  - int: The forced level, or -1 if not forced.
+  - string: The variable forcing the level.
+*/
+func forceColorFrom(getenv func(string) string) (int, string) {
+	if value := getenv("FORCE_COLOR"); value != "" {
+		source := fmt.Sprintf("FORCE_COLOR=%q", value)
+		switch value {
+		case "0", "false":
+			return 0, source
+		case "2":
+			return 2, source
+		case "3":
+			return 3, source
+		}
+		return 1, source
+	}
+	if value := getenv("CLICOLOR_FORCE"); value != "" && value != "0" {
+		return 1, fmt.Sprintf("CLICOLOR_FORCE=%q", value)
+	}
+	return -1, ""
+}
+
+/*
+detectTrueColor reports whether the terminal supports true color (see detectTermLevel, or a Windows
+console with virtual terminal processing enabled), or true color is forced.
+
+Return:
+  - bool: true if true color is supported, false otherwise.
+*/
+func detectTrueColor() bool {
+	return termColorLevel == LevelTrueColor || forceLevel == 3 || enableVirtualTerminal()
+}
+
+/*
+detectXTerm reports whether the terminal supports the Xterm palette (see detectTermLevel), or the
+Xterm palette is forced.
+
+Return:
+  - bool: true if the Xterm palette is supported, false otherwise.
+*/
+func detectXTerm() bool {
+	return termColorLevel >= LevelAnsi256 || forceLevel >= 2
+}
+
+/*
+detectAnsi16 reports whether the terminal supports the 16 ANSI colors (see detectTermLevel), or
+colors are forced.
+
+Return:
+  - bool: true if the 16 ANSI colors are supported, false otherwise.
+*/
+func detectAnsi16() bool {
+	return termColorLevel >= LevelAnsi16 || forceLevel > 0
+}
+
+/*
+detectNoColor reports whether colors must be disabled: the NO_COLOR environment variable is set to
+a non-empty value (unless colors are forced), or FORCE_COLOR disables them.
+
+Return:
+  - bool: true if colors must be disabled, false otherwise.
+*/
+func detectNoColor() bool {
+	if forceLevel >= 0 {
+		return forceLevel == 0
+	}
+	return os.Getenv("NO_COLOR") != ""
+}
+
+/*
+IsTerminal reports whether the given writer is a terminal (a character device). Writers other than
+*os.File (or a SyncWriter wrapping one) are never terminals.
+
+Parameters:
+  - w: The writer.
+
+Return:
+  - bool: true if the writer is a terminal, false otherwise.
+*/
+func IsTerminal(w io.Writer) bool {
+	if sw, ok := w.(*SyncWriter); ok {
+		w = sw.w
+	}
+	file, ok := w.(*os.File)
+	if !ok || file == nil {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+/*
+SetTerminalOnly enables or disables the terminal-only mode. When enabled, escape codes are only
+emitted to outputs that are terminals: FormatText, GetColor, the methods of Color and the functions
+built on them check stdout, while Printer, LineWriter and Typewriter check the writer they write to. Redirecting the
+output to a file or a pipe then produces plain text, as with NO_COLOR.
+
+Colors forced by the user (FORCE_COLOR or CLICOLOR_FORCE) or set by SetColorLevel are emitted
+regardless. The mode is disabled by default.
+
+Parameters:
+  - enabled: true to only emit escape codes to terminals, false to always emit them.
+
+Example:
+
+	c.SetTerminalOnly(true)
+	// plain text when run as `mytool > out.log`
+	done, _ := c.ForegroundText("done", "#00FF00")
+	fmt.Println(done)
+*/
+func SetTerminalOnly(enabled bool) {
+	terminalOnly = enabled
+}
+
+/*
+terminalAllows reports whether the terminal-only mode allows escape codes on the given writer.
+
+Parameters:
+  - w: The writer.
+
+Return:
+  - bool: true if escape codes are allowed, false otherwise.
+*/
+func terminalAllows(w io.Writer) bool {
+	return !terminalOnly || forceLevel > 0 || levelOverride || writerIsTerminal(w)
+}
+
+/*
+ColorsEnabledFor reports whether escape codes are emitted to the given writer: colors are enabled
+(see ColorsEnabled, ignoring stdout) and, in terminal-only mode, the writer is a terminal.
+
+Parameters:
+  - w: The writer.
+
+Return:
+  - bool: true if colors are enabled for the writer, false otherwise.
+
+Example:
+
+	if c.ColorsEnabledFor(os.Stderr) {
+		warning, _ := c.ForegroundText("warning", "#FFA500")
+		fmt.Fprintln(os.Stderr, warning)
+	}
+*/
+func ColorsEnabledFor(w io.Writer) bool {
+	return !noColor && (trueColor || xTerm || ansi16) && terminalAllows(w)
+}
+
+/*
+ColorsEnabled reports whether the package currently emits escape codes: colors are supported by
+the system (true color, Xterm or the 16 ANSI colors), haven't been disabled by the user (NO_COLOR) and, in
+terminal-only mode (see SetTerminalOnly), stdout is a terminal.
+
+When colors are disabled by NO_COLOR, the formatting functions return the text unmodified without
+an error, and GetColor returns an empty code.
+
+Return:
+  - bool: true if colors are enabled, false otherwise.
+
+Example:
+
+	if !c.ColorsEnabled() {
+		// use plain markers instead of colors
+	}
+*/
+func ColorsEnabled() bool {
+	return ColorsEnabledFor(os.Stdout)
+}
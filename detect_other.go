@@ -0,0 +1,14 @@
+//go:build !windows
+
+package colorize
+
+/*
+enableVirtualTerminal is only needed on Windows (see detect_windows.go): other systems process
+escape sequences natively and are detected through COLORTERM and TERM.
+
+Return:
+  - bool: Always false.
+*/
+func enableVirtualTerminal() bool {
+	return false
+}
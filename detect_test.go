@@ -0,0 +1,190 @@
+package colorize
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+/* TestDetectNoColor tests the detectNoColor function */
+func TestDetectNoColor(t *testing.T) {
+	defer func(level int) { forceLevel = level }(forceLevel)
+	forceLevel = -1
+
+	t.Setenv("NO_COLOR", "")
+	if detectNoColor() {
+		t.Error("Expected an empty NO_COLOR to be ignored")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if !detectNoColor() {
+		t.Error("Expected NO_COLOR to disable colors")
+	}
+}
+
+/* TestNoColor tests the formatting functions when colors are disabled by NO_COLOR */
+func TestNoColor(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	noColor = false
+
+	if !ColorsEnabled() {
+		t.Error("Expected colors to be enabled")
+	}
+
+	noColor = true
+	if ColorsEnabled() {
+		t.Error("Expected colors to be disabled")
+	}
+
+	text, err := FormatText("Hello", &Options{FgColor: "#FF0000", Styles: []string{"bold"}})
+	if text != "Hello" || err != nil {
+		t.Errorf("Expected the plain text without an error but got %q, %v", text, err)
+	}
+	if text := StyleText("Hello", []string{"bold"}); text != "Hello" {
+		t.Errorf("Expected the plain text but got %q", text)
+	}
+
+	code, err := GetColor("#FF0000", foreground)
+	if code != "" || err != nil {
+		t.Errorf("Expected an empty code without an error but got %q, %v", code, err)
+	}
+	if _, err := GetColor("bad", foreground); err == nil {
+		t.Error("Expected invalid colors to be reported")
+	}
+
+	if exp := Explain("Hello", &Options{Styles: []string{"bold"}}); exp.Profile != "none" || len(exp.Dropped) != 1 {
+		t.Errorf("Unexpected explanation: %v", exp)
+	}
+}
+
+/* TestDetectForceColor tests the detectForceColor function and the detection it overrides */
+func TestDetectForceColor(t *testing.T) {
+	defer func(level int, source string) { forceLevel, forceSource = level, source }(forceLevel, forceSource)
+
+	tests := []struct {
+		force, cliForce string
+		level           int
+	}{
+		{"", "", -1},
+		{"0", "", 0},
+		{"false", "1", 0},
+		{"1", "", 1},
+		{"true", "", 1},
+		{"2", "", 2},
+		{"3", "", 3},
+		{"", "1", 1},
+		{"", "0", -1},
+	}
+	for _, test := range tests {
+		t.Setenv("FORCE_COLOR", test.force)
+		t.Setenv("CLICOLOR_FORCE", test.cliForce)
+		if level, _ := detectForceColor(); level != test.level {
+			t.Errorf("FORCE_COLOR=%q CLICOLOR_FORCE=%q: expected %d but got %d", test.force, test.cliForce, test.level, level)
+		}
+	}
+
+	defer func(level Level) { termColorLevel = level }(termColorLevel)
+	termColorLevel = LevelNone
+	t.Setenv("NO_COLOR", "1")
+
+	forceLevel = 3
+	if !detectTrueColor() || detectNoColor() {
+		t.Error("Expected true color to be forced over NO_COLOR")
+	}
+	forceLevel = 2
+	if detectTrueColor() || !detectXTerm() {
+		t.Error("Expected the Xterm palette to be forced")
+	}
+	forceLevel = 1
+	if detectXTerm() || !detectAnsi16() {
+		t.Error("Expected the 16 ANSI colors to be forced")
+	}
+	forceLevel = 0
+	if detectAnsi16() || !detectNoColor() {
+		t.Error("Expected colors to be disabled")
+	}
+	forceLevel = -1
+	if detectAnsi16() || !detectNoColor() {
+		t.Error("Expected NO_COLOR to disable colors")
+	}
+}
+
+/* TestEnableVirtualTerminal tests the enableVirtualTerminal function outside of Windows consoles */
+func TestEnableVirtualTerminal(t *testing.T) {
+	// test binaries don't run in a console (their output is captured), so VT can't be enabled
+	if enableVirtualTerminal() {
+		t.Error("Expected virtual terminal processing not to be enabled")
+	}
+}
+
+/* TestTerminalOnly tests the terminal-only mode */
+func TestTerminalOnly(t *testing.T) {
+	defer restore()
+	defer func(prev func(io.Writer) bool) { writerIsTerminal = prev }(writerIsTerminal)
+	defer func(level int) { forceLevel = level }(forceLevel)
+	trueColor = true
+	noColor = false
+	forceLevel = -1
+
+	var tty, file bytes.Buffer
+	writerIsTerminal = func(w io.Writer) bool { return w == &tty || w == os.Stdout }
+	red := &Options{FgColor: "#FF0000"}
+
+	// disabled by default
+	if !ColorsEnabledFor(&file) {
+		t.Error("Expected colors to be enabled for any writer by default")
+	}
+
+	SetTerminalOnly(true)
+	if !ColorsEnabledFor(&tty) || ColorsEnabledFor(&file) {
+		t.Error("Expected colors to be enabled for terminals only")
+	}
+
+	// FormatText checks stdout
+	if text, err := FormatText("hello", red); err != nil || text == "hello" {
+		t.Errorf("Expected formatted text on a terminal stdout but got %q, %v", text, err)
+	}
+	if code, err := GetColor("#FF0000", foreground); err != nil || code == "" {
+		t.Errorf("Expected a color code on a terminal stdout but got %q, %v", code, err)
+	}
+	if code := (Color{R: 255}).Fg(); code == "" {
+		t.Error("Expected a Color code on a terminal stdout")
+	}
+	writerIsTerminal = func(w io.Writer) bool { return w == &tty }
+	if text, err := FormatText("hello", red); err != nil || text != "hello" {
+		t.Errorf("Expected plain text on a redirected stdout but got %q, %v", text, err)
+	}
+	if code, err := GetColor("#FF0000", foreground); err != nil || code != "" {
+		t.Errorf("Expected no color code on a redirected stdout but got %q, %v", code, err)
+	}
+	if code := (Color{R: 255}).Fg(); code != "" {
+		t.Errorf("Expected no Color code on a redirected stdout but got %q", code)
+	}
+	if ColorsEnabled() {
+		t.Error("Expected colors to be disabled on a redirected stdout")
+	}
+	if exp := Explain("hello", red); exp.Profile != "none" {
+		t.Errorf("Expected profile none but got %s", exp.Profile)
+	}
+
+	// printers check their own writer
+	NewPrinter(&tty).Print(red, "hello")
+	NewPrinter(&file).Print(red, "hello")
+	if tty.String() == "hello" || file.String() != "hello" {
+		t.Errorf("Unexpected outputs: %q, %q", tty.String(), file.String())
+	}
+
+	// forced colors are emitted regardless
+	forceLevel = 1
+	if !ColorsEnabledFor(&file) {
+		t.Error("Expected forced colors to be enabled for any writer")
+	}
+
+	SetTerminalOnly(false)
+	forceLevel = -1
+	if !ColorsEnabledFor(&file) {
+		t.Error("Expected colors to be enabled once the mode is disabled")
+	}
+}
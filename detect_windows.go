@@ -0,0 +1,44 @@
+//go:build windows
+
+package colorize
+
+import (
+	"os"
+	"syscall"
+)
+
+// console mode flag enabling the processing of escape sequences (Windows 10+)
+const enableVirtualTerminalProcessing = 0x0004
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+/*
+enableVirtualTerminal enables the processing of escape sequences (ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+on the console of the standard output. Windows consoles don't set COLORTERM or TERM, so this is how
+the package detects true color support on Windows 10+.
+
+Legacy consoles (before Windows 10) and redirected outputs can't enable it, in which case colors are
+left disabled.
+
+Return:
+  - bool: true if escape sequences are processed by the console, false otherwise.
+*/
+func enableVirtualTerminal() bool {
+	handle := syscall.Handle(os.Stdout.Fd())
+
+	var mode uint32
+	if err := syscall.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+	if err := procSetConsoleMode.Find(); err != nil {
+		return false
+	}
+	ok, _, _ := procSetConsoleMode.Call(uintptr(handle), uintptr(mode|enableVirtualTerminalProcessing))
+	return ok != 0
+}
@@ -0,0 +1,394 @@
+package colorize
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// number of differing lines above which texts are reported as a single change (see editScript)
+const maxDiffEdits = 1000
+
+/* The DiffLayout type represents the layout of a text diff */
+type DiffLayout int
+
+const (
+	/* Supported layouts */
+	DiffUnified    DiffLayout = iota // one column, lines prefixed with "+", "-" or " "
+	DiffSideBySide                   // two columns (old and new) with line numbers
+)
+
+/* The DiffOptions type represents the options for rendering a text diff */
+type DiffOptions struct {
+	Layout DiffLayout // layout of the diff
+	Width  int        // width of the side-by-side layout in cells (the terminal width if 0)
+	Theme  Theme      // theme styling the diff ("diff.*" roles, DefaultTheme if nil)
+}
+
+/* The diffOp type represents a line of a diff */
+type diffOp struct {
+	kind    byte // ' ' (unchanged), '-' (removed) or '+' (added)
+	text    string
+	oldLine int // line number in the old text (0 for added lines)
+	newLine int // line number in the new text (0 for removed lines)
+}
+
+/*
+splitLines splits a text into lines, ignoring the trailing newline.
+
+Parameters:
+  - text: The text to split.
+
+Return:
+  - []string: The lines.
+*/
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+/*
+diffLines computes a line diff of two texts. The common prefix and suffix are kept as is, and the
+lines in between are compared with the Myers algorithm (see editScript).
+
+Parameters:
+  - a: The lines of the old text.
+  - b: The lines of the new text.
+
+Return:
+  - []diffOp: The diff, with removed lines before added lines within a change.
+*/
+func diffLines(a []string, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, diffOp{' ', a[i], i + 1, i + 1})
+	}
+	for _, op := range editScript(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		if op.oldLine > 0 {
+			op.oldLine += prefix
+		}
+		if op.newLine > 0 {
+			op.newLine += prefix
+		}
+		ops = append(ops, op)
+	}
+	for i := len(a) - suffix; i < len(a); i++ {
+		ops = append(ops, diffOp{' ', a[i], i + 1, i - len(a) + len(b) + 1})
+	}
+	return removalsFirst(ops)
+}
+
+/*
+editScript computes a shortest edit script of two texts with the Myers algorithm, in O((N+M)·D)
+time and O(D²) memory for D differing lines. Texts differing by more than maxDiffEdits lines are
+reported as a single change (every old line removed, every new line added), so that the memory
+stays bounded.
+
+Parameters:
+  - a: The lines of the old text.
+  - b: The lines of the new text.
+
+Return:
+  - []diffOp: The diff, with line numbers relative to the given lines.
+*/
+func editScript(a []string, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	// v[offset+k] is the furthest x reached on diagonal k (x - y = k)
+	v := make([]int, 2*offset+1)
+	// trace[d] is the window [-d, d] of v before step d
+	trace := [][]int{}
+
+	for d := 0; d <= n+m && d <= maxDiffEdits; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			x := v[offset+k-1] + 1
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackEdits(a, b, trace)
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	for i, line := range a {
+		ops = append(ops, diffOp{'-', line, i + 1, 0})
+	}
+	for j, line := range b {
+		ops = append(ops, diffOp{'+', line, 0, j + 1})
+	}
+	return ops
+}
+
+/*
+backtrackEdits walks the trace of the Myers algorithm back from the end of both texts.
+
+Parameters:
+  - a: The lines of the old text.
+  - b: The lines of the new text.
+  - trace: The windows of the furthest points reached before each step (see editScript).
+
+Return:
+  - []diffOp: The diff, in order.
+*/
+func backtrackEdits(a []string, b []string, trace [][]int) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		prevX, prevY := 0, 0
+		if d > 0 {
+			window, k := trace[d], x-y
+			prevK := k - 1
+			if k == -d || (k != d && window[k-1+d] < window[k+1+d]) {
+				prevK = k + 1
+			}
+			prevX = window[prevK+d]
+			prevY = prevX - prevK
+		}
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{' ', a[x-1], x, y})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, diffOp{'+', b[y-1], 0, y})
+			} else {
+				ops = append(ops, diffOp{'-', a[x-1], x, 0})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	slices.Reverse(ops)
+	return ops
+}
+
+/*
+removalsFirst reorders every change of a diff so that its removed lines come before its added lines.
+
+Parameters:
+  - ops: The diff.
+
+Return:
+  - []diffOp: The reordered diff.
+*/
+func removalsFirst(ops []diffOp) []diffOp {
+	for start := 0; start < len(ops); start++ {
+		if ops[start].kind == ' ' {
+			continue
+		}
+		end := start
+		for end < len(ops) && ops[end].kind != ' ' {
+			end++
+		}
+		slices.SortStableFunc(ops[start:end], func(x, y diffOp) int { return int(y.kind) - int(x.kind) })
+		start = end
+	}
+	return ops
+}
+
+/*
+Diff compares two texts line by line and returns a colorized diff, styled with the "diff.added" and
+"diff.removed" roles of the theme.
+
+The side-by-side layout (DiffOptions.Layout) shows the old and new texts in two columns sized to
+the terminal width, with line numbers and changed words highlighted with the "diff.added.inline"
+and "diff.removed.inline" roles. Like sdiff, the columns are separated by "|" (changed line),
+"<" (removed line) or ">" (added line).
+
+Parameters:
+  - a: The old text.
+  - b: The new text.
+  - opts: The diff options, or nil for a unified diff.
+
+Return:
+  - string: The diff, without a trailing newline.
+
+Example:
+
+	fmt.Println(c.Diff(before, after, &c.DiffOptions{Layout: c.DiffSideBySide}))
+*/
+func Diff(a string, b string, opts *DiffOptions) string {
+	if opts == nil {
+		opts = &DiffOptions{}
+	}
+	theme := opts.Theme.orDefault()
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	if opts.Layout == DiffSideBySide {
+		width := opts.Width
+		if width <= 0 {
+			width = terminalWidth()
+		}
+		numWidth := len(strconv.Itoa(max(len(splitLines(a)), len(splitLines(b)), 1)))
+		return sideBySide(ops, width, numWidth, theme)
+	}
+
+	lines := make([]string, len(ops))
+	for i, op := range ops {
+		line := string(op.kind) + " " + op.text
+		switch op.kind {
+		case '-':
+			line = theme.Format("diff.removed", line)
+		case '+':
+			line = theme.Format("diff.added", line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+/*
+sideBySide renders a diff in two columns.
+
+Parameters:
+  - ops: The diff.
+  - width: The total width, in cells.
+  - numWidth: The width of the line numbers.
+  - theme: The theme styling the diff.
+
+Return:
+  - string: The rendered diff.
+*/
+func sideBySide(ops []diffOp, width int, numWidth int, theme Theme) string {
+	// each column holds a line number, a space and the text; the separator takes 3 cells
+	textWidth := max((width-3)/2-numWidth-1, 1)
+
+	column := func(line int, role string, text string) string {
+		if line == 0 {
+			return strings.Repeat(" ", numWidth+1+textWidth)
+		}
+		number := fmt.Sprintf("%*d ", numWidth, line)
+		if role == "" {
+			return number + text
+		}
+		return theme.Format(role, number) + text
+	}
+
+	lines := []string{}
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			text := fitWidth(ops[i].text, textWidth)
+			lines = append(lines, column(ops[i].oldLine, "", text)+pad(text, textWidth)+"   "+column(ops[i].newLine, "", text))
+			i++
+			continue
+		}
+
+		// a change: removed lines are paired with the added lines following them
+		removed := []diffOp{}
+		for ; i < len(ops) && ops[i].kind == '-'; i++ {
+			removed = append(removed, ops[i])
+		}
+		added := []diffOp{}
+		for ; i < len(ops) && ops[i].kind == '+'; i++ {
+			added = append(added, ops[i])
+		}
+
+		for k := 0; k < max(len(removed), len(added)); k++ {
+			switch {
+			case k >= len(added):
+				left := theme.Format("diff.removed", fitWidth(removed[k].text, textWidth))
+				lines = append(lines, column(removed[k].oldLine, "diff.removed", left)+pad(left, textWidth)+" < ")
+			case k >= len(removed):
+				right := theme.Format("diff.added", fitWidth(added[k].text, textWidth))
+				lines = append(lines, column(0, "", "")+" > "+column(added[k].newLine, "diff.added", right))
+			default:
+				left, right := highlightChange(fitWidth(removed[k].text, textWidth), fitWidth(added[k].text, textWidth), "diff.removed", "diff.added", theme)
+				lines = append(lines, column(removed[k].oldLine, "diff.removed", left)+pad(left, textWidth)+" | "+column(added[k].newLine, "diff.added", right))
+			}
+		}
+	}
+
+	// trailing spaces of the right column are never written
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+/*
+fitWidth prepares a line for a column: tabs are expanded and the text is truncated to the width.
+
+Parameters:
+  - text: The line.
+  - width: The width of the column, in cells.
+
+Return:
+  - string: The line, fitting the column.
+*/
+func fitWidth(text string, width int) string {
+	return takeWidth(segmentize(strings.ReplaceAll(text, "\t", "    ")), width)
+}
+
+/*
+pad returns the spaces needed to pad the given text to the width.
+
+Parameters:
+  - text: The text.
+  - width: The width, in cells.
+
+Return:
+  - string: The padding.
+*/
+func pad(text string, width int) string {
+	return strings.Repeat(" ", max(width-visibleWidth(text), 0))
+}
+
+/*
+highlightChange styles a changed line: the common prefix and suffix of the old and new lines are
+styled with the old and new roles, and the parts in between with the inline variants of the roles
+(e.g., "diff.added.inline").
+
+Parameters:
+  - old: The old line.
+  - new: The new line.
+  - oldRole: The role of the old line.
+  - newRole: The role of the new line.
+  - theme: The theme styling the lines.
+
+Return:
+  - string: The styled old line.
+  - string: The styled new line.
+*/
+func highlightChange(old string, new string, oldRole string, newRole string, theme Theme) (string, string) {
+	oldRunes := []rune(old)
+	newRunes := []rune(new)
+
+	prefix := 0
+	for prefix < len(oldRunes) && prefix < len(newRunes) && oldRunes[prefix] == newRunes[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldRunes)-prefix && suffix < len(newRunes)-prefix &&
+		oldRunes[len(oldRunes)-1-suffix] == newRunes[len(newRunes)-1-suffix] {
+		suffix++
+	}
+
+	style := func(runes []rune, base string) string {
+		end := len(runes) - suffix
+		return theme.Format(base, string(runes[:prefix])) +
+			theme.Format(base+".inline", string(runes[prefix:end])) +
+			theme.Format(base, string(runes[end:]))
+	}
+	return style(oldRunes, oldRole), style(newRunes, newRole)
+}
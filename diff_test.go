@@ -0,0 +1,162 @@
+package colorize
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+/* TestDiff tests the unified layout of the Diff function */
+func TestDiff(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	diff := Diff("one\ntwo\nthree\n", "one\n2wo\nthree\nfour\n", nil)
+	expected := "  one\n- two\n+ 2wo\n  three\n+ four"
+	if diff != expected {
+		t.Errorf("Expected %q but got %q", expected, diff)
+	}
+	if Diff("", "", nil) != "" {
+		t.Error("Expected an empty diff")
+	}
+
+	// styled
+	trueColor = true
+	diff = Diff("a", "b", nil)
+	if diff != DefaultTheme.Format("diff.removed", "- a")+"\n"+DefaultTheme.Format("diff.added", "+ b") {
+		t.Errorf("Unexpected diff: %q", diff)
+	}
+}
+
+/* TestDiffSideBySide tests the side-by-side layout of the Diff function */
+func TestDiffSideBySide(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	opts := &DiffOptions{Layout: DiffSideBySide, Width: 31}
+	diff := Diff("one\ntwo\nthree\nfour\tx\n", "one\n2wo\nthree\nfive\nsix\n", opts)
+	expected := strings.Join([]string{
+		"1 one            1 one",
+		"2 two          | 2 2wo",
+		"3 three          3 three",
+		"4 four    x    | 4 five",
+		"               > 5 six",
+	}, "\n")
+	if diff != expected {
+		t.Errorf("Expected:\n%s\nbut got:\n%s", expected, diff)
+	}
+
+	// removed lines and truncation
+	diff = Diff("a very long line indeed\nb\n", "b\n", opts)
+	expected = "1 a very long  <\n2 b              1 b"
+	if diff != expected {
+		t.Errorf("Expected:\n%s\nbut got:\n%s", expected, diff)
+	}
+
+	// intra-line highlighting
+	trueColor = true
+	theme := Theme{"diff.removed.inline": {Styles: []string{"bold"}}}
+	diff = Diff("cat\n", "cot\n", &DiffOptions{Layout: DiffSideBySide, Width: 20, Theme: theme})
+	if diff != "1 c"+styles["bold"]+"a"+reset+"t    | 1 cot" {
+		t.Errorf("Unexpected diff: %q", diff)
+	}
+}
+
+/* TestDiffLines tests that diffLines returns a shortest edit script, removals first */
+func TestDiffLines(t *testing.T) {
+	// lcsLength is the reference length of the longest common subsequence
+	lcsLength := func(a, b []string) int {
+		prev := make([]int, len(b)+1)
+		for i := range a {
+			cur := make([]int, len(b)+1)
+			for j := range b {
+				if a[i] == b[j] {
+					cur[j+1] = prev[j] + 1
+				} else {
+					cur[j+1] = max(prev[j+1], cur[j])
+				}
+			}
+			prev = cur
+		}
+		return prev[len(b)]
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	random := func() []string {
+		lines := make([]string, rng.Intn(12))
+		for i := range lines {
+			lines[i] = string(rune('a' + rng.Intn(3)))
+		}
+		return lines
+	}
+	for i := 0; i < 500; i++ {
+		a, b := random(), random()
+		ops := diffLines(a, b)
+
+		var old, new []string
+		unchanged := 0
+		for j, op := range ops {
+			if op.kind != '+' {
+				old = append(old, op.text)
+				if a[op.oldLine-1] != op.text {
+					t.Fatalf("%v → %v: wrong old line number in %+v", a, b, op)
+				}
+			}
+			if op.kind != '-' {
+				new = append(new, op.text)
+				if b[op.newLine-1] != op.text {
+					t.Fatalf("%v → %v: wrong new line number in %+v", a, b, op)
+				}
+			}
+			if op.kind == ' ' {
+				unchanged++
+			}
+			if j > 0 && op.kind == '-' && ops[j-1].kind == '+' {
+				t.Fatalf("%v → %v: expected removed lines before added lines", a, b)
+			}
+		}
+		if strings.Join(old, ",") != strings.Join(a, ",") || strings.Join(new, ",") != strings.Join(b, ",") {
+			t.Fatalf("%v → %v: the diff doesn't rebuild both texts", a, b)
+		}
+		if expected := lcsLength(a, b); unchanged != expected {
+			t.Fatalf("%v → %v: expected %d unchanged lines but got %d", a, b, expected, unchanged)
+		}
+	}
+}
+
+/* TestDiffLinesLarge tests that large texts are diffed without a quadratic table */
+func TestDiffLinesLarge(t *testing.T) {
+	a := make([]string, 20000)
+	for i := range a {
+		a[i] = fmt.Sprintf("line %d", i)
+	}
+	b := append([]string(nil), a...)
+	b[10] = "changed"
+	b[15000] = "changed"
+
+	allocs := testing.AllocsPerRun(1, func() {
+		ops := diffLines(a, b)
+		if len(ops) != 20002 {
+			t.Errorf("Expected 20002 lines but got %d", len(ops))
+		}
+	})
+	if allocs > 100 {
+		t.Errorf("Expected a few allocations but got %.0f", allocs)
+	}
+
+	// texts differing everywhere are reported as a single change
+	for i := range b {
+		b[i] = "other " + a[i]
+	}
+	ops := diffLines(a, b)
+	if len(ops) != 40000 || ops[0].kind != '-' || ops[19999].kind != '-' || ops[20000].kind != '+' {
+		t.Error("Expected every old line removed, then every new line added")
+	}
+}
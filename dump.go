@@ -0,0 +1,129 @@
+package colorize
+
+import (
+	"flag"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// value shown in place of secrets
+const maskedValue = "********"
+
+// regex for the names of variables and flags holding secrets
+var secretRegex = regexp.MustCompile(`(?i)(secret|token|passw(or)?d|pwd|api_?key|private_?key|access_?key|credential|auth)`)
+
+/* dumpEntry is a key/value row of a configuration dump */
+type dumpEntry struct {
+	key   string
+	value string
+	note  string // dimmed annotation following the value (e.g., "(default)")
+}
+
+/*
+renderDump renders key/value rows as an aligned block, styled with the "dump.*" roles of
+DefaultTheme. The values of keys matching secretRegex are masked.
+
+Parameters:
+  - entries: The rows.
+
+Return:
+  - string: The block, one row per line, or an empty string if there are no rows.
+*/
+func renderDump(entries []dumpEntry) string {
+	width := 0
+	for _, entry := range entries {
+		width = max(width, visibleWidth(entry.key))
+	}
+
+	builder := strings.Builder{}
+	for _, entry := range entries {
+		builder.WriteString(DefaultTheme.Format("dump.key", entry.key))
+		builder.WriteString(strings.Repeat(" ", width-visibleWidth(entry.key)+2))
+		switch {
+		case secretRegex.MatchString(entry.key) && entry.value != "":
+			builder.WriteString(DefaultTheme.Format("dump.secret", maskedValue))
+		case entry.value == "":
+			builder.WriteString(DefaultTheme.Format("dump.note", `""`))
+		default:
+			builder.WriteString(DefaultTheme.Format("dump.value", entry.value))
+		}
+		if entry.note != "" {
+			builder.WriteString(" " + DefaultTheme.Format("dump.note", entry.note))
+		}
+		builder.WriteString("\n")
+	}
+	return withDebugGrid(builder.String())
+}
+
+/*
+DumpEnv renders the environment variables starting with one of the given prefixes as an aligned,
+colorized key/value block, sorted by name, for verbose or debug startup output. The values of
+variables whose name looks like a secret (e.g., API_TOKEN, DB_PASSWORD) are masked.
+
+Parameters:
+  - prefixes: The prefixes of the variables to show (e.g., "MYAPP_"), or nil for all of them.
+
+Return:
+  - string: The block, one variable per line, or an empty string if no variable matches.
+
+Example:
+
+	fmt.Fprint(os.Stderr, c.DumpEnv([]string{"MYAPP_"}))
+	// MYAPP_ENV       staging
+	// MYAPP_API_TOKEN ********
+*/
+func DumpEnv(prefixes []string) string {
+	entries := []dumpEntry{}
+	for _, variable := range os.Environ() {
+		key, value, _ := strings.Cut(variable, "=")
+		matches := len(prefixes) == 0
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				matches = true
+				break
+			}
+		}
+		if matches {
+			entries = append(entries, dumpEntry{key: key, value: value})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	return renderDump(entries)
+}
+
+/*
+DumpFlags renders the flags of a flag set with their current values as an aligned, colorized
+key/value block, sorted by name. Flags left to their default value are annotated with
+"(default)", and the values of flags whose name looks like a secret are masked.
+
+Parameters:
+  - fs: The flag set, or nil for flag.CommandLine.
+
+Return:
+  - string: The block, one flag per line, or an empty string if the set has no flags.
+
+Example:
+
+	flag.Parse()
+	if *verbose {
+		fmt.Fprint(os.Stderr, c.DumpFlags(nil))
+	}
+	// -addr     :8080 (default)
+	// -password ********
+*/
+func DumpFlags(fs *flag.FlagSet) string {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	entries := []dumpEntry{}
+	fs.VisitAll(func(f *flag.Flag) {
+		entry := dumpEntry{key: "-" + f.Name, value: f.Value.String()}
+		if entry.value == f.DefValue {
+			entry.note = translate("(default)")
+		}
+		entries = append(entries, entry)
+	})
+	return renderDump(entries)
+}
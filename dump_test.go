@@ -0,0 +1,49 @@
+package colorize
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+/* TestDumpEnv tests the DumpEnv function */
+func TestDumpEnv(t *testing.T) {
+	t.Setenv("DUMPTEST_ENV", "staging")
+	t.Setenv("DUMPTEST_API_TOKEN", "s3cr3t")
+	t.Setenv("DUMPTEST_EMPTY", "")
+	t.Setenv("OTHER_DUMPTEST", "ignored")
+
+	expected := "DUMPTEST_API_TOKEN  ********\n" +
+		"DUMPTEST_EMPTY      \"\"\n" +
+		"DUMPTEST_ENV        staging\n"
+	if got := stripANSI(DumpEnv([]string{"DUMPTEST_"})); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+	if got := DumpEnv([]string{"NO_SUCH_PREFIX_"}); got != "" {
+		t.Errorf("Expected an empty dump but got %q", got)
+	}
+}
+
+/* TestDumpFlags tests the DumpFlags function */
+func TestDumpFlags(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("addr", ":8080", "listen address")
+	fs.String("password", "", "database password")
+	fs.Int("workers", 4, "number of workers")
+	fs.Parse([]string{"-password", "hunter2", "-workers", "8"})
+
+	expected := "-addr      :8080 (default)\n" +
+		"-password  ********\n" +
+		"-workers   8\n"
+	got := DumpFlags(fs)
+	if stripANSI(got) != expected {
+		t.Errorf("Expected %q but got %q", expected, stripANSI(got))
+	}
+	if secret := DefaultTheme.Format("dump.secret", maskedValue); !strings.Contains(got, secret) {
+		t.Errorf("Expected the masked value to be styled: %q", got)
+	}
+}
@@ -0,0 +1,89 @@
+package colorize
+
+import (
+	"math"
+	"time"
+)
+
+/*
+The EasingFunc type represents an easing curve: it maps the progress of a transition (from 0 to 1)
+to the progress of the animated value (0 at the start, 1 at the end, possibly overshooting in
+between).
+*/
+type EasingFunc func(t float64) float64
+
+const (
+	// oscillations of the Spring easing over the transition
+	springOscillations = 1.5
+	// damping of the Spring easing (higher settles faster)
+	springDamping = 6.0
+)
+
+var (
+	/* Built-in easing curves */
+	Linear    EasingFunc = func(t float64) float64 { return t }
+	EaseIn    EasingFunc = func(t float64) float64 { return t * t }
+	EaseOut   EasingFunc = func(t float64) float64 { return 1 - (1-t)*(1-t) }
+	EaseInOut EasingFunc = func(t float64) float64 { return (1 - math.Cos(math.Pi*t)) / 2 }
+	Spring    EasingFunc = func(t float64) float64 {
+		return 1 - math.Exp(-springDamping*t)*math.Cos(2*math.Pi*springOscillations*t)
+	}
+)
+
+/*
+Ease returns the progress of an animated value at the given progress of the transition. The
+progress is clamped to [0, 1], and the value is exactly 0 at the start and 1 at the end whatever
+the curve.
+
+Parameters:
+  - t: The progress of the transition, from 0 to 1.
+  - fn: The easing curve (e.g., EaseInOut), or nil for Linear.
+
+Return:
+  - float64: The progress of the value.
+
+Example:
+
+	amount := c.Ease(0.25, c.EaseInOut) // 0.146...
+*/
+func Ease(t float64, fn EasingFunc) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	case fn == nil:
+		return t
+	}
+	return fn(t)
+}
+
+/*
+EaseElapsed returns the progress of an animated value after the given time, so that transitions
+take the same time and look the same whatever the frame rate they are drawn at.
+
+Parameters:
+  - elapsed: The time elapsed since the start of the transition.
+  - duration: The duration of the transition.
+  - fn: The easing curve, or nil for Linear.
+
+Return:
+  - float64: The progress of the value (1 once the duration has elapsed).
+
+Example:
+
+	start := time.Now()
+	for range time.Tick(time.Second / 30) {
+		amount := c.EaseElapsed(time.Since(start), 500*time.Millisecond, c.EaseOut)
+		draw(amount)
+		if amount == 1 {
+			break
+		}
+	}
+*/
+func EaseElapsed(elapsed time.Duration, duration time.Duration, fn EasingFunc) float64 {
+	if duration <= 0 {
+		return 1
+	}
+	return Ease(float64(elapsed)/float64(duration), fn)
+}
@@ -0,0 +1,67 @@
+package colorize
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+/* TestEase tests the built-in easing curves */
+func TestEase(t *testing.T) {
+	curves := map[string]EasingFunc{"linear": Linear, "in": EaseIn, "out": EaseOut, "inout": EaseInOut, "spring": Spring, "nil": nil}
+	for name, fn := range curves {
+		// exact endpoints, clamped progress
+		for _, test := range []struct{ t, expected float64 }{{-1, 0}, {0, 0}, {1, 1}, {2, 1}} {
+			if got := Ease(test.t, fn); got != test.expected {
+				t.Errorf("%s(%v): expected %v but got %v", name, test.t, test.expected, got)
+			}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		fn       EasingFunc
+		t        float64
+		expected float64
+	}{
+		{"linear", Linear, 0.25, 0.25},
+		{"nil", nil, 0.25, 0.25},
+		{"in", EaseIn, 0.5, 0.25},
+		{"out", EaseOut, 0.5, 0.75},
+		{"inout", EaseInOut, 0.5, 0.5},
+		{"inout", EaseInOut, 0.25, (1 - math.Sqrt2/2) / 2},
+	}
+	for _, test := range tests {
+		if got := Ease(test.t, test.fn); math.Abs(got-test.expected) > 1e-9 {
+			t.Errorf("%s(%v): expected %v but got %v", test.name, test.t, test.expected, got)
+		}
+	}
+
+	// the spring overshoots before settling
+	overshoot := 0.0
+	for i := 1; i < 100; i++ {
+		overshoot = max(overshoot, Ease(float64(i)/100, Spring))
+	}
+	if overshoot <= 1 {
+		t.Errorf("Expected the spring to overshoot but got a maximum of %v", overshoot)
+	}
+}
+
+/* TestEaseElapsed tests that the progress depends on the elapsed time only */
+func TestEaseElapsed(t *testing.T) {
+	tests := []struct {
+		elapsed, duration time.Duration
+		expected          float64
+	}{
+		{0, time.Second, 0},
+		{250 * time.Millisecond, time.Second, 0.0625},
+		{time.Second, time.Second, 1},
+		{2 * time.Second, time.Second, 1},
+		{time.Second, 0, 1},
+	}
+	for _, test := range tests {
+		if got := EaseElapsed(test.elapsed, test.duration, EaseIn); math.Abs(got-test.expected) > 1e-9 {
+			t.Errorf("%v/%v: expected %v but got %v", test.elapsed, test.duration, test.expected, got)
+		}
+	}
+}
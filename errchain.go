@@ -0,0 +1,188 @@
+package colorize
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// maximum depth of the error trees rendered by FormatErrorChain (guards against cycles)
+const maxErrorDepth = 64
+
+/*
+The ErrorLocation interface can be implemented by errors to report where they were created, which
+FormatErrorChain shows next to their message.
+*/
+type ErrorLocation interface {
+	Location() (file string, line int)
+}
+
+/*
+errorCauses returns the direct causes of an error: the errors returned by Unwrap() []error (e.g.,
+errors.Join), Unwrap() error or Cause() error (github.com/pkg/errors style causers).
+
+Parameters:
+  - err: The error.
+
+Return:
+  - []error: The causes, nil if there are none.
+*/
+func errorCauses(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	case interface{ Unwrap() error }:
+		if cause := e.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	case interface{ Cause() error }:
+		if cause := e.Cause(); cause != nil && cause != err {
+			return []error{cause}
+		}
+	}
+	return nil
+}
+
+/*
+errorLocation returns the location an error was created at, from the ErrorLocation interface or,
+for errors with a stack trace (a StackTrace method returning program counters, like
+github.com/pkg/errors), from the innermost frame.
+
+Parameters:
+  - err: The error.
+
+Return:
+  - string: The location ("file.go:12"), empty if it's not available.
+*/
+func errorLocation(err error) string {
+	if e, ok := err.(ErrorLocation); ok {
+		if file, line := e.Location(); file != "" {
+			return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+		}
+		return ""
+	}
+
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return ""
+	}
+	trace := method.Call(nil)[0]
+	if trace.Kind() != reflect.Slice || trace.Len() == 0 || trace.Index(0).Kind() != reflect.Uintptr {
+		return ""
+	}
+	// the frames hold return addresses
+	pc := uintptr(trace.Index(0).Uint()) - 1
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+	file, line := fn.FileLine(pc)
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
+
+/*
+errorMessage returns the message of an error without the messages of its causes, which are shown
+as separate nodes of the tree.
+
+Parameters:
+  - err: The error.
+  - causes: The causes of the error.
+
+Return:
+  - string: The message.
+*/
+func errorMessage(err error, causes []error) string {
+	msg := err.Error()
+	if len(causes) > 1 {
+		return fmt.Sprintf(translate("%d errors"), len(causes))
+	}
+	if len(causes) == 1 {
+		if trimmed := strings.TrimSuffix(msg, ": "+causes[0].Error()); trimmed != msg {
+			return trimmed
+		}
+		if msg == causes[0].Error() {
+			return fmt.Sprintf("%T", err)
+		}
+	}
+	return msg
+}
+
+/*
+writeErrorNode writes an error and its causes as a tree.
+
+Parameters:
+  - builder: The builder to write to.
+  - err: The error.
+  - prefix: The tree guides of the parent.
+  - branch: The tree guide of the error.
+  - depth: The depth of the error.
+  - theme: The theme styling the tree.
+  - set: The glyphs of the tree connectors.
+*/
+func writeErrorNode(builder *strings.Builder, err error, prefix string, branch string, depth int, theme Theme, set GlyphSet) {
+	causes := errorCauses(err)
+
+	role := "error.message"
+	if depth > 0 {
+		role = "error.cause"
+	}
+	builder.WriteString(theme.Format("error.tree", prefix+branch) + theme.Format(role, errorMessage(err, causes)))
+	if location := errorLocation(err); location != "" {
+		builder.WriteString("  " + theme.Format("error.location", "("+location+")"))
+	}
+	builder.WriteString("\n")
+
+	if depth >= maxErrorDepth {
+		return
+	}
+
+	// the guides of the children continue the guide of the error
+	switch branch {
+	case set.TreeBranch:
+		prefix += set.TreeVertical
+	case set.TreeLast:
+		prefix += set.TreeSpace
+	}
+	for i, cause := range causes {
+		if cause == nil {
+			continue
+		}
+		childBranch := set.TreeBranch
+		if i == len(causes)-1 {
+			childBranch = set.TreeLast
+		}
+		writeErrorNode(builder, cause, prefix, childBranch, depth+1, theme, set)
+	}
+}
+
+/*
+FormatErrorChain renders an error and its causes (walking Unwrap, errors.Join trees and Cause) as
+a colored tree, styled with the "error.*" roles of DefaultTheme. Each node shows the message of the
+error without the messages of its causes and, when available (see ErrorLocation), its location,
+dimmed. The tree connectors are the glyphs of the current glyph set (see SetGlyphSet).
+
+Parameters:
+  - err: The error.
+
+Return:
+  - string: The tree, one error per line, or an empty string if err is nil.
+
+Example:
+
+	err := fmt.Errorf("load config: %w", errors.Join(errParse, errPermission))
+	fmt.Print(c.FormatErrorChain(err))
+	// load config
+	// └─ 2 errors
+	//    ├─ parse error
+	//    └─ permission denied
+*/
+func FormatErrorChain(err error) string {
+	if err == nil {
+		return ""
+	}
+	builder := strings.Builder{}
+	writeErrorNode(&builder, err, "", "", 0, DefaultTheme, glyphs())
+	return builder.String()
+}
@@ -0,0 +1,77 @@
+package colorize
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+/* locatedErr is an error implementing the ErrorLocation interface */
+type locatedErr struct{}
+
+func (locatedErr) Error() string                     { return "located" }
+func (locatedErr) Location() (file string, line int) { return "/src/app/config.go", 12 }
+
+/* stackErr is an error with a github.com/pkg/errors style stack trace */
+type stackTrace []frame
+type frame uintptr
+type stackErr struct{ stack stackTrace }
+
+func (stackErr) Error() string            { return "stacked" }
+func (e stackErr) StackTrace() stackTrace { return e.stack }
+func newStackErr() stackErr               { return stackErr{callers()} }
+func callers() stackTrace {
+	pcs := make([]uintptr, 1)
+	runtime.Callers(3, pcs)
+	return stackTrace{frame(pcs[0])}
+}
+
+/* causeErr is a github.com/pkg/errors style causer */
+type causeErr struct{ cause error }
+
+func (e causeErr) Error() string { return "wrapped: " + e.cause.Error() }
+func (e causeErr) Cause() error  { return e.cause }
+
+/* TestFormatErrorChain tests the FormatErrorChain function */
+func TestFormatErrorChain(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	if FormatErrorChain(nil) != "" {
+		t.Error("Expected an empty string for a nil error")
+	}
+
+	errParse := errors.New("parse error")
+	err := fmt.Errorf("load config: %w", errors.Join(errParse, causeErr{locatedErr{}}))
+	expected := strings.Join([]string{
+		"load config",
+		"└─ 2 errors",
+		"   ├─ parse error",
+		"   └─ wrapped",
+		"      └─ located  (config.go:12)",
+		"",
+	}, "\n")
+	if got := FormatErrorChain(err); got != expected {
+		t.Errorf("Expected:\n%s\nbut got:\n%s", expected, got)
+	}
+
+	// stack traces
+	got := FormatErrorChain(newStackErr())
+	if !strings.HasPrefix(got, "stacked  (errchain_test.go:") {
+		t.Errorf("Expected the location of the error but got %q", got)
+	}
+
+	// styled
+	trueColor = true
+	got = FormatErrorChain(fmt.Errorf("a: %w", errParse))
+	expected = DefaultTheme.Format("error.message", "a") + "\n" +
+		DefaultTheme.Format("error.tree", "└─ ") + DefaultTheme.Format("error.cause", "parse error") + "\n"
+	if got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
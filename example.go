@@ -0,0 +1,68 @@
+package colorize
+
+import "strings"
+
+// indentation of the lines of usage examples
+const exampleIndent = "  "
+
+/* The Example type represents a usage example of a command line interface */
+type Example struct {
+	Command     string // command line (e.g., "mytool deploy --env staging")
+	Description string // comment describing the command, optional
+}
+
+/*
+FormatExample renders a usage example: the description as a comment ("# ..."), dimmed, followed by
+the command line, in cyan, both indented. They are styled with the "example.comment" and
+"example.command" roles of DefaultTheme.
+
+Parameters:
+  - cmdline: The command line.
+  - description: The description of the command, or an empty string for none. Multi-line
+    descriptions produce one comment line per line.
+
+Return:
+  - string: The example, without a trailing newline.
+
+Example:
+
+	fmt.Println(c.FormatExample("mytool deploy --env staging", "Deploy to the staging environment"))
+	//   # Deploy to the staging environment
+	//   mytool deploy --env staging
+*/
+func FormatExample(cmdline string, description string) string {
+	lines := []string{}
+	if description != "" {
+		for _, line := range strings.Split(description, "\n") {
+			lines = append(lines, exampleIndent+DefaultTheme.Format("example.comment", "# "+line))
+		}
+	}
+	lines = append(lines, exampleIndent+DefaultTheme.Format("example.command", cmdline))
+	return strings.Join(lines, "\n")
+}
+
+/*
+ExampleBlock renders a section of usage examples (see FormatExample), separated by blank lines, so
+that every command of a CLI shows its examples consistently.
+
+Parameters:
+  - examples: The examples.
+
+Return:
+  - string: The examples, without a trailing newline, or an empty string if there are none.
+
+Example:
+
+	fmt.Println("Examples:")
+	fmt.Println(c.ExampleBlock([]c.Example{
+		{Command: "mytool deploy", Description: "Deploy to production"},
+		{Command: "mytool deploy --env staging", Description: "Deploy to staging"},
+	}))
+*/
+func ExampleBlock(examples []Example) string {
+	blocks := make([]string, len(examples))
+	for i, example := range examples {
+		blocks[i] = FormatExample(example.Command, example.Description)
+	}
+	return withDebugGrid(strings.Join(blocks, "\n\n"))
+}
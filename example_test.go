@@ -0,0 +1,38 @@
+package colorize
+
+import (
+	"strings"
+	"testing"
+)
+
+/* TestFormatExample tests the FormatExample and ExampleBlock functions */
+func TestFormatExample(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	got := FormatExample("mytool deploy", "Deploy\nto production")
+	expected := "  " + DefaultTheme.Format("example.comment", "# Deploy") + "\n" +
+		"  " + DefaultTheme.Format("example.comment", "# to production") + "\n" +
+		"  " + DefaultTheme.Format("example.command", "mytool deploy")
+	if got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	// no description
+	if got := stripANSI(FormatExample("mytool", "")); got != "  mytool" {
+		t.Errorf("Unexpected example: %q", got)
+	}
+
+	// blocks
+	block := stripANSI(ExampleBlock([]Example{
+		{Command: "mytool deploy", Description: "Deploy"},
+		{Command: "mytool status"},
+	}))
+	if expected := strings.Join([]string{"  # Deploy", "  mytool deploy", "", "  mytool status"}, "\n"); block != expected {
+		t.Errorf("Expected %q but got %q", expected, block)
+	}
+	if got := ExampleBlock(nil); got != "" {
+		t.Errorf("Expected an empty block but got %q", got)
+	}
+}
@@ -0,0 +1,245 @@
+package colorize
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+/* The Explanation type describes how FormatText renders a text with the given options */
+type Explanation struct {
+	Profile string            // color profile chosen: "truecolor", "xterm", "ansi16" or "none"
+	Reason  string            // why the profile was chosen
+	Colors  []ColorResolution // how each color in the options was resolved
+	Styles  []string          // styles that were applied
+	Dropped []DroppedStyle    // styles that were dropped
+	Output  string            // the text as returned by FormatText
+	Err     error             // the error returned by FormatText, if any
+}
+
+/* The ColorResolution type describes how a single color of the options was resolved */
+type ColorResolution struct {
+	Context    ColorContext // background or foreground
+	Input      string       // the color as provided in the options
+	Resolved   string       // the normalized hex code ("#RRGGBB"), empty if invalid
+	Name       string       // the closest CSS color name (see NameOf), empty if invalid
+	Xterm      int          // the Xterm color code when downgraded to the Xterm palette, -1 otherwise
+	Ansi16     int          // the basic color (0-15) when downgraded to the 16 ANSI colors, -1 otherwise
+	Downgraded bool         // whether the color was approximated to a palette
+	Err        error        // the error found while resolving the color, if any
+}
+
+/* The DroppedStyle type describes a style that was not applied and why */
+type DroppedStyle struct {
+	Style  string // the style as provided in the options
+	Reason string // why the style was dropped
+}
+
+/*
+getProfile returns the name of the color profile in use and the reason it was chosen.
+
+Return:
+  - string: The profile name ("truecolor", "xterm", "ansi16" or "none").
+  - string: The reason the profile was chosen.
+*/
+func getProfile() (string, string) {
+	if levelOverride {
+		switch ColorLevel() {
+		case LevelTrueColor:
+			return "truecolor", "set by SetColorLevel"
+		case LevelAnsi256:
+			return "xterm", "set by SetColorLevel"
+		case LevelAnsi16:
+			return "ansi16", "set by SetColorLevel"
+		}
+		return "none", "set by SetColorLevel"
+	}
+	if noColor {
+		if forceLevel == 0 {
+			return "none", forceSource
+		}
+		return "none", fmt.Sprintf("NO_COLOR=%q", os.Getenv("NO_COLOR"))
+	}
+	if !terminalAllows(os.Stdout) {
+		return "none", "stdout is not a terminal (terminal-only mode)"
+	}
+	if trueColor {
+		if termColorLevel != LevelTrueColor && forceLevel == 3 {
+			return "truecolor", forceSource
+		}
+		if termColorLevel != LevelTrueColor && runtime.GOOS == "windows" {
+			return "truecolor", "Windows console with virtual terminal processing"
+		}
+		return "truecolor", termSource
+	}
+	if xTerm {
+		if termColorLevel < LevelAnsi256 && forceLevel >= 2 {
+			return "xterm", forceSource
+		}
+		return "xterm", termSource
+	}
+	if ansi16 {
+		if termColorLevel < LevelAnsi16 && forceLevel > 0 {
+			return "ansi16", forceSource
+		}
+		return "ansi16", termSource
+	}
+	return "none", fmt.Sprintf("neither COLORTERM=%q nor TERM=%q is supported", os.Getenv("COLORTERM"), os.Getenv("TERM"))
+}
+
+/*
+explainColor resolves a single color the same way FormatText does and describes the result.
+
+Parameters:
+  - hex: The hexadecimal color code as provided in the options.
+  - ctx: The color context (background or foreground).
+
+Return:
+  - ColorResolution: The description of the resolved color.
+*/
+func explainColor(hex string, ctx ColorContext) ColorResolution {
+	res := ColorResolution{Context: ctx, Input: hex, Xterm: -1, Ansi16: -1}
+
+	col, err := getColor(hex)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	res.Resolved = fmt.Sprintf("#%02X%02X%02X", col.r, col.g, col.b)
+	res.Name, _ = nearestName(col)
+	switch {
+	case trueColor:
+	case xTerm:
+		res.Downgraded = true
+		res.Xterm = int(rgbToXterm(col))
+	case ansi16:
+		res.Downgraded = true
+		res.Ansi16 = int(rgbToAnsi16(col))
+	}
+
+	return res
+}
+
+/*
+explainIndex resolves a single Xterm palette index the same way FormatText does and describes the
+result.
+
+Parameters:
+  - index: The Xterm palette index as provided in the options.
+  - ctx: The color context (background or foreground).
+
+Return:
+  - ColorResolution: The description of the resolved color.
+*/
+func explainIndex(index uint8, ctx ColorContext) ColorResolution {
+	col := xtermToRGB(index)
+	res := ColorResolution{Context: ctx, Input: fmt.Sprintf("xterm %d", index), Xterm: int(index), Ansi16: -1}
+	res.Resolved = fmt.Sprintf("#%02X%02X%02X", col.r, col.g, col.b)
+	res.Name, _ = nearestName(col)
+	if !trueColor && !xTerm && ansi16 {
+		res.Downgraded = index >= colorOffset
+		res.Ansi16 = int(rgbToAnsi16(col))
+	}
+	return res
+}
+
+/*
+Explain describes how FormatText renders the given text with the specified options. The text is
+formatted with FormatText, so the call is reported to the metrics sink like any other (see
+SetMetricsSink).
+
+The returned Explanation reports which color profile was chosen, how each color was resolved (and
+whether it was downgraded to the Xterm palette or the 16 ANSI colors), which styles were applied
+and which ones were dropped and why. It is meant to help diagnosing reports such as "colors look wrong on my terminal".
+
+Parameters:
+  - text: The text to be formatted.
+  - options: The formatting options including background color, foreground color, and styles.
+
+Return:
+  - Explanation: The description of the rendering.
+
+Example:
+
+	exp := c.Explain("Hello, world!", &c.Options{FgColor: "#FF0000", Styles: []string{"bold", "shiny"}})
+	fmt.Println(exp) // prints a human-readable report
+*/
+func Explain(text string, options *Options) Explanation {
+	exp := Explanation{}
+	exp.Profile, exp.Reason = getProfile()
+	exp.Output, exp.Err = FormatText(text, options)
+
+	if options == nil {
+		return exp
+	}
+	options = options.withImageColors()
+
+	if options.BgColor256 != nil {
+		exp.Colors = append(exp.Colors, explainIndex(*options.BgColor256, background))
+	} else if options.BgColor != "" {
+		exp.Colors = append(exp.Colors, explainColor(options.BgColor, background))
+	}
+	if options.FgColor256 != nil {
+		exp.Colors = append(exp.Colors, explainIndex(*options.FgColor256, foreground))
+	} else if options.FgColor != "" {
+		exp.Colors = append(exp.Colors, explainColor(options.FgColor, foreground))
+	}
+
+	for _, s := range options.Styles {
+		switch {
+		case noColor && levelOverride:
+			exp.Dropped = append(exp.Dropped, DroppedStyle{Style: s, Reason: "colors disabled by SetColorLevel"})
+		case noColor:
+			exp.Dropped = append(exp.Dropped, DroppedStyle{Style: s, Reason: "colors disabled by NO_COLOR"})
+		case !terminalAllows(os.Stdout):
+			exp.Dropped = append(exp.Dropped, DroppedStyle{Style: s, Reason: "stdout is not a terminal"})
+		case exp.Profile == "none":
+			exp.Dropped = append(exp.Dropped, DroppedStyle{Style: s, Reason: "system does not support true color, xterm or ansi colors"})
+		case exp.Err != nil:
+			exp.Dropped = append(exp.Dropped, DroppedStyle{Style: s, Reason: "formatting failed"})
+		case styles[s] == "":
+			exp.Dropped = append(exp.Dropped, DroppedStyle{Style: s, Reason: "unknown style"})
+		default:
+			exp.Styles = append(exp.Styles, s)
+		}
+	}
+
+	return exp
+}
+
+/*
+String returns a human-readable report of the Explanation.
+
+Return:
+  - string: The report, one line per item.
+*/
+func (e Explanation) String() string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("profile: %s (%s)\n", e.Profile, e.Reason))
+	for _, col := range e.Colors {
+		switch {
+		case col.Err != nil:
+			builder.WriteString(fmt.Sprintf("%s: %s -> %v\n", col.Context, col.Input, col.Err))
+		case col.Downgraded && col.Ansi16 >= 0:
+			builder.WriteString(fmt.Sprintf("%s: %s -> %s ~%s (downgraded to ansi %d)\n", col.Context, col.Input, col.Resolved, col.Name, col.Ansi16))
+		case col.Downgraded:
+			builder.WriteString(fmt.Sprintf("%s: %s -> %s ~%s (downgraded to xterm %d)\n", col.Context, col.Input, col.Resolved, col.Name, col.Xterm))
+		default:
+			builder.WriteString(fmt.Sprintf("%s: %s -> %s ~%s\n", col.Context, col.Input, col.Resolved, col.Name))
+		}
+	}
+	for _, s := range e.Styles {
+		builder.WriteString(fmt.Sprintf("style: %s\n", s))
+	}
+	for _, d := range e.Dropped {
+		builder.WriteString(fmt.Sprintf("dropped style: %s (%s)\n", d.Style, d.Reason))
+	}
+	if e.Err != nil {
+		builder.WriteString(fmt.Sprintf("error: %v\n", e.Err))
+	}
+
+	return builder.String()
+}
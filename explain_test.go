@@ -0,0 +1,70 @@
+package colorize
+
+import (
+	"strings"
+	"testing"
+)
+
+/* TestExplain tests the Explain function */
+func TestExplain(t *testing.T) {
+	// defer restore
+	defer restore()
+
+	opts := &Options{FgColor: "#FF0000", BgColor: "#0000FF0", Styles: []string{"bold", "shiny"}}
+
+	// true color support
+	trueColor = true
+	exp := Explain("test", &Options{FgColor: "#ff0000", Styles: []string{"bold", "shiny"}})
+	if exp.Profile != "truecolor" {
+		t.Errorf("Expected profile to be 'truecolor' but got '%s'", exp.Profile)
+	}
+	if len(exp.Colors) != 1 || exp.Colors[0].Resolved != "#FF0000" || exp.Colors[0].Downgraded {
+		t.Error("Expected the foreground color to be resolved without downgrade")
+	}
+	if exp.Colors[0].Name != "red" || !strings.Contains(exp.String(), "foreground: #ff0000 -> #FF0000 ~red\n") {
+		t.Errorf("Expected the color name in the report but got %q", exp.String())
+	}
+	if len(exp.Styles) != 1 || exp.Styles[0] != "bold" {
+		t.Error("Expected the bold style to be applied")
+	}
+	if len(exp.Dropped) != 1 || exp.Dropped[0].Reason != "unknown style" {
+		t.Error("Expected the shiny style to be dropped as unknown")
+	}
+
+	// xterm support
+	trueColor = false
+	xTerm = true
+	exp = Explain("test", &Options{FgColor: "#FF0000"})
+	if exp.Profile != "xterm" {
+		t.Errorf("Expected profile to be 'xterm' but got '%s'", exp.Profile)
+	}
+	if !exp.Colors[0].Downgraded || exp.Colors[0].Xterm != int(rgbToXterm(&color{255, 0, 0})) {
+		t.Error("Expected the foreground color to be downgraded")
+	}
+
+	// invalid color
+	exp = Explain("test", opts)
+	if exp.Err == nil || exp.Colors[0].Err == nil {
+		t.Error("Expected an error but got nil")
+	}
+	if exp.Output != "test" {
+		t.Error("Expected the original text to be returned")
+	}
+	if !strings.Contains(exp.String(), "dropped style: bold") {
+		t.Error("Expected the report to list the dropped styles")
+	}
+
+	// no color support
+	xTerm = false
+	ansi16 = false
+	exp = Explain("test", &Options{Styles: []string{"bold"}})
+	if exp.Profile != "none" || len(exp.Dropped) != 1 {
+		t.Error("Expected every style to be dropped without color support")
+	}
+
+	// no options
+	exp = Explain("test", nil)
+	if exp.Err == nil || len(exp.Colors) != 0 {
+		t.Error("Expected an error and no colors without options")
+	}
+}
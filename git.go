@@ -0,0 +1,107 @@
+package colorize
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+GitStatus formats a git short-status code (the two "XY" characters printed by `git status --short`)
+using the git roles of the given theme (DefaultTheme if nil):
+
+  - "git.staged": changes in the index (X).
+  - "git.unstaged": changes in the working tree (Y).
+  - "git.untracked": untracked files ("??").
+  - "git.ignored": ignored files ("!!").
+  - "git.conflict": unmerged paths ("UU", "AA", "DD", "AU", "UA", "DU", "UD").
+
+Parameters:
+  - code: The short-status code (e.g., "M ", " M", "??").
+  - theme: The theme to use, or nil.
+
+Return:
+  - string: The formatted status code.
+
+Example:
+
+	fmt.Println(c.GitStatus("M ", nil), "colorize.go")
+*/
+func GitStatus(code string, theme Theme) string {
+	theme = theme.orDefault()
+
+	switch code {
+	case "??":
+		return theme.Format("git.untracked", code)
+	case "!!":
+		return theme.Format("git.ignored", code)
+	case "UU", "AA", "DD", "AU", "UA", "DU", "UD":
+		return theme.Format("git.conflict", code)
+	}
+
+	if len(code) != 2 {
+		return code
+	}
+
+	staged, unstaged := code[:1], code[1:]
+	if staged != " " {
+		staged = theme.Format("git.staged", staged)
+	}
+	if unstaged != " " {
+		unstaged = theme.Format("git.unstaged", unstaged)
+	}
+	return staged + unstaged
+}
+
+/*
+GitBranch formats a branch name using the "git.branch" role of the given theme (DefaultTheme if
+nil). Detached heads ("HEAD" or "(HEAD detached at ...)") use the "git.detached" role instead.
+
+Parameters:
+  - name: The branch name.
+  - theme: The theme to use, or nil.
+
+Return:
+  - string: The formatted branch name.
+
+Example:
+
+	fmt.Println("On branch", c.GitBranch("main", nil))
+*/
+func GitBranch(name string, theme Theme) string {
+	theme = theme.orDefault()
+
+	if name == "HEAD" || strings.HasPrefix(name, "(HEAD detached") {
+		return theme.Format("git.detached", name)
+	}
+	return theme.Format("git.branch", name)
+}
+
+/*
+GitAheadBehind formats the ahead/behind markers of a branch ("↑2 ↓1") using the "git.ahead" and
+"git.behind" roles of the given theme (DefaultTheme if nil). Zero counts are omitted, so an
+up-to-date branch produces an empty string.
+
+Parameters:
+  - ahead: The number of commits ahead of the upstream branch.
+  - behind: The number of commits behind the upstream branch.
+  - theme: The theme to use, or nil.
+
+Return:
+  - string: The formatted markers.
+
+Example:
+
+	fmt.Println(c.GitBranch("main", nil), c.GitAheadBehind(2, 1, nil))
+*/
+func GitAheadBehind(ahead int, behind int, theme Theme) string {
+	theme = theme.orDefault()
+
+	markers := []string{}
+	if ahead > 0 {
+		markers = append(markers, theme.Format("git.ahead", fmt.Sprintf("↑%d", ahead)))
+	}
+	if behind > 0 {
+		markers = append(markers, theme.Format("git.behind", fmt.Sprintf("↓%d", behind)))
+	}
+	return strings.Join(markers, " ")
+}
@@ -0,0 +1,92 @@
+package colorize
+
+import (
+	"testing"
+)
+
+var testTheme = Theme{
+	"git.staged":    {Styles: []string{"bold"}},
+	"git.unstaged":  {Styles: []string{"italic"}},
+	"git.untracked": {Styles: []string{"underline"}},
+	"git.conflict":  {Styles: []string{"reverse"}},
+	"git.branch":    {Styles: []string{"bold"}},
+	"git.detached":  {Styles: []string{"italic"}},
+	"git.ahead":     {Styles: []string{"bold"}},
+	"git.behind":    {Styles: []string{"italic"}},
+}
+
+/* TestThemeFormat tests the Format method of the Theme type */
+func TestThemeFormat(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	if testTheme.Format("git.branch", "main") != styles["bold"]+"main"+reset {
+		t.Error("Expected the role options to be applied")
+	}
+	if testTheme.Format("unknown", "main") != "main" {
+		t.Error("Expected unknown roles to be rendered as plain text")
+	}
+	if testTheme.Format("git.branch", "") != "" {
+		t.Error("Expected empty text to be returned unmodified")
+	}
+}
+
+/* TestGitStatus tests the GitStatus function */
+func TestGitStatus(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	cases := map[string]string{
+		"M ":  styles["bold"] + "M" + reset + " ",
+		" M":  " " + styles["italic"] + "M" + reset,
+		"MM":  styles["bold"] + "M" + reset + styles["italic"] + "M" + reset,
+		"??":  styles["underline"] + "??" + reset,
+		"UU":  styles["reverse"] + "UU" + reset,
+		"!!":  "!!", // no git.ignored role in the test theme
+		"bad": "bad",
+	}
+	for code, want := range cases {
+		if got := GitStatus(code, testTheme); got != want {
+			t.Errorf("Expected %q but got %q", want, got)
+		}
+	}
+
+	// default theme
+	if GitStatus("M ", nil) == "M " {
+		t.Error("Expected the default theme to be used")
+	}
+}
+
+/* TestGitBranch tests the GitBranch function */
+func TestGitBranch(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	if GitBranch("main", testTheme) != styles["bold"]+"main"+reset {
+		t.Error("Expected the branch role to be applied")
+	}
+	if GitBranch("(HEAD detached at 1a2b3c)", testTheme) != styles["italic"]+"(HEAD detached at 1a2b3c)"+reset {
+		t.Error("Expected the detached role to be applied")
+	}
+}
+
+/* TestGitAheadBehind tests the GitAheadBehind function */
+func TestGitAheadBehind(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	if GitAheadBehind(0, 0, testTheme) != "" {
+		t.Error("Expected an empty string for an up-to-date branch")
+	}
+	if GitAheadBehind(2, 0, testTheme) != styles["bold"]+"↑2"+reset {
+		t.Error("Expected only the ahead marker")
+	}
+	want := styles["bold"] + "↑2" + reset + " " + styles["italic"] + "↓1" + reset
+	if got := GitAheadBehind(2, 1, testTheme); got != want {
+		t.Errorf("Expected %q but got %q", want, got)
+	}
+}
@@ -0,0 +1,184 @@
+package colorize
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+/* The GlyphSet type holds the glyphs widgets are drawn with */
+type GlyphSet struct {
+	Horizontal  string // horizontal border line (e.g., rules)
+	Vertical    string // vertical border line
+	TopLeft     string // top left border corner
+	TopRight    string // top right border corner
+	BottomLeft  string // bottom left border corner
+	BottomRight string // bottom right border corner
+
+	Spinner       []string // frames of a spinner
+	ProgressFull  string   // filled part of a progress bar
+	ProgressEmpty string   // empty part of a progress bar
+
+	TreeBranch   string // tree connector of a child followed by siblings
+	TreeLast     string // tree connector of the last child
+	TreeVertical string // tree guide continuing a branch
+	TreeSpace    string // tree guide below a last child
+
+	Bullet string // list bullet
+}
+
+// glyphs of the built-in "unicode" set, the fallback of the empty glyphs of the other sets
+var unicodeGlyphs = GlyphSet{
+	Horizontal: "─", Vertical: "│", TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘",
+	Spinner:      []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+	ProgressFull: "█", ProgressEmpty: "░",
+	TreeBranch: "├─ ", TreeLast: "└─ ", TreeVertical: "│  ", TreeSpace: "   ",
+	Bullet: "•",
+}
+
+var (
+	// glyph set registry, keyed by name
+	glyphSetsMu = sync.RWMutex{}
+	glyphSets   = map[string]GlyphSet{
+		"unicode": unicodeGlyphs,
+		"ascii": {
+			Horizontal: "-", Vertical: "|", TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+			Spinner:      []string{"|", "/", "-", "\\"},
+			ProgressFull: "#", ProgressEmpty: ".",
+			TreeBranch: "|- ", TreeLast: "`- ", TreeVertical: "|  ", TreeSpace: "   ",
+			Bullet: "*",
+		},
+		"heavy": {
+			Horizontal: "━", Vertical: "┃", TopLeft: "┏", TopRight: "┓", BottomLeft: "┗", BottomRight: "┛",
+			Spinner:      []string{"◐", "◓", "◑", "◒"},
+			ProgressFull: "█", ProgressEmpty: "▒",
+			TreeBranch: "┣━ ", TreeLast: "┗━ ", TreeVertical: "┃  ", TreeSpace: "   ",
+			Bullet: "●",
+		},
+		"rounded": {
+			Horizontal: "─", Vertical: "│", TopLeft: "╭", TopRight: "╮", BottomLeft: "╰", BottomRight: "╯",
+			Spinner:      []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+			ProgressFull: "█", ProgressEmpty: "░",
+			TreeBranch: "├─ ", TreeLast: "╰─ ", TreeVertical: "│  ", TreeSpace: "   ",
+			Bullet: "•",
+		},
+	}
+
+	// name of the glyph set used by the widgets, empty to follow the font capability
+	glyphSetName = ""
+)
+
+/*
+RegisterGlyphSet adds a glyph set to the registry, or replaces an existing one (including the
+built-in ones).
+
+Empty glyphs fall back to the glyphs of the built-in "unicode" set, so a set can override a few glyphs only.
+
+Parameters:
+  - name: The name of the set.
+  - set: The glyphs.
+
+Example:
+
+	c.RegisterGlyphSet("arrows", c.GlyphSet{TreeBranch: "├▶ ", TreeLast: "└▶ "})
+*/
+func RegisterGlyphSet(name string, set GlyphSet) {
+	glyphSetsMu.Lock()
+	defer glyphSetsMu.Unlock()
+	glyphSets[name] = set
+}
+
+/*
+SetGlyphSet sets the glyph set widgets are drawn with (e.g., Rule and FormatErrorChain). By default,
+the "ascii" set is used with FontASCII (see SetFontCapability) and the "unicode" set otherwise.
+
+Built-in sets: unicode, ascii, heavy and rounded.
+
+Parameters:
+  - name: The name of the set, or an empty string to restore the default.
+
+Return:
+  - error: An error if the set is not registered.
+*/
+func SetGlyphSet(name string) error {
+	glyphSetsMu.Lock()
+	defer glyphSetsMu.Unlock()
+	if _, ok := glyphSets[name]; !ok && name != "" {
+		return fmt.Errorf("unknown glyph set: %s", name)
+	}
+	glyphSetName = name
+	return nil
+}
+
+/*
+GetGlyphSet returns a registered glyph set, its empty glyphs filled from the built-in "unicode"
+set.
+
+Parameters:
+  - name: The name of the set, or an empty string for the set widgets are drawn with (see
+    SetGlyphSet).
+
+Return:
+  - GlyphSet: The glyphs.
+  - bool: false if the set is not registered.
+
+Example:
+
+	glyphs, _ := c.GetGlyphSet("")
+	fmt.Println(glyphs.Bullet, "first item")
+*/
+func GetGlyphSet(name string) (GlyphSet, bool) {
+	iconsMu.RLock()
+	capability := fontCapability
+	iconsMu.RUnlock()
+
+	glyphSetsMu.RLock()
+	defer glyphSetsMu.RUnlock()
+
+	if name == "" {
+		name = glyphSetName
+	}
+	if name == "" {
+		name = "unicode"
+		if capability == FontASCII {
+			name = "ascii"
+		}
+	}
+	set, ok := glyphSets[name]
+	if !ok {
+		set = unicodeGlyphs
+		set.Spinner = slices.Clone(set.Spinner)
+		return set, false
+	}
+
+	fallback := unicodeGlyphs
+	for _, field := range []struct{ glyph, fallback *string }{
+		{&set.Horizontal, &fallback.Horizontal}, {&set.Vertical, &fallback.Vertical},
+		{&set.TopLeft, &fallback.TopLeft}, {&set.TopRight, &fallback.TopRight},
+		{&set.BottomLeft, &fallback.BottomLeft}, {&set.BottomRight, &fallback.BottomRight},
+		{&set.ProgressFull, &fallback.ProgressFull}, {&set.ProgressEmpty, &fallback.ProgressEmpty},
+		{&set.TreeBranch, &fallback.TreeBranch}, {&set.TreeLast, &fallback.TreeLast},
+		{&set.TreeVertical, &fallback.TreeVertical}, {&set.TreeSpace, &fallback.TreeSpace},
+		{&set.Bullet, &fallback.Bullet},
+	} {
+		if *field.glyph == "" {
+			*field.glyph = *field.fallback
+		}
+	}
+	if len(set.Spinner) == 0 {
+		set.Spinner = fallback.Spinner
+	}
+	set.Spinner = slices.Clone(set.Spinner)
+	return set, true
+}
+
+/*
+glyphs returns the glyph set widgets are drawn with.
+
+Return:
+  - GlyphSet: The glyphs.
+*/
+func glyphs() GlyphSet {
+	set, _ := GetGlyphSet("")
+	return set
+}
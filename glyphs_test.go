@@ -0,0 +1,84 @@
+package colorize
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+/* TestGlyphSets tests the built-in glyph sets and the default set */
+func TestGlyphSets(t *testing.T) {
+	defer SetFontCapability(FontUnicode)
+	defer func() { _ = SetGlyphSet("") }()
+
+	for _, name := range []string{"unicode", "ascii", "heavy", "rounded"} {
+		set, ok := GetGlyphSet(name)
+		if !ok || set.Horizontal == "" || set.TreeLast == "" || len(set.Spinner) == 0 || set.Bullet == "" {
+			t.Errorf("%s: unexpected glyph set %+v", name, set)
+		}
+	}
+	if _, ok := GetGlyphSet("nope"); ok {
+		t.Error("Expected an unknown glyph set not to be found")
+	}
+	if err := SetGlyphSet("nope"); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+
+	// the default set follows the font capability
+	if set := glyphs(); set.Horizontal != "─" {
+		t.Errorf("Expected the unicode set but got %+v", set)
+	}
+	SetFontCapability(FontASCII)
+	if set := glyphs(); set.Horizontal != "-" {
+		t.Errorf("Expected the ascii set but got %+v", set)
+	}
+	_ = SetGlyphSet("heavy")
+	if set := glyphs(); set.Horizontal != "━" {
+		t.Errorf("Expected the heavy set but got %+v", set)
+	}
+}
+
+/* TestRegisterGlyphSet tests custom glyph sets and their fallbacks */
+func TestRegisterGlyphSet(t *testing.T) {
+	defer func() { _ = SetGlyphSet("") }()
+	defer func() {
+		glyphSetsMu.Lock()
+		delete(glyphSets, "arrows")
+		glyphSetsMu.Unlock()
+	}()
+
+	RegisterGlyphSet("arrows", GlyphSet{TreeBranch: "├▶ ", TreeLast: "└▶ "})
+	set, ok := GetGlyphSet("arrows")
+	if !ok || set.TreeBranch != "├▶ " || set.Horizontal != "─" || set.TreeVertical != "│  " {
+		t.Errorf("Unexpected glyph set %+v", set)
+	}
+
+	// the returned spinner frames are a copy
+	set.Spinner[0] = "x"
+	if again, _ := GetGlyphSet("arrows"); again.Spinner[0] == "x" {
+		t.Error("Expected the registry to be unchanged")
+	}
+}
+
+/* TestGlyphSetWidgets tests the widgets drawn with the glyph sets */
+func TestGlyphSetWidgets(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer func() { _ = SetGlyphSet("") }()
+	trueColor, xTerm, ansi16 = false, false, false
+
+	// per-widget set
+	if got := Rule("", &RuleOptions{Width: 3, Glyphs: "heavy"}); got != "━━━" {
+		t.Errorf("Expected %q but got %q", "━━━", got)
+	}
+
+	// global set
+	_ = SetGlyphSet("ascii")
+	if got := Rule("", &RuleOptions{Width: 3}); got != "---" {
+		t.Errorf("Expected %q but got %q", "---", got)
+	}
+	err := fmt.Errorf("load: %w", errors.Join(errors.New("a"), errors.New("b")))
+	if got, expected := FormatErrorChain(err), "load\n`- 2 errors\n   |- a\n   `- b\n"; got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
@@ -0,0 +1,24 @@
+package colorize
+
+/* graceful makes the lack of color support a silent downgrade rather than an error */
+var graceful = false
+
+/*
+SetGracefulDegradation enables or disables the graceful degradation mode.
+
+By default, FormatText (and the functions built on it) returns a SYSNOCOLOR error when the system
+supports neither true color, Xterm nor the 16 ANSI colors. With graceful degradation, the plain
+text is returned with a nil error instead, and GetColor returns an empty code, as when colors are
+disabled by NO_COLOR. Options.Graceful enables the same behavior for a single call.
+
+Parameters:
+  - enabled: true to return plain text without an error, false to report SYSNOCOLOR.
+
+Example:
+
+	c.SetGracefulDegradation(true)
+	text, err := c.FormatText("done", &c.Options{FgColor: "#00FF00"}) // err is nil on a dumb terminal
+*/
+func SetGracefulDegradation(enabled bool) {
+	graceful = enabled
+}
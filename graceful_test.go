@@ -0,0 +1,38 @@
+package colorize
+
+import "testing"
+
+/* TestGracefulDegradation tests the graceful degradation mode, globally and per call */
+func TestGracefulDegradation(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer SetGracefulDegradation(false)
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+	noColor = false
+
+	// disabled by default
+	if _, err := FormatText("hello", &Options{FgColor: "#FF0000"}); err == nil {
+		t.Error("Expected a SYSNOCOLOR error")
+	}
+
+	// per call
+	if text, err := FormatText("hello", &Options{FgColor: "#FF0000", Graceful: true}); err != nil || text != "hello" {
+		t.Errorf("Expected the plain text but got %q, %v", text, err)
+	}
+
+	// global
+	SetGracefulDegradation(true)
+	if text, err := FormatText("hello", &Options{FgColor: "#FF0000"}); err != nil || text != "hello" {
+		t.Errorf("Expected the plain text but got %q, %v", text, err)
+	}
+	if code, err := GetColor("#FF0000", foreground); err != nil || code != "" {
+		t.Errorf("Expected an empty code but got %q, %v", code, err)
+	}
+
+	// invalid colors are still reported
+	if _, err := GetColor("#FF00000", foreground); err == nil {
+		t.Error("Expected an error for an invalid color")
+	}
+}
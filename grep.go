@@ -0,0 +1,143 @@
+package colorize
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// formatting options assigned in turn to the patterns of Grep
+var grepPalette = []*Options{
+	{FgColor: "#FF5F5F", Styles: []string{"bold"}},
+	{FgColor: "#5FD700", Styles: []string{"bold"}},
+	{FgColor: "#FFD700", Styles: []string{"bold"}},
+	{FgColor: "#5FAFFF", Styles: []string{"bold"}},
+	{FgColor: "#D787FF", Styles: []string{"bold"}},
+	{FgColor: "#5FD7D7", Styles: []string{"bold"}},
+}
+
+/* The GrepOptions type represents the options of Grep */
+type GrepOptions struct {
+	Before      int        // context lines printed before each match
+	After       int        // context lines printed after each match
+	LineNumbers bool       // prefixes the lines with their number
+	Colors      []*Options // formatting options of the matches, by pattern (assigned in turn if missing)
+	Theme       Theme      // theme styling the line numbers and separators ("grep.*" roles, DefaultTheme if nil)
+}
+
+/* grepLine is a line read by Grep */
+type grepLine struct {
+	number int
+	text   string
+}
+
+/*
+Grep prints the lines of a reader matching any of the given patterns, highlighting the matches
+within the lines, with a distinct color per pattern. Like grep, context lines can be printed around
+the matches (non-contiguous groups are separated by "--"), and line numbers are followed by ":" on
+matching lines and "-" on context lines. Colors follow the color level of the writer (see
+DetectLevel).
+
+Parameters:
+  - r: The reader.
+  - patterns: The patterns to look for.
+  - opts: The options, or nil for the defaults.
+  - w: The writer.
+
+Return:
+  - int: The number of matching lines.
+  - error: The read or write error, if any.
+
+Example:
+
+	n, err := c.Grep(f, []*regexp.Regexp{regexp.MustCompile(`ERROR`), regexp.MustCompile(`timeout`)},
+		&c.GrepOptions{After: 2, LineNumbers: true}, os.Stdout)
+*/
+func Grep(r io.Reader, patterns []*regexp.Regexp, opts *GrepOptions, w io.Writer) (int, error) {
+	if opts == nil {
+		opts = &GrepOptions{}
+	}
+	theme := opts.Theme.orDefault()
+	colorizer := NewColorizer(w)
+
+	rules := make([]HighlightRule, len(patterns))
+	for i, pattern := range patterns {
+		rules[i] = HighlightRule{Pattern: pattern, Options: grepPalette[i%len(grepPalette)]}
+		if i < len(opts.Colors) && opts.Colors[i] != nil {
+			rules[i].Options = opts.Colors[i]
+		}
+	}
+
+	// themed pieces are formatted for the writer
+	themed := func(role string, text string) string {
+		return colorizer.format(text, theme[role])
+	}
+	write := func(line grepLine, separator string, text string) error {
+		builder := strings.Builder{}
+		if opts.LineNumbers {
+			builder.WriteString(themed("grep.line", strconv.Itoa(line.number)))
+			builder.WriteString(themed("grep.separator", separator))
+		}
+		builder.WriteString(text)
+		builder.WriteString("\n")
+		_, err := colorizer.Writer().Write([]byte(builder.String()))
+		return err
+	}
+
+	matches := 0
+	printed := 0 // number of the last printed line
+	after := 0   // context lines left to print after a match
+	var before []grepLine
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		line := grepLine{number, strings.TrimSuffix(scanner.Text(), "\r")}
+
+		matched := false
+		for _, pattern := range patterns {
+			if pattern.MatchString(line.text) {
+				matched = true
+				break
+			}
+		}
+
+		switch {
+		case matched:
+			matches++
+			first := line.number
+			if len(before) > 0 {
+				first = before[0].number
+			}
+			if printed > 0 && first > printed+1 && (opts.Before > 0 || opts.After > 0) {
+				if _, err := colorizer.Writer().Write([]byte(themed("grep.separator", "--") + "\n")); err != nil {
+					return matches, err
+				}
+			}
+			for _, previous := range before {
+				if err := write(previous, "-", previous.text); err != nil {
+					return matches, err
+				}
+			}
+			before = before[:0]
+			if err := write(line, ":", highlightLine(line.text, rules, colorizer.format)); err != nil {
+				return matches, err
+			}
+			printed, after = line.number, opts.After
+		case after > 0:
+			after--
+			if err := write(line, "-", line.text); err != nil {
+				return matches, err
+			}
+			printed = line.number
+		case opts.Before > 0:
+			before = append(before, line)
+			if len(before) > opts.Before {
+				before = before[1:]
+			}
+		}
+	}
+	return matches, scanner.Err()
+}
@@ -0,0 +1,74 @@
+package colorize
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// input of the Grep tests
+var grepInput = strings.Join([]string{
+	"start",
+	"ERROR disk full",
+	"retrying",
+	"waiting",
+	"idle",
+	"idle",
+	"timeout after ERROR",
+	"done",
+}, "\n")
+
+/* TestGrep tests the Grep function */
+func TestGrep(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	patterns := []*regexp.Regexp{regexp.MustCompile(`ERROR`), regexp.MustCompile(`timeout`)}
+	tests := []struct {
+		opts     *GrepOptions
+		expected string
+	}{
+		{nil, "ERROR disk full\ntimeout after ERROR\n"},
+		{&GrepOptions{LineNumbers: true}, "2:ERROR disk full\n7:timeout after ERROR\n"},
+		{&GrepOptions{Before: 1, After: 1, LineNumbers: true}, "1-start\n2:ERROR disk full\n3-retrying\n--\n6-idle\n7:timeout after ERROR\n8-done\n"},
+		{&GrepOptions{Before: 3, After: 2}, "start\nERROR disk full\nretrying\nwaiting\nidle\nidle\ntimeout after ERROR\ndone\n"},
+	}
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		n, err := Grep(strings.NewReader(grepInput), patterns, test.opts, buf)
+		if err != nil || n != 2 || buf.String() != test.expected {
+			t.Errorf("%+v: expected %q but got %q (%d, %v)", test.opts, test.expected, buf.String(), n, err)
+		}
+	}
+}
+
+/* TestGrepColors tests the highlighting of the matches by Grep */
+func TestGrepColors(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	SetColorLevel(LevelTrueColor)
+
+	patterns := []*regexp.Regexp{regexp.MustCompile(`ERROR`), regexp.MustCompile(`timeout`)}
+	buf := &bytes.Buffer{}
+	if _, err := Grep(strings.NewReader("timeout after ERROR"), patterns, nil, buf); err != nil {
+		t.Fatal("Expected no error but got", err)
+	}
+	first, _ := FormatText("ERROR", grepPalette[0])
+	second, _ := FormatText("timeout", grepPalette[1])
+	if expected := second + " after " + first + "\n"; buf.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, buf.String())
+	}
+
+	// custom colors
+	buf.Reset()
+	custom := &Options{Styles: []string{"underline"}}
+	_, _ = Grep(strings.NewReader("ERROR"), patterns, &GrepOptions{Colors: []*Options{custom}}, buf)
+	if expected, _ := FormatText("ERROR", custom); buf.String() != expected+"\n" {
+		t.Errorf("Expected %q but got %q", expected+"\n", buf.String())
+	}
+}
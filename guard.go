@@ -0,0 +1,281 @@
+package colorize
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+const (
+	// terminal state escape codes
+	altScreenOn  = "\033[?1049h"
+	altScreenOff = "\033[?1049l"
+	cursorHide   = "\033[?25l"
+	cursorShow   = "\033[?25h"
+	mouseOn      = "\033[?1000h\033[?1006h"
+	mouseOff     = "\033[?1006l\033[?1000l"
+)
+
+/*
+The TerminalGuard type tracks the terminal state changes made through it (alternate screen, hidden
+cursor, raw mode and mouse reporting) so that they can be undone at once with Restore.
+
+A TerminalGuard is safe for concurrent use.
+*/
+type TerminalGuard struct {
+	mu           sync.Mutex
+	w            io.Writer
+	altScreen    bool
+	hiddenCursor bool
+	mouse        bool
+	rawRestore   func() error
+}
+
+/*
+Guard returns a TerminalGuard writing to the standard output.
+
+The typical usage is to defer Restore right after creating the guard, so that the terminal is
+restored both on normal return and on panic:
+
+	g := c.Guard()
+	defer g.Restore()
+
+	g.EnterAltScreen()
+	g.HideCursor()
+
+Return:
+  - *TerminalGuard: The newly created guard.
+*/
+func Guard() *TerminalGuard {
+	return NewGuard(os.Stdout)
+}
+
+/*
+NewGuard returns a TerminalGuard writing to the provided writer.
+
+Parameters:
+  - w: The writer connected to the terminal.
+
+Return:
+  - *TerminalGuard: The newly created guard.
+*/
+func NewGuard(w io.Writer) *TerminalGuard {
+	return &TerminalGuard{w: w}
+}
+
+/*
+write writes the given escape code to the guarded terminal. The caller must hold the lock.
+
+Parameters:
+  - code: The escape code to write.
+
+Return:
+  - error: The error returned by the writer, if any.
+*/
+func (g *TerminalGuard) write(code string) error {
+	_, err := io.WriteString(g.w, code)
+	return err
+}
+
+/* EnterAltScreen switches the terminal to the alternate screen buffer */
+func (g *TerminalGuard) EnterAltScreen() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.altScreen = true
+	return g.write(altScreenOn)
+}
+
+/* ExitAltScreen switches the terminal back to the main screen buffer */
+func (g *TerminalGuard) ExitAltScreen() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.altScreen = false
+	return g.write(altScreenOff)
+}
+
+/* HideCursor hides the terminal cursor */
+func (g *TerminalGuard) HideCursor() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hiddenCursor = true
+	return g.write(cursorHide)
+}
+
+/* ShowCursor shows the terminal cursor */
+func (g *TerminalGuard) ShowCursor() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hiddenCursor = false
+	return g.write(cursorShow)
+}
+
+/* EnableMouse enables mouse reporting (SGR extended mode) */
+func (g *TerminalGuard) EnableMouse() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mouse = true
+	return g.write(mouseOn)
+}
+
+/* DisableMouse disables mouse reporting */
+func (g *TerminalGuard) DisableMouse() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mouse = false
+	return g.write(mouseOff)
+}
+
+/*
+TrackRawMode registers the function restoring the terminal from raw mode.
+
+The package does not switch the terminal to raw mode by itself (that requires platform specific
+system calls), so the caller enters raw mode with its terminal library of choice and hands the
+restore function over to the guard.
+
+Parameters:
+  - restore: The function restoring the terminal to its previous mode, or nil to stop tracking.
+
+Example:
+
+	state, _ := term.MakeRaw(fd)
+	g.TrackRawMode(func() error { return term.Restore(fd, state) })
+*/
+func (g *TerminalGuard) TrackRawMode(restore func() error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rawRestore = restore
+}
+
+/*
+Restore undoes every tracked state change: it leaves raw mode, disables mouse reporting, shows the
+cursor and leaves the alternate screen. Calling Restore more than once is safe, since only the
+changes still in effect are undone.
+
+Restore does not recover panics, so when deferred, the panic message is printed on a restored
+terminal.
+
+Return:
+  - error: The first error found while restoring, if any.
+*/
+func (g *TerminalGuard) Restore() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var firstErr error
+	if g.rawRestore != nil {
+		firstErr = g.rawRestore()
+		g.rawRestore = nil
+	}
+
+	builder := strings.Builder{}
+	if g.mouse {
+		builder.WriteString(mouseOff)
+		g.mouse = false
+	}
+	if g.hiddenCursor {
+		builder.WriteString(cursorShow)
+		g.hiddenCursor = false
+	}
+	if g.altScreen {
+		builder.WriteString(altScreenOff)
+		g.altScreen = false
+	}
+
+	if builder.Len() > 0 {
+		if err := g.write(builder.String()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+/*
+raiseSignal restores the default behavior of the given signal and sends it again to the current
+process, so that it terminates as if it had never been caught. It is a variable so that tests can
+replace it.
+
+Parameters:
+  - sig: The signal to raise.
+*/
+var raiseSignal = func(sig os.Signal) {
+	signal.Reset(sig)
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(sig)
+	}
+	if err != nil {
+		// the signal can't be raised on this platform
+		os.Exit(1)
+	}
+}
+
+/*
+HandleSignals restores the terminal when the process receives one of the given signals (SIGINT and
+SIGTERM if none is provided).
+
+On signal, the guard restores every tracked state, emits the Reset escape code (so that a colored
+output interrupted halfway doesn't leak its colors into the shell) and raises the signal again with
+its default behavior, so the process still terminates with the expected status.
+
+Note that the default behavior of the signal is restored before raising it, which also drops any
+other handler registered with signal.Notify for it.
+
+Parameters:
+  - sigs: The signals to handle.
+
+Return:
+  - func(): A function that stops handling the signals.
+
+Example:
+
+	g := c.Guard()
+	defer g.Restore()
+	stop := g.HandleSignals()
+	defer stop()
+*/
+func (g *TerminalGuard) HandleSignals(sigs ...os.Signal) func() {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+
+	go func() {
+		select {
+		case sig := <-ch:
+			signal.Stop(ch)
+			g.onSignal(sig)
+		case <-done:
+		}
+	}()
+
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+/*
+onSignal restores the terminal, emits the Reset escape code and raises the signal again.
+
+Parameters:
+  - sig: The signal received.
+*/
+func (g *TerminalGuard) onSignal(sig os.Signal) {
+	_ = g.Restore()
+
+	g.mu.Lock()
+	_ = g.write(reset)
+	g.mu.Unlock()
+
+	raiseSignal(sig)
+}
@@ -0,0 +1,146 @@
+package colorize
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+/* TestTerminalGuard tests the TerminalGuard type */
+func TestTerminalGuard(t *testing.T) {
+	buf := &bytes.Buffer{}
+	g := NewGuard(buf)
+
+	// nothing to restore
+	if err := g.Restore(); err != nil || buf.Len() != 0 {
+		t.Error("Expected nothing to be restored")
+	}
+
+	// track every state
+	rawRestored := false
+	_ = g.EnterAltScreen()
+	_ = g.HideCursor()
+	_ = g.EnableMouse()
+	g.TrackRawMode(func() error {
+		rawRestored = true
+		return nil
+	})
+	buf.Reset()
+
+	if err := g.Restore(); err != nil {
+		t.Error("Expected no error but got", err)
+	}
+	if !rawRestored {
+		t.Error("Expected raw mode to be restored")
+	}
+	if buf.String() != mouseOff+cursorShow+altScreenOff {
+		t.Errorf("Expected every state to be restored but got %q", buf.String())
+	}
+
+	// restore twice
+	buf.Reset()
+	_ = g.Restore()
+	if buf.Len() != 0 {
+		t.Error("Expected nothing to be restored twice")
+	}
+
+	// states undone manually are not restored
+	_ = g.HideCursor()
+	_ = g.ShowCursor()
+	_ = g.EnableMouse()
+	_ = g.DisableMouse()
+	_ = g.EnterAltScreen()
+	_ = g.ExitAltScreen()
+	buf.Reset()
+	_ = g.Restore()
+	if buf.Len() != 0 {
+		t.Error("Expected states undone manually not to be restored")
+	}
+
+	// raw mode errors are reported
+	g.TrackRawMode(func() error { return errors.New("raw") })
+	if err := g.Restore(); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+}
+
+/* TestGuardPanic tests that a deferred Restore runs on panic */
+func TestGuardPanic(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	func() {
+		defer func() { _ = recover() }()
+		g := NewGuard(buf)
+		defer g.Restore()
+		_ = g.HideCursor()
+		panic("test")
+	}()
+
+	if buf.String() != cursorHide+cursorShow {
+		t.Errorf("Expected the cursor to be restored on panic but got %q", buf.String())
+	}
+}
+
+/* TestHandleSignals tests the HandleSignals method */
+func TestHandleSignals(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals can't be sent to the current process on windows")
+	}
+
+	prevRaise := raiseSignal
+	defer func() { raiseSignal = prevRaise }()
+
+	raised := make(chan os.Signal, 1)
+	raiseSignal = func(sig os.Signal) {
+		raised <- sig
+	}
+
+	buf := &syncBuffer{}
+	g := NewGuard(buf)
+	_ = g.HideCursor()
+	stop := g.HandleSignals(os.Interrupt)
+	defer stop()
+
+	p, _ := os.FindProcess(os.Getpid())
+	if err := p.Signal(os.Interrupt); err != nil {
+		t.Fatal("Expected no error but got", err)
+	}
+
+	select {
+	case sig := <-raised:
+		if sig != os.Interrupt {
+			t.Errorf("Expected the interrupt signal to be raised but got %v", sig)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the signal to be handled")
+	}
+
+	if buf.String() != cursorHide+cursorShow+reset {
+		t.Errorf("Expected the terminal to be restored and reset but got %q", buf.String())
+	}
+
+	// stopping twice is safe
+	stop()
+}
+
+/* syncBuffer is a bytes.Buffer safe for concurrent use */
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
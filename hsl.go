@@ -0,0 +1,102 @@
+package colorize
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// regex for CSS hsl() colors, with comma or space separated components (e.g., "hsl(210, 50%, 40%)")
+var hslRegex = regexp.MustCompile(`(?i)^hsl\(\s*(-?\d+(?:\.\d+)?)(?:deg)?(?:\s*,\s*|\s+)(\d+(?:\.\d+)?)%(?:\s*,\s*|\s+)(\d+(?:\.\d+)?)%\s*\)$`)
+
+/*
+hslToRGB converts an HSL color to RGB representation.
+
+Parameters:
+  - h: The hue, in degrees (wrapped to [0, 360)).
+  - s: The saturation, in percent (clamped to [0, 100]).
+  - l: The lightness, in percent (clamped to [0, 100]).
+
+Return:
+  - *color: A pointer to the color struct representing the RGB color.
+*/
+func hslToRGB(h float64, s float64, l float64) *color {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = math.Max(0, math.Min(s, 100)) / 100
+	l = math.Max(0, math.Min(l, 100)) / 100
+
+	chroma := (1 - math.Abs(2*l-1)) * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - chroma/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g = chroma, x
+	case h < 120:
+		r, g = x, chroma
+	case h < 180:
+		g, b = chroma, x
+	case h < 240:
+		g, b = x, chroma
+	case h < 300:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+
+	channel := func(v float64) uint8 {
+		return uint8(math.Round((v + m) * 255))
+	}
+	return &color{channel(r), channel(g), channel(b)}
+}
+
+/*
+parseHSL parses a CSS hsl() color (e.g., "hsl(210, 50%, 40%)" or "hsl(210deg 50% 40%)").
+
+Parameters:
+  - s: The color string.
+
+Return:
+  - *color: A pointer to the color struct representing the RGB color, or nil.
+  - bool: false if the string isn't a valid hsl() color (including percentages above 100%).
+*/
+func parseHSL(s string) (*color, bool) {
+	match := hslRegex.FindStringSubmatch(s)
+	if match == nil {
+		return nil, false
+	}
+	// errors are omitted due to regex
+	h, _ := strconv.ParseFloat(match[1], 64)
+	sat, _ := strconv.ParseFloat(match[2], 64)
+	l, _ := strconv.ParseFloat(match[3], 64)
+	if sat > 100 || l > 100 {
+		return nil, false
+	}
+	return hslToRGB(h, sat, l), true
+}
+
+/*
+HSL returns the hexadecimal color code of the given HSL color, to be used in Options.
+
+Parameters:
+  - h: The hue, in degrees (wrapped to [0, 360)).
+  - s: The saturation, in percent (clamped to [0, 100]).
+  - l: The lightness, in percent (clamped to [0, 100]).
+
+Return:
+  - string: The hexadecimal color code (e.g., "#336699").
+
+Example:
+
+	text, err := c.FormatText("info", &c.Options{FgColor: c.HSL(210, 50, 40)})
+	// equivalent to
+	text, err = c.FormatText("info", &c.Options{FgColor: "hsl(210, 50%, 40%)"})
+*/
+func HSL(h float64, s float64, l float64) string {
+	col := hslToRGB(h, s, l)
+	return RGB(col.r, col.g, col.b)
+}
@@ -0,0 +1,47 @@
+package colorize
+
+import "testing"
+
+/* TestHSL tests the HSL function */
+func TestHSL(t *testing.T) {
+	tests := []struct {
+		h, s, l  float64
+		expected string
+	}{
+		{0, 100, 50, "#FF0000"},
+		{120, 100, 50, "#00FF00"},
+		{240, 100, 50, "#0000FF"},
+		{210, 50, 40, "#336699"},
+		{0, 0, 100, "#FFFFFF"},
+		{0, 0, 0, "#000000"},
+		{-120, 100, 50, "#0000FF"},
+		{480, 150, 50, "#00FF00"},
+	}
+	for _, test := range tests {
+		if hex := HSL(test.h, test.s, test.l); hex != test.expected {
+			t.Errorf("HSL(%v, %v, %v): expected %s but got %s", test.h, test.s, test.l, test.expected, hex)
+		}
+	}
+}
+
+/* TestParseHSL tests the parsing of hsl() colors */
+func TestParseHSL(t *testing.T) {
+	valid := map[string]color{
+		"hsl(210, 50%, 40%)":    {0x33, 0x66, 0x99},
+		"HSL(210deg 50% 40%)":   {0x33, 0x66, 0x99},
+		"hsl( 0 , 100% , 50% )": {255, 0, 0},
+		"hsl(120.0, 100%, 25%)": {0, 128, 0},
+	}
+	for s, expected := range valid {
+		col, err := getColor(s)
+		if err != nil || *col != expected {
+			t.Errorf("%s: expected %v but got %v, %v", s, expected, col, err)
+		}
+	}
+
+	for _, s := range []string{"hsl(210, 50, 40)", "hsl(210, 150%, 40%)", "hsl(210, 50%)", "hsla(210, 50%, 40%, 1)"} {
+		if err := validateHex(s); err == nil {
+			t.Errorf("%s: expected an error but got nil", s)
+		}
+	}
+}
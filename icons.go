@@ -0,0 +1,109 @@
+package colorize
+
+import (
+	"sync"
+)
+
+/* The FontCapability type represents the glyphs the user's font is able to render */
+type FontCapability int
+
+const (
+	/* Font capabilities, from the most to the least capable */
+	FontNerd    FontCapability = iota // Nerd Font patched fonts (private use area glyphs)
+	FontUnicode                       // standard Unicode symbols
+	FontASCII                         // plain ASCII only
+)
+
+/* The Icon type holds the glyphs of an icon for each font capability */
+type Icon struct {
+	Nerd    string // Nerd Font glyph
+	Unicode string // Unicode fallback
+	ASCII   string // ASCII fallback
+}
+
+var (
+	// icon registry, keyed by semantic name
+	iconsMu = sync.RWMutex{}
+	icons   = map[string]Icon{
+		"folder":  {Nerd: "\uf07b", Unicode: "📁", ASCII: "[D]"},
+		"file":    {Nerd: "\uf15b", Unicode: "📄", ASCII: "[F]"},
+		"branch":  {Nerd: "\ue725", Unicode: "⎇", ASCII: "git:"},
+		"error":   {Nerd: "\uf057", Unicode: "✖", ASCII: "x"},
+		"warning": {Nerd: "\uf071", Unicode: "⚠", ASCII: "!"},
+		"info":    {Nerd: "\uf05a", Unicode: "ℹ", ASCII: "i"},
+		"success": {Nerd: "\uf00c", Unicode: "✔", ASCII: "v"},
+		"lock":    {Nerd: "\uf023", Unicode: "🔒", ASCII: "#"},
+		"clock":   {Nerd: "\uf017", Unicode: "⏱", ASCII: "@"},
+	}
+
+	// font capability used to pick the glyphs
+	fontCapability = FontUnicode
+)
+
+/*
+SetFontCapability sets the glyphs the user's font is able to render. It defaults to FontUnicode,
+since Nerd Fonts can't be detected and must be opted in (e.g., through a CLI flag or config file).
+
+Parameters:
+  - capability: The font capability (FontNerd, FontUnicode or FontASCII).
+*/
+func SetFontCapability(capability FontCapability) {
+	iconsMu.Lock()
+	defer iconsMu.Unlock()
+	fontCapability = capability
+}
+
+/*
+RegisterIcon adds an icon to the registry, or replaces an existing one.
+
+Empty glyphs fall back to the next, less capable, glyph of the icon (Nerd Font to Unicode to
+ASCII), so registering an ASCII glyph is recommended.
+
+Parameters:
+  - name: The semantic name of the icon (e.g., "folder").
+  - icon: The glyphs of the icon.
+
+Example:
+
+	c.RegisterIcon("docker", c.Icon{Nerd: "\uf308", Unicode: "🐳", ASCII: "[docker]"})
+*/
+func RegisterIcon(name string, icon Icon) {
+	iconsMu.Lock()
+	defer iconsMu.Unlock()
+	icons[name] = icon
+}
+
+/*
+GetIcon returns the glyph of the named icon for the current font capability, falling back to the
+less capable glyphs when the icon does not define one.
+
+Built-in icons: folder, file, branch, error, warning, info, success, lock and clock.
+
+Parameters:
+  - name: The semantic name of the icon.
+
+Return:
+  - string: The glyph, or an empty string if the icon is not registered.
+
+Example:
+
+	fmt.Println(c.GetIcon("branch"), c.GitBranch("main", nil))
+*/
+func GetIcon(name string) string {
+	iconsMu.RLock()
+	defer iconsMu.RUnlock()
+
+	icon, ok := icons[name]
+	if !ok {
+		return ""
+	}
+
+	glyphs := []string{icon.Nerd, icon.Unicode, icon.ASCII}
+	start := min(max(int(fontCapability), int(FontNerd)), int(FontASCII))
+	for _, glyph := range glyphs[start:] {
+		if glyph != "" {
+			return glyph
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestGetIcon tests the GetIcon function */
+func TestGetIcon(t *testing.T) {
+	defer SetFontCapability(FontUnicode)
+
+	// default capability
+	if GetIcon("folder") != "📁" {
+		t.Errorf("Expected the Unicode glyph but got '%s'", GetIcon("folder"))
+	}
+
+	// nerd fonts
+	SetFontCapability(FontNerd)
+	if GetIcon("folder") != "\uf07b" {
+		t.Errorf("Expected the Nerd Font glyph but got '%s'", GetIcon("folder"))
+	}
+
+	// ascii
+	SetFontCapability(FontASCII)
+	if GetIcon("folder") != "[D]" {
+		t.Errorf("Expected the ASCII glyph but got '%s'", GetIcon("folder"))
+	}
+
+	// unknown icon
+	if GetIcon("unknown") != "" {
+		t.Error("Expected an empty string for unknown icons")
+	}
+
+	// out of range capability
+	SetFontCapability(FontCapability(10))
+	if GetIcon("folder") != "[D]" {
+		t.Errorf("Expected the ASCII glyph but got '%s'", GetIcon("folder"))
+	}
+}
+
+/* TestRegisterIcon tests the RegisterIcon function */
+func TestRegisterIcon(t *testing.T) {
+	defer SetFontCapability(FontUnicode)
+
+	RegisterIcon("test", Icon{Nerd: "\ue725", ASCII: "[test]"})
+
+	// fallback from a missing unicode glyph
+	SetFontCapability(FontUnicode)
+	if GetIcon("test") != "[test]" {
+		t.Errorf("Expected the ASCII fallback but got '%s'", GetIcon("test"))
+	}
+	SetFontCapability(FontNerd)
+	if GetIcon("test") != "\ue725" {
+		t.Errorf("Expected the Nerd Font glyph but got '%s'", GetIcon("test"))
+	}
+}
@@ -0,0 +1,101 @@
+package colorize
+
+import (
+	imagecolor "image/color"
+)
+
+/*
+RGBA returns the alpha-premultiplied red, green, blue and alpha values of the color, as the
+color.Color interface of the image/color package requires. The color is always opaque.
+
+Return:
+  - uint32: The red value, in [0, 0xFFFF].
+  - uint32: The green value, in [0, 0xFFFF].
+  - uint32: The blue value, in [0, 0xFFFF].
+  - uint32: The alpha value, always 0xFFFF.
+*/
+func (c Color) RGBA() (uint32, uint32, uint32, uint32) {
+	return uint32(c.R) * 0x101, uint32(c.G) * 0x101, uint32(c.B) * 0x101, 0xFFFF
+}
+
+/*
+imageToColor converts a color of the image/color package to RGB representation. Translucent colors
+are composited over the base color (see SetAlphaBase), as "#RRGGBBAA" colors are.
+
+Parameters:
+  - value: The color.
+
+Return:
+  - *color: A pointer to the color struct representing the RGB color.
+*/
+func imageToColor(value imagecolor.Color) *color {
+	r, g, b, a := value.RGBA()
+	// the values are premultiplied: the base color shows through the transparent part
+	composite := func(c uint32, base uint8) uint8 {
+		return uint8((c + uint32(base)*0x101*(0xFFFF-a)/0xFFFF) >> 8)
+	}
+	return &color{composite(r, alphaBase.r), composite(g, alphaBase.g), composite(b, alphaBase.b)}
+}
+
+/*
+ColorOf converts any color of the image/color package (e.g., from a palette) to a Color.
+Translucent colors are composited over the base color (see SetAlphaBase).
+
+Parameters:
+  - value: The color.
+
+Return:
+  - Color: The opaque RGB color.
+
+Example:
+
+	col := c.ColorOf(palette.Plan9[42])
+*/
+func ColorOf(value imagecolor.Color) Color {
+	col := imageToColor(value)
+	return Color{col.r, col.g, col.b}
+}
+
+/*
+GetColorOf returns the ANSI escape code of a color of the image/color package, as GetColor does for
+hex codes.
+
+Parameters:
+  - value: The color.
+  - ctx: The color context (background or foreground).
+
+Return:
+  - string: The ANSI escape code.
+  - error: An error if the system does not support true color, Xterm or the 16 ANSI colors.
+
+Example:
+
+	red, err := c.GetColorOf(color.RGBA{R: 255, A: 255}, "foreground")
+*/
+func GetColorOf(value imagecolor.Color, ctx ColorContext) (string, error) {
+	col := imageToColor(value)
+	return GetColor(RGB(col.r, col.g, col.b), ctx)
+}
+
+/*
+withImageColors returns a copy of the options whose Fg and Bg colors are converted to hex codes
+(overriding FgColor and BgColor), or the options themselves if they set neither.
+
+Return:
+  - *Options: The options to render.
+*/
+func (o *Options) withImageColors() *Options {
+	if o.Fg == nil && o.Bg == nil {
+		return o
+	}
+	copied := *o
+	if o.Fg != nil {
+		col := imageToColor(o.Fg)
+		copied.FgColor = RGB(col.r, col.g, col.b)
+	}
+	if o.Bg != nil {
+		col := imageToColor(o.Bg)
+		copied.BgColor = RGB(col.r, col.g, col.b)
+	}
+	return &copied
+}
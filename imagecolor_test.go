@@ -0,0 +1,70 @@
+package colorize
+
+import (
+	imagecolor "image/color"
+	"image/color/palette"
+	"testing"
+)
+
+/* TestColorRGBA tests that Color implements the color.Color interface */
+func TestColorRGBA(t *testing.T) {
+	var value imagecolor.Color = Color{255, 128, 0}
+	r, g, b, a := value.RGBA()
+	if r != 0xFFFF || g != 0x8080 || b != 0 || a != 0xFFFF {
+		t.Errorf("Expected 16-bit values but got %x %x %x %x", r, g, b, a)
+	}
+	if converted := imagecolor.RGBAModel.Convert(value); converted != (imagecolor.RGBA{255, 128, 0, 255}) {
+		t.Errorf("Expected the same color but got %v", converted)
+	}
+}
+
+/* TestColorOf tests the ColorOf function */
+func TestColorOf(t *testing.T) {
+	defer SetAlphaBase("")
+	t.Setenv("COLORFGBG", "")
+	_ = SetAlphaBase("")
+
+	tests := []struct {
+		value    imagecolor.Color
+		expected Color
+	}{
+		{imagecolor.RGBA{255, 0, 0, 255}, Color{255, 0, 0}},
+		{imagecolor.Gray{128}, Color{128, 128, 128}},
+		{imagecolor.NRGBA{255, 255, 255, 128}, Color{128, 128, 128}},
+		{palette.WebSafe[1], Color{0, 0, 0x33}},
+		{Color{1, 2, 3}, Color{1, 2, 3}},
+	}
+	for _, test := range tests {
+		if col := ColorOf(test.value); col != test.expected {
+			t.Errorf("%v: expected %v but got %v", test.value, test.expected, col)
+		}
+	}
+
+	// translucent colors are composited over the base color
+	_ = SetAlphaBase("#FFFFFF")
+	if col := ColorOf(imagecolor.NRGBA{0, 0, 0, 0}); col != (Color{255, 255, 255}) {
+		t.Errorf("Expected the base color but got %v", col)
+	}
+}
+
+/* TestImageColorOptions tests the Fg and Bg options and GetColorOf */
+func TestImageColorOptions(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	text, err := FormatText("text", &Options{FgColor: "#FFFFFF", Fg: imagecolor.RGBA{255, 0, 0, 255}, Bg: Color{0, 0, 255}})
+	expected, _ := FormatText("text", &Options{FgColor: "#FF0000", BgColor: "#0000FF"})
+	if err != nil || text != expected {
+		t.Errorf("Expected %q but got %q, %v", expected, text, err)
+	}
+
+	code, err := GetColorOf(Color{255, 0, 0}, foreground)
+	if expected, _ := GetColor("#FF0000", foreground); err != nil || code != expected {
+		t.Errorf("Expected %q but got %q, %v", expected, code, err)
+	}
+
+	if exp := Explain("text", &Options{Fg: Color{255, 0, 0}}); len(exp.Colors) != 1 || exp.Colors[0].Resolved != "#FF0000" {
+		t.Errorf("Expected the color to be explained but got %+v", exp.Colors)
+	}
+}
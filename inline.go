@@ -0,0 +1,58 @@
+package colorize
+
+import "strconv"
+
+/*
+Code styles an inline piece of code (e.g., a command or an identifier) within a message, with the
+"inline.code" role of DefaultTheme.
+
+Parameters:
+  - s: The code.
+
+Return:
+  - string: The styled code.
+
+Example:
+
+	fmt.Printf("Run %s to fix\n", c.Code("go mod tidy"))
+*/
+func Code(s string) string {
+	return DefaultTheme.Format("inline.code", s)
+}
+
+/*
+Quote quotes a value within a message (as strconv.Quote does, escaping special characters) and
+styles it with the "inline.quote" role of DefaultTheme.
+
+Parameters:
+  - s: The value.
+
+Return:
+  - string: The styled, quoted value.
+
+Example:
+
+	fmt.Printf("unknown option %s\n", c.Quote(name))
+	// unknown option "colour"
+*/
+func Quote(s string) string {
+	return DefaultTheme.Format("inline.quote", strconv.Quote(s))
+}
+
+/*
+Path styles a file path within a message, with the "inline.path" role of DefaultTheme. Unlike
+ColorizePath, it doesn't depend on the file type.
+
+Parameters:
+  - s: The path.
+
+Return:
+  - string: The styled path.
+
+Example:
+
+	fmt.Printf("wrote %s\n", c.Path("out/report.html"))
+*/
+func Path(s string) string {
+	return DefaultTheme.Format("inline.path", s)
+}
@@ -0,0 +1,28 @@
+package colorize
+
+import "testing"
+
+/* TestInline tests the Code, Quote and Path functions */
+func TestInline(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	tests := []struct {
+		got      string
+		role     string
+		expected string
+	}{
+		{Code("go mod tidy"), "inline.code", "go mod tidy"},
+		{Quote("colour\n"), "inline.quote", `"colour\n"`},
+		{Path("out/report.html"), "inline.path", "out/report.html"},
+	}
+	for _, test := range tests {
+		if formatted := DefaultTheme.Format(test.role, test.expected); test.got != formatted {
+			t.Errorf("Expected %q but got %q", formatted, test.got)
+		}
+		if plain := stripANSI(test.got); plain != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, plain)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package colorize
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// precision of the numbers compared by jsonEqual, in bits (exact for integers of up to 154 digits)
+const jsonNumberPrec = 512
+
+// regex for object keys that can be written in dot notation
+var identRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+/*
+jsonPath appends an object key to the given path, in dot notation when possible
+(e.g., $.name, $["content-type"]).
+
+Parameters:
+  - path: The parent path.
+  - key: The object key.
+
+Return:
+  - string: The path of the key.
+*/
+func jsonPath(path string, key string) string {
+	if identRegex.MatchString(key) {
+		return path + "." + key
+	}
+	quoted, _ := json.Marshal(key)
+	return path + "[" + string(quoted) + "]"
+}
+
+/*
+jsonValue returns the compact JSON representation of a decoded value.
+
+Parameters:
+  - v: The decoded value.
+
+Return:
+  - string: The JSON representation.
+*/
+func jsonValue(v any) string {
+	encoded, _ := json.Marshal(v)
+	return string(encoded)
+}
+
+/*
+decodeJSON decodes a JSON document, keeping its numbers as json.Number so that no precision is
+lost (e.g., on 64-bit IDs).
+
+Parameters:
+  - data: The JSON document.
+
+Return:
+  - any: The decoded value.
+  - error: An error if the document is not valid JSON.
+*/
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+	return v, nil
+}
+
+/*
+jsonEqual reports whether two decoded JSON values are equal. Numbers are compared by value, so
+that 1.0 equals 1 but 12345678901234567 doesn't equal 12345678901234568.
+
+Parameters:
+  - a: The old value.
+  - b: The new value.
+
+Return:
+  - bool: true if the values are equal, false otherwise.
+*/
+func jsonEqual(a any, b any) bool {
+	aNum, aOk := a.(json.Number)
+	bNum, bOk := b.(json.Number)
+	if !aOk || !bOk {
+		return reflect.DeepEqual(a, b)
+	}
+	if aNum == bNum {
+		return true
+	}
+	aFloat, _, aErr := big.ParseFloat(string(aNum), 10, jsonNumberPrec, big.ToNearestEven)
+	bFloat, _, bErr := big.ParseFloat(string(bNum), 10, jsonNumberPrec, big.ToNearestEven)
+	return aErr == nil && bErr == nil && aFloat.Cmp(bFloat) == 0
+}
+
+/*
+diffValues appends the differences between two decoded JSON values to lines.
+
+Objects are compared key by key (in sorted order) and arrays index by index; any other difference
+(including a change of type) is reported as a change of the whole value.
+
+Parameters:
+  - lines: The lines to append to.
+  - path: The path of the values.
+  - a: The old value.
+  - b: The new value.
+  - theme: The theme used to style the lines.
+
+Return:
+  - []string: The resulting lines.
+*/
+func diffValues(lines []string, path string, a any, b any, theme Theme) []string {
+	switch a := a.(type) {
+	case map[string]any:
+		if b, ok := b.(map[string]any); ok {
+			keys := make([]string, 0, len(a)+len(b))
+			for key := range a {
+				keys = append(keys, key)
+			}
+			for key := range b {
+				if _, ok := a[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				oldValue, inA := a[key]
+				newValue, inB := b[key]
+				switch {
+				case !inA:
+					lines = append(lines, theme.Format("diff.added", fmt.Sprintf("+ %s: %s", jsonPath(path, key), jsonValue(newValue))))
+				case !inB:
+					lines = append(lines, theme.Format("diff.removed", fmt.Sprintf("- %s: %s", jsonPath(path, key), jsonValue(oldValue))))
+				default:
+					lines = diffValues(lines, jsonPath(path, key), oldValue, newValue, theme)
+				}
+			}
+			return lines
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			for i := 0; i < max(len(a), len(b)); i++ {
+				elemPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(a):
+					lines = append(lines, theme.Format("diff.added", fmt.Sprintf("+ %s: %s", elemPath, jsonValue(b[i]))))
+				case i >= len(b):
+					lines = append(lines, theme.Format("diff.removed", fmt.Sprintf("- %s: %s", elemPath, jsonValue(a[i]))))
+				default:
+					lines = diffValues(lines, elemPath, a[i], b[i], theme)
+				}
+			}
+			return lines
+		}
+	}
+
+	if !jsonEqual(a, b) {
+		lines = append(lines, theme.Format("diff.changed", fmt.Sprintf("~ %s: %s → %s", path, jsonValue(a), jsonValue(b))))
+	}
+	return lines
+}
+
+/*
+DiffJSON compares two JSON documents structurally and returns a colorized diff, one line per
+difference, styled with the "diff.added", "diff.removed" and "diff.changed" roles of DefaultTheme.
+Each line starts with "+" (added value), "-" (removed value) or "~" (changed value, shown as
+"old → new"), followed by the JSONPath of the value.
+
+Unlike a line-based diff, formatting and key order don't matter. Numbers are compared exactly,
+whatever their size.
+
+Parameters:
+  - a: The old JSON document.
+  - b: The new JSON document.
+
+Return:
+  - string: The diff, or an empty string if the documents are equivalent.
+  - error: An error if either document is not valid JSON.
+
+Example:
+
+	diff, err := c.DiffJSON([]byte(`{"port": 80, "debug": true}`), []byte(`{"port": 8080}`))
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println(diff)
+	// - $.debug: true
+	// ~ $.port: 80 → 8080
+*/
+func DiffJSON(a []byte, b []byte) (string, error) {
+	oldDoc, err := decodeJSON(a)
+	if err != nil {
+		return "", newColorizeErr("JSONERR", fmt.Sprintf("invalid old document: %v", err))
+	}
+	newDoc, err := decodeJSON(b)
+	if err != nil {
+		return "", newColorizeErr("JSONERR", fmt.Sprintf("invalid new document: %v", err))
+	}
+
+	return strings.Join(diffValues(nil, "$", oldDoc, newDoc, DefaultTheme), "\n"), nil
+}
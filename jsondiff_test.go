@@ -0,0 +1,55 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestDiffJSON tests the DiffJSON function */
+func TestDiffJSON(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	tests := []struct {
+		a, b     string
+		expected string
+	}{
+		{`{"a": 1, "b": [1, 2]}`, `{"b":[1,2],"a":1}`, ""},
+		{`{"port": 80, "debug": true}`, `{"port": 8080}`, "- $.debug: true\n~ $.port: 80 → 8080"},
+		{`{"tags": ["a"]}`, `{"tags": ["b", "c"]}`, "~ $.tags[0]: \"a\" → \"b\"\n+ $.tags[1]: \"c\""},
+		{`{"x": {"y": 1}}`, `{"x": [1]}`, "~ $.x: {\"y\":1} → [1]"},
+		{`{"content-type": "a"}`, `{}`, "- $[\"content-type\"]: \"a\""},
+		{`1`, `2`, "~ $: 1 → 2"},
+		{`{"id": 12345678901234567}`, `{"id": 12345678901234568}`, "~ $.id: 12345678901234567 → 12345678901234568"},
+		{`{"n": 1.0, "m": 100}`, `{"n": 1, "m": 1e2}`, ""},
+	}
+	for _, test := range tests {
+		diff, err := DiffJSON([]byte(test.a), []byte(test.b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, diff)
+		}
+	}
+
+	// styled
+	trueColor = true
+	diff, _ := DiffJSON([]byte(`{}`), []byte(`{"a": 1}`))
+	if diff != DefaultTheme.Format("diff.added", "+ $.a: 1") || diff == "+ $.a: 1" {
+		t.Errorf("Unexpected diff: %q", diff)
+	}
+
+	// invalid documents
+	if _, err := DiffJSON([]byte(`{`), []byte(`{}`)); err == nil {
+		t.Error("Expected an error")
+	}
+	if _, err := DiffJSON([]byte(`{}`), []byte(`nope`)); err == nil {
+		t.Error("Expected an error")
+	}
+	if _, err := DiffJSON([]byte(`{} {}`), []byte(`{}`)); err == nil {
+		t.Error("Expected an error on trailing data")
+	}
+}
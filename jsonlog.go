@@ -0,0 +1,176 @@
+package colorize
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/* The JSONLogOptions type represents the options of a JSONLogFormatter */
+type JSONLogOptions struct {
+	LevelKey   string  // key of the level field ("level" if empty)
+	TimeKey    string  // key of the time field ("time" if empty)
+	MessageKey string  // key of the message field ("msg" if empty)
+	Layout     *Layout // layout of the lines (DefaultLogLayout with DefaultTheme if nil)
+}
+
+/*
+The JSONLogFormatter type renders NDJSON log lines (one JSON object per line) as human-friendly
+colored lines with a Layout: by default, the time, the level, the message and the other fields as
+sorted key=value pairs. Lines that aren't JSON objects are written as is.
+
+It implements io.Writer, so that it can be the output of a logger or the destination of io.Copy.
+Colors follow the color level of the underlying writer (see DetectLevel).
+*/
+type JSONLogFormatter struct {
+	colorizer *Colorizer
+	opts      JSONLogOptions
+	pending   []byte // partial line held back until its line break arrives
+}
+
+/*
+NewJSONLogFormatter returns a JSONLogFormatter writing to the given writer.
+
+Parameters:
+  - w: The writer.
+  - opts: The options, or nil for the defaults.
+
+Return:
+  - *JSONLogFormatter: The newly created formatter.
+
+Example:
+
+	f := c.NewJSONLogFormatter(os.Stdout, &c.JSONLogOptions{MessageKey: "message"})
+	_, err := io.Copy(f, os.Stdin)
+	f.Flush()
+*/
+func NewJSONLogFormatter(w io.Writer, opts *JSONLogOptions) *JSONLogFormatter {
+	f := &JSONLogFormatter{colorizer: NewColorizer(w)}
+	if opts != nil {
+		f.opts = *opts
+	}
+	if f.opts.LevelKey == "" {
+		f.opts.LevelKey = "level"
+	}
+	if f.opts.TimeKey == "" {
+		f.opts.TimeKey = "time"
+	}
+	if f.opts.MessageKey == "" {
+		f.opts.MessageKey = "msg"
+	}
+	if f.opts.Layout == nil {
+		f.opts.Layout, _ = CompileLayout(DefaultLogLayout, nil)
+	}
+	return f
+}
+
+/*
+Write renders the complete lines of p. A trailing partial line is held back until its line break
+arrives (see Flush).
+
+Parameters:
+  - p: The bytes to write.
+
+Return:
+  - int: The number of bytes consumed (len(p) unless writing failed).
+  - error: The write error, if any.
+*/
+func (f *JSONLogFormatter) Write(p []byte) (int, error) {
+	f.pending = append(f.pending, p...)
+	for {
+		i := bytes.IndexByte(f.pending, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(bytes.TrimSuffix(f.pending[:i], []byte("\r")))
+		f.pending = f.pending[i+1:]
+		if _, err := io.WriteString(f.colorizer.Writer(), f.render(line)+"\n"); err != nil {
+			return 0, err
+		}
+	}
+}
+
+/*
+Flush renders the partial line held back by Write, if any, as a complete line.
+
+Return:
+  - error: The write error, if any.
+*/
+func (f *JSONLogFormatter) Flush() error {
+	if len(f.pending) == 0 {
+		return nil
+	}
+	line := string(f.pending)
+	f.pending = nil
+	_, err := io.WriteString(f.colorizer.Writer(), f.render(line)+"\n")
+	return err
+}
+
+/*
+render renders a log line.
+
+Parameters:
+  - line: The line, without its line break.
+
+Return:
+  - string: The rendered line, or the line itself if it isn't a JSON object.
+*/
+func (f *JSONLogFormatter) render(line string) string {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return line
+	}
+
+	record := &LogRecord{}
+	if raw, ok := fields[f.opts.TimeKey]; ok {
+		record.Time = jsonLogValue(raw)
+		delete(fields, f.opts.TimeKey)
+	}
+	if raw, ok := fields[f.opts.LevelKey]; ok {
+		record.Level = jsonLogValue(raw)
+		delete(fields, f.opts.LevelKey)
+	}
+	if raw, ok := fields[f.opts.MessageKey]; ok {
+		record.Message = jsonLogValue(raw)
+		delete(fields, f.opts.MessageKey)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := jsonLogValue(fields[key])
+		// strings are quoted when needed to be told apart, other values are compact JSON
+		if bytes.HasPrefix(fields[key], []byte(`"`)) && (value == "" || strings.ContainsAny(value, " \t\"=")) {
+			value = strconv.Quote(value)
+		}
+		record.Fields = append(record.Fields, LogField{key, value})
+	}
+	return f.opts.Layout.render(record, f.colorizer.format)
+}
+
+/*
+jsonLogValue returns the text of a JSON value: strings are unquoted, other values are compacted.
+
+Parameters:
+  - raw: The JSON value.
+
+Return:
+  - string: The text of the value.
+*/
+func jsonLogValue(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
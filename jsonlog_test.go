@@ -0,0 +1,80 @@
+package colorize
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+/* TestJSONLogFormatter tests the rendering of NDJSON lines without colors */
+func TestJSONLogFormatter(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	tests := []struct {
+		opts     *JSONLogOptions
+		input    string
+		expected string
+	}{
+		{nil, `{"time":"12:00:01","level":"info","msg":"started","port":8080,"env":"prod"}`, "12:00:01 INFO started env=prod port=8080"},
+		{nil, `{"msg":"failed","level":"error","err":"disk full","tags":["a","b"]}`, `ERROR failed err="disk full" tags=["a","b"]`},
+		{nil, "not json", "not json"},
+		{nil, `{"level":"warn","empty":""}`, `WARN empty=""`},
+		{&JSONLogOptions{LevelKey: "severity", MessageKey: "message", TimeKey: "ts"}, `{"ts":1,"severity":"debug","message":"hi"}`, "1 DEBUG hi"},
+	}
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		f := NewJSONLogFormatter(buf, test.opts)
+		if _, err := io.WriteString(f, test.input+"\n"); err != nil {
+			t.Fatal("Expected no error but got", err)
+		}
+		if buf.String() != test.expected+"\n" {
+			t.Errorf("%s: expected %q but got %q", test.input, test.expected+"\n", buf.String())
+		}
+	}
+}
+
+/* TestJSONLogFormatterPartial tests that partial lines are held back until flushed */
+func TestJSONLogFormatterPartial(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	buf := &bytes.Buffer{}
+	f := NewJSONLogFormatter(buf, nil)
+	_, _ = io.WriteString(f, `{"msg":"one"}`+"\r\n"+`{"msg":`)
+	if buf.String() != "one\n" {
+		t.Errorf("Expected %q but got %q", "one\n", buf.String())
+	}
+	_, _ = io.WriteString(f, `"two"}`)
+	if err := f.Flush(); err != nil || buf.String() != "one\ntwo\n" {
+		t.Errorf("Expected %q but got %q (%v)", "one\ntwo\n", buf.String(), err)
+	}
+}
+
+/* TestJSONLogFormatterColors tests the colors of the default layout */
+func TestJSONLogFormatterColors(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	SetColorLevel(LevelTrueColor)
+
+	buf := &bytes.Buffer{}
+	f := NewJSONLogFormatter(buf, nil)
+	_, _ = io.WriteString(f, `{"level":"warning","msg":"slow","ms":120}`+"\n")
+	level := DefaultTheme.Format("log.level.warn", "WARNING")
+	msg, _ := FormatText("slow", &Options{Styles: []string{"bold"}})
+	key := DefaultTheme.Format("log.key", "ms=")
+	if expected := level + " " + msg + " " + key + "120\n"; buf.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, buf.String())
+	}
+	if !strings.Contains(buf.String(), "\x1b[") {
+		t.Error("Expected colors in", buf.String())
+	}
+}
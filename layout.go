@@ -0,0 +1,282 @@
+package colorize
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* The LogField type represents a field of a log record */
+type LogField struct {
+	Key   string
+	Value string // the value as displayed (e.g., quoted if needed)
+}
+
+/* The LogRecord type represents a log record rendered by a Layout */
+type LogRecord struct {
+	Time    string
+	Level   string
+	Message string
+	Fields  []LogField // the other fields, in display order
+}
+
+/* layoutFilter is a compiled filter of a layout placeholder */
+type layoutFilter func(text string, record *LogRecord, format func(text string, opts *Options) string) string
+
+/* layoutSegment is a literal text or a placeholder of a layout */
+type layoutSegment struct {
+	literal string
+	name    string // name of the placeholder, empty for literal text
+	filters []layoutFilter
+	kv      bool // themes the keys of the fields
+}
+
+/*
+The Layout type represents a compiled log line layout (see CompileLayout), shared by the log
+renderers (e.g., JSONLogFormatter) instead of hard-coded output shapes.
+*/
+type Layout struct {
+	segments []layoutSegment
+	theme    Theme
+}
+
+// DefaultLogLayout is the layout of the log renderers when none is given
+const DefaultLogLayout = "{time|dim} {level|upper|levelcolor} {msg|bold} {fields|kv}"
+
+/*
+CompileLayout compiles a log line layout. Placeholders between braces are replaced with the parts of
+each record, filtered left to right by the filters following "|":
+  - time, level, msg: the time, the level and the message of the record.
+  - fields: the other fields, as space-separated key=value pairs.
+  - any other name: the value of the field with that key, which is then left out of fields.
+
+The filters are:
+  - upper, lower: change the case of the text.
+  - levelcolor: formats the text with the "log.level.*" role of the record level (e.g.,
+    "log.level.warn" for "warning").
+  - kv: formats the keys of fields with the "log.key" role.
+  - dim: formats the text in gray.
+  - a role of the theme (e.g., "inline.code"), a style (e.g., "bold") or a color (e.g., "#FF8000"):
+    formats the text with it.
+
+A placeholder rendering empty text drops the spaces following it, so that missing parts leave no
+gaps. Use "{{" and "}}" for literal braces.
+
+Parameters:
+  - spec: The layout (e.g., DefaultLogLayout).
+  - theme: The theme of the roles, or nil for DefaultTheme.
+
+Return:
+  - *Layout: The compiled layout.
+  - error: An error if a placeholder is unterminated or a filter is unknown.
+
+Example:
+
+	layout, err := c.CompileLayout("{level|upper|levelcolor} [{component|#5FD7FF}] {msg} {fields|kv}", nil)
+*/
+func CompileLayout(spec string, theme Theme) (*Layout, error) {
+	layout := &Layout{theme: theme.orDefault()}
+	literal := strings.Builder{}
+	flush := func() {
+		if literal.Len() > 0 {
+			layout.segments = append(layout.segments, layoutSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(spec); i++ {
+		switch {
+		case strings.HasPrefix(spec[i:], "{{"), strings.HasPrefix(spec[i:], "}}"):
+			literal.WriteByte(spec[i])
+			i++
+		case spec[i] == '{':
+			end := strings.IndexByte(spec[i:], '}')
+			if end < 0 {
+				return nil, newColorizeErr("LAYOUTERR", fmt.Sprintf("unterminated placeholder at offset %d", i))
+			}
+			segment, err := layout.compilePlaceholder(spec[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			layout.segments = append(layout.segments, segment)
+			i += end
+		default:
+			literal.WriteByte(spec[i])
+		}
+	}
+	flush()
+	return layout, nil
+}
+
+/*
+compilePlaceholder compiles the content of a placeholder (the name and its filters).
+
+Parameters:
+  - content: The content, between the braces.
+
+Return:
+  - layoutSegment: The compiled placeholder.
+  - error: An error if the name is empty or a filter is unknown.
+*/
+func (l *Layout) compilePlaceholder(content string) (layoutSegment, error) {
+	parts := strings.Split(content, "|")
+	segment := layoutSegment{name: strings.TrimSpace(parts[0])}
+	if segment.name == "" {
+		return segment, newColorizeErr("LAYOUTERR", fmt.Sprintf("empty placeholder {%s}", content))
+	}
+
+	for _, name := range parts[1:] {
+		name = strings.TrimSpace(name)
+		var opts *Options
+		switch {
+		case name == "upper":
+			segment.filters = append(segment.filters, func(text string, _ *LogRecord, _ func(string, *Options) string) string {
+				return strings.ToUpper(text)
+			})
+			continue
+		case name == "lower":
+			segment.filters = append(segment.filters, func(text string, _ *LogRecord, _ func(string, *Options) string) string {
+				return strings.ToLower(text)
+			})
+			continue
+		case name == "levelcolor":
+			theme := l.theme
+			segment.filters = append(segment.filters, func(text string, record *LogRecord, format func(string, *Options) string) string {
+				return format(text, theme["log.level."+logLevel(record.Level)])
+			})
+			continue
+		case name == "kv":
+			segment.kv = true
+			continue
+		case name == "dim":
+			opts = &Options{FgColor: "#808080"}
+		case l.theme[name] != nil:
+			opts = l.theme[name]
+		case styles[name] != "":
+			opts = &Options{Styles: []string{name}}
+		default:
+			col, err := ParseColor(name)
+			if err != nil {
+				return segment, newColorizeErr("LAYOUTERR", fmt.Sprintf("unknown filter %q in {%s}", name, content))
+			}
+			opts = &Options{FgColor: col.Hex()}
+		}
+		segment.filters = append(segment.filters, func(text string, _ *LogRecord, format func(string, *Options) string) string {
+			return format(text, opts)
+		})
+	}
+	return segment, nil
+}
+
+/*
+render renders a record.
+
+Parameters:
+  - record: The record.
+  - format: The function formatting a piece of text (e.g., the format method of a Colorizer).
+
+Return:
+  - string: The rendered line, without line break.
+*/
+func (l *Layout) render(record *LogRecord, format func(text string, opts *Options) string) string {
+	// fields named by a placeholder are left out of {fields}
+	named := map[string]bool{}
+	for _, segment := range l.segments {
+		named[segment.name] = true
+	}
+
+	builder := strings.Builder{}
+	skipSpaces := false
+	for _, segment := range l.segments {
+		if segment.name == "" {
+			text := segment.literal
+			if skipSpaces {
+				text = strings.TrimLeft(text, " ")
+			}
+			builder.WriteString(text)
+			skipSpaces = false
+			continue
+		}
+
+		var text string
+		switch segment.name {
+		case "time":
+			text = record.Time
+		case "level":
+			text = record.Level
+		case "msg":
+			text = record.Message
+		case "fields":
+			pairs := make([]string, 0, len(record.Fields))
+			for _, field := range record.Fields {
+				if named[field.Key] {
+					continue
+				}
+				key := field.Key + "="
+				if segment.kv {
+					key = format(key, l.theme["log.key"])
+				}
+				pairs = append(pairs, key+field.Value)
+			}
+			text = strings.Join(pairs, " ")
+		default:
+			for _, field := range record.Fields {
+				if field.Key == segment.name {
+					text = field.Value
+					break
+				}
+			}
+		}
+
+		if skipSpaces = text == ""; skipSpaces {
+			continue
+		}
+		for _, filter := range segment.filters {
+			text = filter(text, record, format)
+		}
+		builder.WriteString(text)
+	}
+	return strings.TrimRight(builder.String(), " ")
+}
+
+/*
+Render renders a record, with colors if the system supports them.
+
+Parameters:
+  - record: The record.
+
+Return:
+  - string: The rendered line, without line break.
+
+Example:
+
+	layout, _ := c.CompileLayout(c.DefaultLogLayout, nil)
+	fmt.Println(layout.Render(c.LogRecord{Level: "info", Message: "listening", Fields: []c.LogField{{"port", "8080"}}}))
+*/
+func (l *Layout) Render(record LogRecord) string {
+	return l.render(&record, applyOptions)
+}
+
+/*
+logLevel normalizes a log level to one of "debug", "info", "warn" and "error", for picking its
+theme role. Unknown levels are returned lowercased.
+
+Parameters:
+  - level: The level.
+
+Return:
+  - string: The normalized level.
+*/
+func logLevel(level string) string {
+	switch level = strings.ToLower(level); level {
+	case "trace", "debug":
+		return "debug"
+	case "information", "notice":
+		return "info"
+	case "warning":
+		return "warn"
+	case "err", "fatal", "panic", "critical":
+		return "error"
+	}
+	return level
+}
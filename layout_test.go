@@ -0,0 +1,81 @@
+package colorize
+
+import (
+	"testing"
+)
+
+// record rendered by the Layout tests
+var layoutRecord = LogRecord{
+	Time:    "12:00:01",
+	Level:   "warning",
+	Message: "slow request",
+	Fields:  []LogField{{"component", "http"}, {"ms", "120"}},
+}
+
+/* TestCompileLayout tests the rendering of compiled layouts without colors */
+func TestCompileLayout(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	tests := []struct {
+		spec     string
+		record   LogRecord
+		expected string
+	}{
+		{DefaultLogLayout, layoutRecord, "12:00:01 WARNING slow request component=http ms=120"},
+		{"[{component}] {level|lower} {msg} {fields}", layoutRecord, "[http] warning slow request ms=120"},
+		{"{time} {level|upper} {msg} {fields}", LogRecord{Message: "hi"}, "hi"},
+		{"{{{msg}}}", LogRecord{Message: "hi"}, "{hi}"},
+	}
+	for _, test := range tests {
+		layout, err := CompileLayout(test.spec, nil)
+		if err != nil {
+			t.Fatalf("%s: expected no error but got %v", test.spec, err)
+		}
+		if got := layout.Render(test.record); got != test.expected {
+			t.Errorf("%s: expected %q but got %q", test.spec, test.expected, got)
+		}
+	}
+
+	// invalid layouts
+	for _, spec := range []string{"{msg", "{}", "{msg|nope}"} {
+		if _, err := CompileLayout(spec, nil); err == nil {
+			t.Errorf("%s: expected an error but got nil", spec)
+		}
+	}
+}
+
+/* TestCompileLayoutFilters tests the formatting filters of layouts */
+func TestCompileLayoutFilters(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	SetColorLevel(LevelTrueColor)
+
+	theme := Theme{"component": {FgColor: "#5FD7FF"}, "log.level.warn": {FgColor: "#FFD700"}, "log.key": {Styles: []string{"italic"}}}
+	layout, err := CompileLayout("{level|upper|levelcolor} {component|component} {msg|#FF8000|underline} {fields|kv}", theme)
+	if err != nil {
+		t.Fatal("Expected no error but got", err)
+	}
+	level, _ := FormatText("WARNING", theme["log.level.warn"])
+	component, _ := FormatText("http", theme["component"])
+	msg, _ := FormatText("slow request", &Options{FgColor: "#FF8000"})
+	msg, _ = FormatText(msg, &Options{Styles: []string{"underline"}})
+	key, _ := FormatText("ms=", theme["log.key"])
+	if expected := level + " " + component + " " + msg + " " + key + "120"; layout.Render(layoutRecord) != expected {
+		t.Errorf("Expected %q but got %q", expected, layout.Render(layoutRecord))
+	}
+}
+
+/* TestLogLevel tests the normalization of the log levels */
+func TestLogLevel(t *testing.T) {
+	tests := map[string]string{"TRACE": "debug", "Info": "info", "WARNING": "warn", "fatal": "error", "audit": "audit"}
+	for level, expected := range tests {
+		if got := logLevel(level); got != expected {
+			t.Errorf("%s: expected %q but got %q", level, expected, got)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package colorize
+
+/* The Level type represents the color level escape codes are rendered with */
+type Level int
+
+const (
+	/* Supported color levels, matching the levels of FORCE_COLOR */
+	LevelNone      Level = iota // no escape codes
+	LevelAnsi16                 // the 16 basic colors
+	LevelAnsi256                // the Xterm 256-color palette
+	LevelTrueColor              // 24-bit colors
+)
+
+// the color level was set by SetColorLevel, overriding the environment
+var levelOverride = false
+
+/*
+String returns the name of the level.
+
+Return:
+  - string: The name of the level (e.g., "truecolor").
+*/
+func (l Level) String() string {
+	switch l {
+	case LevelNone:
+		return "none"
+	case LevelAnsi16:
+		return "ansi16"
+	case LevelAnsi256:
+		return "ansi256"
+	case LevelTrueColor:
+		return "truecolor"
+	}
+	return "unknown"
+}
+
+/*
+SetColorLevel sets the color level at runtime, overriding the detection from the environment
+(COLORTERM, TERM, NO_COLOR, FORCE_COLOR...) and the terminal-only mode. This is meant for tests and
+for flags such as --color=always/never.
+
+Parameters:
+  - level: The color level.
+
+Example:
+
+	switch *colorFlag {
+	case "always":
+		c.SetColorLevel(c.LevelTrueColor)
+	case "never":
+		c.SetColorLevel(c.LevelNone)
+	}
+*/
+func SetColorLevel(level Level) {
+	levelOverride = true
+	trueColor = level >= LevelTrueColor
+	xTerm = level >= LevelAnsi256
+	ansi16 = level >= LevelAnsi16
+	noColor = level == LevelNone
+}
+
+/*
+ColorLevel returns the color level escape codes are currently rendered with, as detected from the
+environment or set by SetColorLevel.
+
+Return:
+  - Level: The color level.
+
+Example:
+
+	if c.ColorLevel() == c.LevelTrueColor {
+		// use gradients
+	}
+*/
+func ColorLevel() Level {
+	switch {
+	case noColor:
+		return LevelNone
+	case trueColor:
+		return LevelTrueColor
+	case xTerm:
+		return LevelAnsi256
+	case ansi16:
+		return LevelAnsi16
+	}
+	return LevelNone
+}
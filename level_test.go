@@ -0,0 +1,51 @@
+package colorize
+
+import (
+	"bytes"
+	"testing"
+)
+
+/* TestSetColorLevel tests the SetColorLevel and ColorLevel functions */
+func TestSetColorLevel(t *testing.T) {
+	// defer restore
+	defer restore()
+	red := &Options{FgColor: "#FF0000"}
+
+	tests := []struct {
+		level    Level
+		expected Level
+		profile  string
+		output   string
+	}{
+		{LevelTrueColor, LevelTrueColor, "truecolor", "\033[38;2;255;0;0mhello" + reset},
+		{LevelAnsi256, LevelAnsi256, "xterm", "\033[38;5;196mhello" + reset},
+		{LevelAnsi16, LevelAnsi16, "ansi16", "\033[91mhello" + reset},
+		{LevelNone, LevelNone, "none", "hello"},
+	}
+	for _, test := range tests {
+		SetColorLevel(test.level)
+		if level := ColorLevel(); level != test.expected {
+			t.Errorf("%s: expected level %s but got %s", test.level, test.expected, level)
+		}
+		if output, err := FormatText("hello", red); err != nil || output != test.output {
+			t.Errorf("%s: expected %q but got %q, %v", test.level, test.output, output, err)
+		}
+		if exp := Explain("hello", red); exp.Profile != test.profile || exp.Reason != "set by SetColorLevel" {
+			t.Errorf("%s: unexpected profile %s (%s)", test.level, exp.Profile, exp.Reason)
+		}
+	}
+
+	// the level overrides the terminal-only mode
+	SetTerminalOnly(true)
+	SetColorLevel(LevelTrueColor)
+	var buf bytes.Buffer
+	NewPrinter(&buf).Print(red, "hello")
+	if buf.String() == "hello" {
+		t.Error("Expected colors to be emitted to a non-terminal")
+	}
+
+	// names
+	if name := Level(7).String(); name != "unknown" {
+		t.Errorf("Unexpected name: %s", name)
+	}
+}
@@ -0,0 +1,93 @@
+package colorize
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	// URLs (http and https) and email addresses
+	linkRegex = regexp.MustCompile(`https?://[^\s<>"'\x1b]+|[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	// punctuation ending a sentence rather than a URL
+	trailingPunct = ".,;:!?"
+)
+
+/*
+Hyperlink wraps the given text in an OSC 8 hyperlink to the given URL. Terminals not supporting
+hyperlinks display the text only.
+
+Parameters:
+  - url: The target of the link.
+  - text: The text of the link.
+
+Return:
+  - string: The linked text.
+
+Example:
+
+	fmt.Println(c.Hyperlink("https://go.dev", "Go"))
+*/
+func Hyperlink(url string, text string) string {
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
+
+/*
+trimLink trims the trailing punctuation of a detected URL (e.g., the period ending a sentence).
+Closing parentheses are trimmed only if unbalanced, to keep links like
+"https://en.wikipedia.org/wiki/Go_(programming_language)" intact.
+
+Parameters:
+  - link: The detected URL.
+
+Return:
+  - string: The trimmed URL.
+*/
+func trimLink(link string) string {
+	for link != "" {
+		last := link[len(link)-1]
+		switch {
+		case strings.IndexByte(trailingPunct, last) >= 0:
+		case last == ')' && strings.Count(link, ")") > strings.Count(link, "("):
+		default:
+			return link
+		}
+		link = link[:len(link)-1]
+	}
+	return link
+}
+
+/*
+Linkify detects the URLs and email addresses of the given text and styles them with the "link"
+role of DefaultTheme. If the terminal supports hyperlinks (see FeatureHyperlinks), they're also
+wrapped in OSC 8 hyperlinks (email addresses link to "mailto:"); otherwise only the style is
+applied.
+
+Parameters:
+  - text: The text.
+
+Return:
+  - string: The text with its links styled.
+
+Example:
+
+	fmt.Println(c.Linkify("See https://go.dev/doc or write to gopher@example.com"))
+*/
+func Linkify(text string) string {
+	linkable := HasFeature(FeatureHyperlinks) && terminalAllows(os.Stdout)
+
+	return linkRegex.ReplaceAllStringFunc(text, func(match string) string {
+		link, target := match, "mailto:"+match
+		if strings.HasPrefix(match, "http") {
+			link = trimLink(match)
+			target = link
+		}
+		rest := match[len(link):]
+
+		styled := DefaultTheme.Format("link", link)
+		if linkable {
+			styled = Hyperlink(target, styled)
+		}
+		return styled + rest
+	})
+}
@@ -0,0 +1,54 @@
+package colorize
+
+import "testing"
+
+/* TestTrimLink tests the trimLink function */
+func TestTrimLink(t *testing.T) {
+	tests := map[string]string{
+		"https://go.dev.":       "https://go.dev",
+		"https://go.dev/doc),":  "https://go.dev/doc",
+		"https://a.io/Go_(x)":   "https://a.io/Go_(x)",
+		"https://a.io/Go_(x)).": "https://a.io/Go_(x)",
+	}
+	for link, expected := range tests {
+		if got := trimLink(link); got != expected {
+			t.Errorf("%s: expected %q but got %q", link, expected, got)
+		}
+	}
+}
+
+/* TestLinkify tests the Linkify function */
+func TestLinkify(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	for _, name := range []string{"TERM_PROGRAM", "WT_SESSION", "VTE_VERSION"} {
+		t.Setenv(name, "")
+	}
+
+	text := "See https://go.dev/doc. Or write to gopher@example.com!"
+	link := DefaultTheme.Format("link", "https://go.dev/doc")
+	email := DefaultTheme.Format("link", "gopher@example.com")
+
+	// hyperlinks not supported: underline only
+	expected := "See " + link + ". Or write to " + email + "!"
+	if got := Linkify(text); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	// hyperlinks supported
+	t.Setenv("TERM_PROGRAM", "iTerm.app")
+	expected = "See " + Hyperlink("https://go.dev/doc", link) + ". Or write to " +
+		Hyperlink("mailto:gopher@example.com", email) + "!"
+	if got := Linkify(text); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+	if plain := stripANSI(Linkify(text)); plain != text {
+		t.Errorf("Expected %q but got %q", text, plain)
+	}
+
+	// no links
+	if got := Linkify("nothing to see"); got != "nothing to see" {
+		t.Errorf("Expected the text unmodified but got %q", got)
+	}
+}
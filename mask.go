@@ -0,0 +1,138 @@
+package colorize
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// default number of trailing characters left visible by MaskSecret
+	defaultMaskReveal = 4
+	// default number of mask characters
+	defaultMaskLength = 4
+	// default mask character
+	defaultMaskChar = "•"
+)
+
+var (
+	// patterns of the secrets masked by MaskSecrets
+	secretPatterns   []*regexp.Regexp
+	secretPatternsMu sync.RWMutex
+)
+
+/* The MaskOptions type represents the options for masking a secret */
+type MaskOptions struct {
+	Reveal int    // trailing characters left visible (4 if 0, none if negative)
+	Length int    // number of mask characters (4 if 0), fixed so that the length of the secret isn't disclosed
+	Char   string // mask character ("•" if empty)
+}
+
+/*
+MaskSecret masks a secret for display, keeping its last characters visible so that it can still be
+identified (e.g., "••••1234"). The mask is styled with the "secret.mask" role of DefaultTheme.
+Secrets too short to reveal part of them safely (no longer than twice the reveal length) are
+masked entirely.
+
+Parameters:
+  - value: The secret.
+  - opts: The masking options.
+
+Return:
+  - string: The masked secret, or an empty string if the secret is empty.
+
+Example:
+
+	fmt.Println("token:", c.MaskSecret(token, c.MaskOptions{}))
+	// token: ••••f3a9
+*/
+func MaskSecret(value string, opts MaskOptions) string {
+	if value == "" {
+		return ""
+	}
+	reveal := opts.Reveal
+	if reveal == 0 {
+		reveal = defaultMaskReveal
+	}
+	length := opts.Length
+	if length <= 0 {
+		length = defaultMaskLength
+	}
+	char := opts.Char
+	if char == "" {
+		char = defaultMaskChar
+	}
+
+	runes := []rune(value)
+	visible := ""
+	if reveal > 0 && len(runes) > 2*reveal {
+		visible = string(runes[len(runes)-reveal:])
+	}
+	return DefaultTheme.Format("secret.mask", strings.Repeat(char, length)) + visible
+}
+
+/*
+RegisterSecretPattern registers a pattern of secrets masked by MaskSecrets (and so by the writers
+returned by NewMaskWriter). If the pattern has a capturing group, only the text of the first group
+is masked (e.g., `token=(\S+)`), otherwise the whole match is.
+
+Parameters:
+  - pattern: The pattern.
+
+Example:
+
+	c.RegisterSecretPattern(regexp.MustCompile(`ghp_[A-Za-z0-9]{36}`))
+	c.RegisterSecretPattern(regexp.MustCompile(`(?i)password=(\S+)`))
+*/
+func RegisterSecretPattern(pattern *regexp.Regexp) {
+	secretPatternsMu.Lock()
+	defer secretPatternsMu.Unlock()
+	secretPatterns = append(secretPatterns, pattern)
+}
+
+/*
+MaskSecrets masks the secrets matching the registered patterns (see RegisterSecretPattern) in the
+given text, with MaskSecret and its default options.
+
+Parameters:
+  - text: The text.
+
+Return:
+  - string: The text with its secrets masked.
+*/
+func MaskSecrets(text string) string {
+	secretPatternsMu.RLock()
+	defer secretPatternsMu.RUnlock()
+
+	for _, pattern := range secretPatterns {
+		text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+			groups := pattern.FindStringSubmatchIndex(match)
+			if len(groups) < 4 || groups[2] < 0 {
+				return MaskSecret(match, MaskOptions{})
+			}
+			return match[:groups[2]] + MaskSecret(match[groups[2]:groups[3]], MaskOptions{}) + match[groups[3]:]
+		})
+	}
+	return text
+}
+
+/*
+NewMaskWriter returns a LineWriter masking the secrets matching the registered patterns (see
+RegisterSecretPattern) in every line written to it, e.g., to filter the output of a subprocess.
+
+Parameters:
+  - w: The writer to write the masked lines to.
+
+Return:
+  - *LineWriter: The newly created writer, to be closed to flush the last line.
+
+Example:
+
+	w := c.NewMaskWriter(os.Stdout)
+	defer w.Close()
+	cmd.Stdout = w
+*/
+func NewMaskWriter(w io.Writer) *LineWriter {
+	return NewLineWriterFunc(w, MaskSecrets, nil)
+}
@@ -0,0 +1,59 @@
+package colorize
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"testing"
+)
+
+/* TestMaskSecret tests the MaskSecret function */
+func TestMaskSecret(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	if got, expected := MaskSecret("sk-live-abcd1234", MaskOptions{}), DefaultTheme.Format("secret.mask", "••••")+"1234"; got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	tests := []struct {
+		value    string
+		opts     MaskOptions
+		expected string
+	}{
+		{"sk-live-abcd1234", MaskOptions{Reveal: 2, Length: 6, Char: "*"}, "******34"},
+		{"sk-live-abcd1234", MaskOptions{Reveal: -1}, "••••"},
+		{"12345678", MaskOptions{}, "••••"},
+		{"123456789", MaskOptions{}, "••••6789"},
+		{"", MaskOptions{}, ""},
+	}
+	for _, test := range tests {
+		if got := stripANSI(MaskSecret(test.value, test.opts)); got != test.expected {
+			t.Errorf("%q: expected %q but got %q", test.value, test.expected, got)
+		}
+	}
+}
+
+/* TestMaskSecrets tests the MaskSecrets function and the mask writer */
+func TestMaskSecrets(t *testing.T) {
+	defer func(prev []*regexp.Regexp) { secretPatterns = prev }(secretPatterns)
+	secretPatterns = nil
+
+	RegisterSecretPattern(regexp.MustCompile(`ghp_[A-Za-z0-9]{12}`))
+	RegisterSecretPattern(regexp.MustCompile(`password=(\S+)`))
+
+	got := stripANSI(MaskSecrets("token ghp_abcdef123456 password=hunter2-longer-value ok"))
+	if expected := "token ••••3456 password=••••alue ok"; got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	var buf bytes.Buffer
+	w := NewMaskWriter(&buf)
+	io.WriteString(w, "login password=sup")
+	io.WriteString(w, "ersecret\nbye")
+	w.Close()
+	if expected := "login password=••••cret\nbye"; stripANSI(buf.String()) != expected {
+		t.Errorf("Expected %q but got %q", expected, stripANSI(buf.String()))
+	}
+}
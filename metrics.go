@@ -0,0 +1,113 @@
+package colorize
+
+import (
+	"sync/atomic"
+)
+
+/* The Metric type identifies a quantity reported to the metrics sink */
+type Metric int
+
+const (
+	/* Metrics reported by the package */
+	MetricFormatCalls      Metric = iota // calls to FormatText (and the functions built on top of it)
+	MetricEscapeBytes                    // bytes of escape sequences added to the formatted text
+	MetricDowngrades                     // colors approximated to the Xterm palette or the 16 ANSI colors
+	MetricWidthCacheHits                 // width measurements served by the width cache
+	MetricWidthCacheMisses               // width measurements computed and added to the width cache
+
+	metricCount // number of metrics (keep last)
+)
+
+/*
+The MetricsSink interface receives the instrumentation of the package.
+
+Record is called synchronously from the formatting functions, so implementations must be safe for
+concurrent use and should return quickly.
+*/
+type MetricsSink interface {
+	Record(m Metric, n int)
+}
+
+/* metricsSink is the sink set by SetMetricsSink (nil disables instrumentation) */
+var metricsSink MetricsSink
+
+/*
+SetMetricsSink sets the sink receiving the instrumentation of the package.
+
+Instrumentation is disabled by default. Passing nil disables it again.
+The sink should be set once at program start, before any formatting takes place.
+
+Parameters:
+  - sink: The MetricsSink receiving the metrics, or nil.
+
+Example:
+
+	counters := &c.Counters{}
+	c.SetMetricsSink(counters)
+
+	// ... format some text ...
+
+	fmt.Println("escape overhead:", counters.Get(c.MetricEscapeBytes), "bytes")
+*/
+func SetMetricsSink(sink MetricsSink) {
+	metricsSink = sink
+}
+
+/*
+record reports n units of the given metric to the metrics sink, if any.
+
+Parameters:
+  - m: The metric being reported.
+  - n: The amount to report.
+*/
+func record(m Metric, n int) {
+	if metricsSink != nil {
+		metricsSink.Record(m, n)
+	}
+}
+
+/*
+The Counters type is a ready-to-use MetricsSink that accumulates every metric in memory.
+
+The zero value is ready to use and safe for concurrent use.
+*/
+type Counters struct {
+	values [metricCount]atomic.Int64
+}
+
+/*
+Record adds n to the counter of the given metric. Unknown metrics are ignored.
+
+Parameters:
+  - m: The metric being reported.
+  - n: The amount to add.
+*/
+func (c *Counters) Record(m Metric, n int) {
+	if m < 0 || int(m) >= len(c.values) {
+		return
+	}
+	c.values[m].Add(int64(n))
+}
+
+/*
+Get returns the accumulated value of the given metric.
+
+Parameters:
+  - m: The metric to read.
+
+Return:
+  - int64: The accumulated value, or 0 for unknown metrics.
+*/
+func (c *Counters) Get(m Metric) int64 {
+	if m < 0 || int(m) >= len(c.values) {
+		return 0
+	}
+	return c.values[m].Load()
+}
+
+/* Reset sets every counter back to zero */
+func (c *Counters) Reset() {
+	for i := range c.values {
+		c.values[i].Store(0)
+	}
+}
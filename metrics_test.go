@@ -0,0 +1,56 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestSetMetricsSink tests the SetMetricsSink function and the Counters sink */
+func TestSetMetricsSink(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer SetMetricsSink(nil)
+
+	counters := &Counters{}
+	SetMetricsSink(counters)
+
+	// true color support
+	trueColor = true
+	text, _ := FormatText("test", &Options{FgColor: "#FF0000", Styles: []string{"bold"}})
+	if counters.Get(MetricFormatCalls) != 1 {
+		t.Errorf("Expected 1 formatted call but got %d", counters.Get(MetricFormatCalls))
+	}
+	if counters.Get(MetricEscapeBytes) != int64(len(text)-len("test")) {
+		t.Errorf("Expected %d escape bytes but got %d", len(text)-len("test"), counters.Get(MetricEscapeBytes))
+	}
+	if counters.Get(MetricDowngrades) != 0 {
+		t.Error("Expected no downgrades with true color support")
+	}
+
+	// xterm support
+	trueColor = false
+	xTerm = true
+	_, _ = FormatText("test", &Options{FgColor: "#FF0000", BgColor: "#0000FF"})
+	_, _ = GetColor("#FF0000", foreground)
+	if counters.Get(MetricDowngrades) != 3 {
+		t.Errorf("Expected 3 downgrades but got %d", counters.Get(MetricDowngrades))
+	}
+
+	// reset
+	counters.Reset()
+	if counters.Get(MetricFormatCalls) != 0 {
+		t.Error("Expected the counters to be reset")
+	}
+
+	// unknown metric
+	counters.Record(Metric(-1), 1)
+	if counters.Get(Metric(100)) != 0 {
+		t.Error("Expected unknown metrics to be ignored")
+	}
+
+	// disabled sink
+	SetMetricsSink(nil)
+	_, _ = FormatText("test", &Options{FgColor: "#FF0000"})
+	if counters.Get(MetricFormatCalls) != 0 {
+		t.Error("Expected no metrics to be recorded once the sink is removed")
+	}
+}
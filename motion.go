@@ -0,0 +1,60 @@
+package colorize
+
+import (
+	"os"
+)
+
+/* The MotionPolicy type represents how much motion the animated effects may use */
+type MotionPolicy int
+
+const (
+	/* Supported motion policies */
+	MotionFull    MotionPolicy = iota // effects are fully animated
+	MotionReduced                     // effects are toned down (e.g., subtler pulses, no typewriter)
+	MotionNone                        // effects fall back to static output
+)
+
+/* motionPolicy is the policy set by SetMotionPolicy (detected from the environment by default) */
+var motionPolicy = detectMotionPolicy()
+
+/*
+detectMotionPolicy detects the motion policy from common environment hints:
+  - CI (set by most CI services) or TERM=dumb: MotionNone.
+  - REDUCE_MOTION or REDUCED_MOTION set to a value other than "0" or "false": MotionReduced.
+
+Return:
+  - MotionPolicy: The detected policy, MotionFull if there are no hints.
+*/
+func detectMotionPolicy() MotionPolicy {
+	if os.Getenv("CI") != "" || os.Getenv("TERM") == "dumb" {
+		return MotionNone
+	}
+	for _, name := range []string{"REDUCE_MOTION", "REDUCED_MOTION"} {
+		if value := os.Getenv(name); value != "" && value != "0" && value != "false" {
+			return MotionReduced
+		}
+	}
+	return MotionFull
+}
+
+/*
+SetMotionPolicy sets how much motion the animated effects (Typewriter, Pulse) may use, for
+accessibility and CI-friendly output. It defaults to the policy detected from the environment
+(see detectMotionPolicy).
+
+  - MotionFull: effects are fully animated.
+  - MotionReduced: Pulse uses a subtler fade and Typewriter writes the text at once.
+  - MotionNone: Pulse returns a single static frame and Typewriter writes the text at once.
+
+Parameters:
+  - policy: The motion policy.
+
+Example:
+
+	if *noAnimations {
+		c.SetMotionPolicy(c.MotionNone)
+	}
+*/
+func SetMotionPolicy(policy MotionPolicy) {
+	motionPolicy = policy
+}
@@ -0,0 +1,28 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestDetectMotionPolicy tests the detectMotionPolicy function */
+func TestDetectMotionPolicy(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		expected MotionPolicy
+	}{
+		{map[string]string{}, MotionFull},
+		{map[string]string{"CI": "true"}, MotionNone},
+		{map[string]string{"TERM": "dumb"}, MotionNone},
+		{map[string]string{"REDUCE_MOTION": "1"}, MotionReduced},
+		{map[string]string{"REDUCED_MOTION": "yes"}, MotionReduced},
+		{map[string]string{"REDUCE_MOTION": "false"}, MotionFull},
+	}
+	for _, test := range tests {
+		for _, name := range []string{"CI", "TERM", "REDUCE_MOTION", "REDUCED_MOTION"} {
+			t.Setenv(name, test.env[name])
+		}
+		if policy := detectMotionPolicy(); policy != test.expected {
+			t.Errorf("%v: expected %d but got %d", test.env, test.expected, policy)
+		}
+	}
+}
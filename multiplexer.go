@@ -0,0 +1,122 @@
+package colorize
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maximum time spent querying the options of tmux
+const tmuxQueryTimeout = 500 * time.Millisecond
+
+var (
+	// whether tmux passes true color through, queried once by DetectTmuxTrueColor
+	tmuxPassthrough     = false
+	tmuxPassthroughOnce = sync.Once{}
+)
+
+// runs a tmux command and returns its output (a variable so tests can replace it)
+var runTmux = func(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tmuxQueryTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "tmux", args...).Output()
+	return string(out), err
+}
+
+/*
+detectMultiplexer detects the terminal multiplexer the program runs in, from the TMUX and STY
+environment variables or, failing that, TERM.
+
+Return:
+  - string: "tmux", "screen", or an empty string outside of a multiplexer.
+*/
+func detectMultiplexer() string {
+	term := os.Getenv("TERM")
+	switch {
+	case os.Getenv("TMUX") != "" || strings.HasPrefix(term, "tmux"):
+		return "tmux"
+	case os.Getenv("STY") != "" || strings.HasPrefix(term, "screen"):
+		return "screen"
+	}
+	return ""
+}
+
+/*
+tmuxTrueColor reports whether tmux passes true color through to the outer terminal: the
+terminal-overrides or terminal-features options of the server advertise the Tc or RGB capability.
+
+Return:
+  - bool: true if true color is passed through, false otherwise (or if tmux can't be queried).
+*/
+func tmuxTrueColor() bool {
+	for _, option := range []string{"terminal-overrides", "terminal-features"} {
+		out, err := runTmux("show-options", "-gsv", option)
+		if err != nil {
+			continue
+		}
+		for _, entry := range strings.Fields(out) {
+			for _, capability := range strings.Split(entry, ":")[1:] {
+				if capability == "Tc" || capability == "RGB" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+/*
+multiplexerLevel returns the color level available in a terminal multiplexer, which often strips
+COLORTERM even though the outer terminal supports true color. tmux always accepts the Xterm
+palette (its true color passthrough is only queried on request, see DetectTmuxTrueColor). GNU
+screen is classified by TERM.
+
+Parameters:
+  - multiplexer: The multiplexer ("tmux" or "screen").
+  - term: The name of the terminal (TERM).
+
+Return:
+  - Level: The color level.
+  - string: The reason of the level.
+*/
+func multiplexerLevel(multiplexer string, term string) (Level, string) {
+	level := termLevel(term)
+	if multiplexer != "tmux" {
+		return level, fmt.Sprintf("screen with TERM=%q", term)
+	}
+	return max(level, LevelAnsi256), fmt.Sprintf("tmux with TERM=%q", term)
+}
+
+/*
+DetectTmuxTrueColor queries the tmux server the program runs in for true color passthrough (see
+tmuxTrueColor) and, when it's enabled, upgrades the color level to true color. The color level is
+left untouched when it was set with SetColorLevel or colors are disabled.
+
+The server is queried on the first call only (which takes up to a second if it doesn't answer):
+the package never runs tmux by itself, so that importing it has no side effects.
+
+Return:
+  - bool: true if the program runs in tmux and true color is passed through, false otherwise.
+
+Example:
+
+	c.DetectTmuxTrueColor()
+	fmt.Println(c.ColorLevel()) // truecolor
+*/
+func DetectTmuxTrueColor() bool {
+	if detectMultiplexer() != "tmux" {
+		return false
+	}
+	tmuxPassthroughOnce.Do(func() {
+		tmuxPassthrough = tmuxTrueColor()
+	})
+	if tmuxPassthrough && !levelOverride && !noColor && termColorLevel < LevelTrueColor {
+		termColorLevel, termSource = LevelTrueColor, "tmux with RGB passthrough"
+		trueColor, xTerm, ansi16 = true, true, true
+	}
+	return tmuxPassthrough
+}
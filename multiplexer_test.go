@@ -0,0 +1,129 @@
+package colorize
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+/* TestDetectMultiplexer tests the detectMultiplexer function */
+func TestDetectMultiplexer(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		expected string
+	}{
+		{map[string]string{"TMUX": "/tmp/tmux-1000/default,1234,0", "TERM": "screen-256color"}, "tmux"},
+		{map[string]string{"TERM": "tmux-256color"}, "tmux"},
+		{map[string]string{"STY": "1234.pts-0.host", "TERM": "screen"}, "screen"},
+		{map[string]string{"TERM": "screen.xterm-256color"}, "screen"},
+		{map[string]string{"TERM": "xterm-256color"}, ""},
+	}
+	for _, test := range tests {
+		for _, name := range []string{"TMUX", "STY", "TERM"} {
+			t.Setenv(name, test.env[name])
+		}
+		if got := detectMultiplexer(); got != test.expected {
+			t.Errorf("%v: expected %q but got %q", test.env, test.expected, got)
+		}
+	}
+}
+
+/* TestMultiplexerLevel tests the multiplexerLevel function, which never runs tmux */
+func TestMultiplexerLevel(t *testing.T) {
+	defer func(prev func(...string) (string, error)) { runTmux = prev }(runTmux)
+	runTmux = func(args ...string) (string, error) {
+		t.Error("Expected tmux not to be queried during detection")
+		return "", errors.New("unexpected")
+	}
+
+	// the Xterm palette is always available in tmux
+	if level, reason := multiplexerLevel("tmux", "screen"); level != LevelAnsi256 || reason != `tmux with TERM="screen"` {
+		t.Errorf("Unexpected level %s (%s)", level, reason)
+	}
+	if level, _ := multiplexerLevel("tmux", "tmux-direct"); level != LevelTrueColor {
+		t.Errorf("Expected level truecolor but got %s", level)
+	}
+
+	// screen is classified by TERM
+	if level, _ := multiplexerLevel("screen", "screen-256color"); level != LevelAnsi256 {
+		t.Errorf("Expected level ansi256 but got %s", level)
+	}
+}
+
+/* TestTmuxTrueColor tests the tmuxTrueColor function, with a fake tmux */
+func TestTmuxTrueColor(t *testing.T) {
+	defer func(prev func(...string) (string, error)) { runTmux = prev }(runTmux)
+
+	options := map[string]string{}
+	runTmux = func(args ...string) (string, error) {
+		out, ok := options[args[len(args)-1]]
+		if !ok {
+			return "", errors.New("no server running")
+		}
+		return out, nil
+	}
+
+	// tmux can't be queried
+	if tmuxTrueColor() {
+		t.Error("Expected no passthrough without a server")
+	}
+
+	// no passthrough
+	options["terminal-overrides"] = "linux*:AX@\n"
+	if tmuxTrueColor() {
+		t.Error("Expected no passthrough")
+	}
+
+	// passthrough through terminal-overrides (Tc) or terminal-features (RGB)
+	options["terminal-overrides"] = "xterm-256color:Tc\n"
+	if !tmuxTrueColor() {
+		t.Error("Expected a Tc passthrough")
+	}
+	delete(options, "terminal-overrides")
+	options["terminal-features"] = "xterm*:clipboard:ccolour:cstyle:focus:title\nalacritty:RGB\n"
+	if !tmuxTrueColor() {
+		t.Error("Expected an RGB passthrough")
+	}
+}
+
+/* TestDetectTmuxTrueColor tests the DetectTmuxTrueColor function, with a fake tmux */
+func TestDetectTmuxTrueColor(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer func(prev func(...string) (string, error)) { runTmux = prev }(runTmux)
+	defer func(level Level, source string) { termColorLevel, termSource = level, source }(termColorLevel, termSource)
+	trueColor, xTerm, ansi16 = false, true, true
+	termColorLevel = LevelAnsi256
+
+	queries := 0
+	runTmux = func(args ...string) (string, error) {
+		queries++
+		return "xterm-256color:Tc\n", nil
+	}
+
+	// outside of tmux, nothing is queried
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+	if DetectTmuxTrueColor() || queries != 0 {
+		t.Error("Expected tmux not to be queried outside of tmux")
+	}
+
+	// inside tmux, the server is queried once and the level upgraded
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	tmuxPassthroughOnce = sync.Once{}
+	if !DetectTmuxTrueColor() || !DetectTmuxTrueColor() || queries != 1 {
+		t.Errorf("Expected the server to be queried once but got %d queries", queries)
+	}
+	if ColorLevel() != LevelTrueColor || termSource != "tmux with RGB passthrough" {
+		t.Errorf("Expected level truecolor but got %s (%s)", ColorLevel(), termSource)
+	}
+
+	// a level set by SetColorLevel is left untouched
+	SetColorLevel(LevelAnsi256)
+	termColorLevel = LevelAnsi256
+	DetectTmuxTrueColor()
+	if ColorLevel() != LevelAnsi256 {
+		t.Errorf("Expected level ansi256 but got %s", ColorLevel())
+	}
+	tmuxPassthroughOnce = sync.Once{}
+}
@@ -0,0 +1,58 @@
+package colorize
+
+import "fmt"
+
+/*
+MustGetColor is like GetColor but panics if the color is invalid, for package-level variables
+initialized with color literals. A missing system color support is not an error: the code is then
+empty, as GetColor returns it.
+
+Parameters:
+  - hex: The color (e.g., "#RRGGBB").
+  - ctx: The color context (background or foreground).
+
+Return:
+  - string: The ANSI escape code, or an empty string if the system does not support colors.
+
+Example:
+
+	var red = c.MustGetColor("#FF0000", "foreground")
+*/
+func MustGetColor(hex string, ctx ColorContext) string {
+	if _, err := getColor(hex); err != nil {
+		panic(fmt.Sprintf("colorize: MustGetColor(%q): %v", hex, err))
+	}
+	code, _ := GetColor(hex, ctx)
+	return code
+}
+
+/*
+MustFormatText is like FormatText but panics if a color of the options is invalid, for
+package-level variables initialized with color literals. Other errors (e.g., a missing system
+color support) are not: the text is then returned unformatted, as FormatText returns it.
+
+Parameters:
+  - text: The text to be formatted.
+  - options: The formatting options.
+
+Return:
+  - string: The formatted text.
+
+Example:
+
+	var banner = c.MustFormatText("myapp", &c.Options{FgColor: "#5FD7FF", Styles: []string{"bold"}})
+*/
+func MustFormatText(text string, options *Options) string {
+	if options != nil {
+		for _, hex := range []string{options.FgColor, options.BgColor} {
+			if hex == "" {
+				continue
+			}
+			if _, err := getColor(hex); err != nil {
+				panic(fmt.Sprintf("colorize: MustFormatText: %v", err))
+			}
+		}
+	}
+	formatted, _ := FormatText(text, options)
+	return formatted
+}
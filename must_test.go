@@ -0,0 +1,56 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* expectPanic fails the test if fn does not panic */
+func expectPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: expected a panic", name)
+		}
+	}()
+	fn()
+}
+
+/* TestMustGetColor tests the MustGetColor function */
+func TestMustGetColor(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	expected, _ := GetColor("#FF0000", foreground)
+	if got := MustGetColor("#FF0000", foreground); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+	expectPanic(t, "invalid hex", func() { MustGetColor("#GG0000", foreground) })
+
+	// no color support is not an error
+	trueColor, xTerm, ansi16 = false, false, false
+	if got := MustGetColor("#FF0000", foreground); got != "" {
+		t.Errorf("Expected an empty code but got %q", got)
+	}
+}
+
+/* TestMustFormatText tests the MustFormatText function */
+func TestMustFormatText(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	opts := &Options{FgColor: "#FF0000", Styles: []string{"bold"}}
+	expected, _ := FormatText("text", opts)
+	if got := MustFormatText("text", opts); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+	expectPanic(t, "invalid foreground", func() { MustFormatText("text", &Options{FgColor: "red"}) })
+	expectPanic(t, "invalid background", func() { MustFormatText("text", &Options{BgColor: "#12345"}) })
+
+	// no color support is not an error
+	trueColor, xTerm, ansi16 = false, false, false
+	if got := MustFormatText("text", opts); got != "text" {
+		t.Errorf("Expected %q but got %q", "text", got)
+	}
+}
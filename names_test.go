@@ -0,0 +1,30 @@
+package colorize
+
+import (
+	"math"
+	"testing"
+)
+
+/* TestNameOf tests the NameOf function */
+func TestNameOf(t *testing.T) {
+	tests := []struct {
+		hex  string
+		name string
+		dist float64
+	}{
+		{"#FF0000", "red", 0},
+		{"#0ff", "cyan", 0},
+		{"#4A80B0", "steelblue", math.Sqrt(4*4 + 2*2 + 4*4)},
+		{"hsl(0, 0%, 50%)", "gray", 0},
+	}
+	for _, test := range tests {
+		name, dist := NameOf(test.hex)
+		if name != test.name || math.Abs(dist-test.dist) > 1e-9 {
+			t.Errorf("%s: expected %s (%.2f) but got %s (%.2f)", test.hex, test.name, test.dist, name, dist)
+		}
+	}
+
+	if name, dist := NameOf("#FF00"); name != "" || dist != -1 {
+		t.Errorf("Expected no name for an invalid color but got %s (%.2f)", name, dist)
+	}
+}
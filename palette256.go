@@ -0,0 +1,78 @@
+package colorize
+
+import "fmt"
+
+// levels of the 6x6x6 color cube of the Xterm palette
+var xtermCubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+/*
+Index256 returns a pointer to the given Xterm palette index, to be used in the FgColor256 and
+BgColor256 fields of Options.
+
+Parameters:
+  - index: The index in the Xterm palette (0-255).
+
+Return:
+  - *uint8: A pointer to the index.
+
+Example:
+
+	text, err := c.FormatText("Hello, world!", &c.Options{FgColor256: c.Index256(202)})
+*/
+func Index256(index uint8) *uint8 {
+	return &index
+}
+
+/*
+xtermToRGB converts an Xterm palette index to RGB representation, using the default colors of
+Xterm.
+
+Parameters:
+  - index: The index in the Xterm palette.
+
+Return:
+  - *color: A pointer to the color struct representing the RGB color.
+*/
+func xtermToRGB(index uint8) *color {
+	switch {
+	case index < colorOffset:
+		col := ansi16Palette[index]
+		return &col
+	case index < grayOffset:
+		i := index - colorOffset
+		return &color{xtermCubeLevels[i/colorFactor1], xtermCubeLevels[i/colorFactor2%6], xtermCubeLevels[i%6]}
+	}
+	gray := 8 + (index-grayOffset)*10
+	return &color{gray, gray, gray}
+}
+
+/*
+getIndexCode returns the ANSI escape code for setting an Xterm palette index at the given color
+level. The index is used as is by true color and Xterm terminals, and approximated to the 16 basic
+colors otherwise.
+
+Parameters:
+  - index: The index in the Xterm palette.
+  - ctx: The color context (background or foreground).
+  - level: The color level (LevelTrueColor, LevelAnsi256 or LevelAnsi16).
+
+Return:
+  - string: The ANSI escape code.
+  - *Warning: The downgrade to the 16 basic colors, or nil.
+*/
+func getIndexCode(index uint8, ctx ColorContext, level Level) (string, *Warning) {
+	if level >= LevelAnsi256 {
+		if ctx == background {
+			return fmt.Sprintf("%s%dm", bgXterm, index), nil
+		}
+		return fmt.Sprintf("%s%dm", fgXterm, index), nil
+	}
+
+	col := xtermToRGB(index)
+	if index < colorOffset {
+		return getAnsi16Code(col, ctx), nil
+	}
+	record(MetricDowngrades, 1)
+	warning := newWarning("DOWNGRADE", fmt.Sprintf("%s color xterm %d approximated to ansi %d", ctx, index, rgbToAnsi16(col)))
+	return getAnsi16Code(col, ctx), &warning
+}
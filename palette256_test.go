@@ -0,0 +1,59 @@
+package colorize
+
+import (
+	"errors"
+	"testing"
+)
+
+/* TestXtermToRGB tests the xtermToRGB function */
+func TestXtermToRGB(t *testing.T) {
+	tests := map[uint8]color{
+		1:   ansi16Palette[1],
+		16:  {0, 0, 0},
+		202: {255, 95, 0},
+		231: {255, 255, 255},
+		232: {8, 8, 8},
+		255: {238, 238, 238},
+	}
+	for index, expected := range tests {
+		if col := xtermToRGB(index); *col != expected {
+			t.Errorf("%d: expected %v but got %v", index, expected, *col)
+		}
+	}
+
+}
+
+/* TestColor256 tests the FgColor256 and BgColor256 options */
+func TestColor256(t *testing.T) {
+	// defer restore
+	defer restore()
+
+	opts := &Options{FgColor: "#FFFFFF", FgColor256: Index256(202), BgColor256: Index256(0)}
+
+	// the index is used as is, overriding FgColor
+	for _, profile := range benchProfiles[:2] {
+		trueColor, xTerm, ansi16 = profile.trueColor, profile.xTerm, profile.ansi16
+		text, err := FormatText("text", opts)
+		if expected := "\033[48;5;0m\033[38;5;202mtext" + reset; err != nil || text != expected {
+			t.Errorf("%s: expected %q but got %q, %v", profile.name, expected, text, err)
+		}
+	}
+
+	exp := Explain("text", opts)
+	if len(exp.Colors) != 2 || exp.Colors[1].Input != "xterm 202" || exp.Colors[1].Resolved != "#FF5F00" || exp.Colors[1].Downgraded {
+		t.Errorf("Expected the index to be explained but got %+v", exp.Colors)
+	}
+
+	// 16 colors: approximated
+	trueColor, xTerm, ansi16 = false, false, true
+	text, err := FormatText("text", opts)
+	if expected := "\033[40m\033[91mtext" + reset; err != nil || text != expected {
+		t.Errorf("Expected %q but got %q, %v", expected, text, err)
+	}
+	SetStrictMode(true)
+	defer SetStrictMode(false)
+	var warnings Warnings
+	if _, err = FormatText("text", opts); !errors.As(err, &warnings) || len(warnings) != 1 {
+		t.Errorf("Expected a downgrade warning but got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package colorize
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	// default LS_COLORS entries (as defined by GNU dircolors) used when LS_COLORS is not set
+	defaultLSColors = "di=01;34:ln=01;36:pi=40;33:so=01;35:do=01;35:bd=40;33;01:cd=40;33;01:or=40;31;01:su=37;41:sg=30;43:tw=30;42:ow=34;42:st=37;44:ex=01;32"
+
+	// parsed LS_COLORS cache, refreshed whenever the environment variable changes
+	lsColorsMu    sync.Mutex
+	lsColorsRaw   string
+	lsColorsCache map[string]string
+)
+
+/*
+parseLSColors parses a LS_COLORS value ("key=value" entries separated by colons) into a map from
+file type keys (e.g., "di", "ln") and extension globs (e.g., "*.go") to SGR parameters.
+
+Parameters:
+  - raw: The LS_COLORS value.
+
+Return:
+  - map[string]string: The parsed entries.
+*/
+func parseLSColors(raw string) map[string]string {
+	entries := map[string]string{}
+	for _, entry := range strings.Split(raw, ":") {
+		key, value, found := strings.Cut(entry, "=")
+		if !found || key == "" || value == "" {
+			continue
+		}
+		entries[key] = value
+	}
+	return entries
+}
+
+/*
+getLSColors returns the parsed LS_COLORS entries, falling back to the GNU defaults when the
+environment variable is not set.
+
+Return:
+  - map[string]string: The parsed entries.
+*/
+func getLSColors() map[string]string {
+	raw, ok := os.LookupEnv("LS_COLORS")
+	if !ok {
+		raw = defaultLSColors
+	}
+
+	lsColorsMu.Lock()
+	defer lsColorsMu.Unlock()
+
+	if lsColorsCache == nil || raw != lsColorsRaw {
+		lsColorsRaw = raw
+		lsColorsCache = parseLSColors(raw)
+	}
+	return lsColorsCache
+}
+
+/*
+lsColorsCode returns the SGR parameters LS_COLORS assigns to a file, following the same precedence
+as GNU ls: file type first, then extension for regular files.
+
+Parameters:
+  - path: The path of the file.
+  - info: The file info (from os.Lstat) or nil if the file does not exist.
+  - entries: The parsed LS_COLORS entries.
+
+Return:
+  - string: The SGR parameters, or an empty string if none applies.
+*/
+func lsColorsCode(path string, info fs.FileInfo, entries map[string]string) string {
+	if info == nil {
+		return entries["mi"]
+	}
+
+	mode := info.Mode()
+	switch {
+	case mode&fs.ModeSymlink != 0:
+		if _, err := os.Stat(path); err != nil && entries["or"] != "" {
+			return entries["or"]
+		}
+		return entries["ln"]
+	case mode.IsDir():
+		sticky := mode&fs.ModeSticky != 0
+		otherWritable := mode.Perm()&0o002 != 0
+		if sticky && otherWritable && entries["tw"] != "" {
+			return entries["tw"]
+		}
+		if otherWritable && entries["ow"] != "" {
+			return entries["ow"]
+		}
+		if sticky && entries["st"] != "" {
+			return entries["st"]
+		}
+		return entries["di"]
+	case mode&fs.ModeNamedPipe != 0:
+		return entries["pi"]
+	case mode&fs.ModeSocket != 0:
+		return entries["so"]
+	case mode&fs.ModeCharDevice != 0:
+		return entries["cd"]
+	case mode&fs.ModeDevice != 0:
+		return entries["bd"]
+	case mode&fs.ModeSetuid != 0 && entries["su"] != "":
+		return entries["su"]
+	case mode&fs.ModeSetgid != 0 && entries["sg"] != "":
+		return entries["sg"]
+	case mode.Perm()&0o111 != 0 && entries["ex"] != "":
+		return entries["ex"]
+	}
+
+	// extensions: exact match first, then case-insensitive
+	name := filepath.Base(path)
+	for i := 0; i < len(name); i++ {
+		if name[i] != '.' {
+			continue
+		}
+		if code, ok := entries["*"+name[i:]]; ok {
+			return code
+		}
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] != '.' {
+			continue
+		}
+		for key, code := range entries {
+			if strings.HasPrefix(key, "*") && strings.EqualFold(key[1:], name[i:]) {
+				return code
+			}
+		}
+	}
+
+	return entries["fi"]
+}
+
+/*
+ColorizePath formats the given path the way ls does, honoring the user's LS_COLORS environment
+variable (or the GNU dircolors defaults when it's not set), so that file-listing tools match the
+user's existing ls color configuration.
+
+If info is nil, the file is looked up with os.Lstat. If the system does not support colors, or no
+LS_COLORS entry applies to the file, the path is returned unmodified.
+
+Parameters:
+  - path: The path of the file.
+  - info: The file info, as returned by os.Lstat or fs.DirEntry.Info, or nil.
+
+Return:
+  - string: The formatted path.
+
+Example:
+
+	entries, _ := os.ReadDir(".")
+	for _, entry := range entries {
+		info, _ := entry.Info()
+		fmt.Println(c.ColorizePath(entry.Name(), info))
+	}
+*/
+func ColorizePath(path string, info fs.FileInfo) string {
+	if !ColorsEnabled() {
+		return path
+	}
+
+	if info == nil {
+		info, _ = os.Lstat(path)
+	}
+
+	code := lsColorsCode(path, info, getLSColors())
+	if code == "" || code == "0" || code == "00" {
+		return path
+	}
+
+	return "\033[" + code + "m" + path + reset
+}
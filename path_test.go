@@ -0,0 +1,82 @@
+package colorize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/* TestParseLSColors tests the parseLSColors function */
+func TestParseLSColors(t *testing.T) {
+	entries := parseLSColors("di=01;34:*.go=00;36::invalid:ex=:ln=01;36")
+	if len(entries) != 3 {
+		t.Errorf("Expected 3 entries but got %d", len(entries))
+	}
+	if entries["di"] != "01;34" || entries["*.go"] != "00;36" {
+		t.Error("Expected the entries to be parsed")
+	}
+}
+
+/* TestColorizePath tests the ColorizePath function */
+func TestColorizePath(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	t.Setenv("LS_COLORS", "di=01;34:ex=01;32:*.go=00;36:*.TXT=00;33:fi=0:mi=01;31")
+
+	dir := t.TempDir()
+	goFile := filepath.Join(dir, "main.go")
+	txtFile := filepath.Join(dir, "notes.txt")
+	exeFile := filepath.Join(dir, "run")
+	plainFile := filepath.Join(dir, "plain")
+	for _, f := range []string{goFile, txtFile, plainFile} {
+		_ = os.WriteFile(f, []byte{}, 0o644)
+	}
+	_ = os.WriteFile(exeFile, []byte{}, 0o755)
+
+	cases := map[string]string{
+		dir:       "\033[01;34m" + dir + reset,
+		goFile:    "\033[00;36m" + goFile + reset,
+		txtFile:   "\033[00;33m" + txtFile + reset,
+		plainFile: plainFile,
+	}
+	for path, want := range cases {
+		if got := ColorizePath(path, nil); got != want {
+			t.Errorf("Expected %q but got %q", want, got)
+		}
+	}
+
+	// file info provided
+	info, _ := os.Lstat(goFile)
+	if ColorizePath("main.go", info) != "\033[00;36mmain.go"+reset {
+		t.Error("Expected the provided file info to be used")
+	}
+
+	// executables (on systems with permission bits)
+	if info, _ := os.Lstat(exeFile); info.Mode().Perm()&0o111 != 0 {
+		if ColorizePath(exeFile, nil) != "\033[01;32m"+exeFile+reset {
+			t.Error("Expected the executable to be colored")
+		}
+	}
+
+	// missing file
+	missing := filepath.Join(dir, "missing")
+	if ColorizePath(missing, nil) != "\033[01;31m"+missing+reset {
+		t.Error("Expected the missing file to be colored")
+	}
+
+	// default colors
+	os.Unsetenv("LS_COLORS")
+	if ColorizePath(dir, nil) != "\033[01;34m"+dir+reset {
+		t.Error("Expected the default colors to be used")
+	}
+
+	// no color support
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+	if ColorizePath(dir, nil) != dir {
+		t.Error("Expected the path to be returned unmodified")
+	}
+}
@@ -0,0 +1,254 @@
+package colorize
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+/*
+The SyncWriter type serializes the writes of multiple goroutines to the same writer, so that
+concurrent workers never interleave partial lines or escape sequences.
+
+Every call to Write is atomic. Use WriteBlock to write several chunks atomically.
+*/
+type SyncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+/*
+NewSyncWriter returns a SyncWriter wrapping the given writer. If the writer already is a
+SyncWriter, it's returned as is.
+
+Parameters:
+  - w: The writer to wrap.
+
+Return:
+  - *SyncWriter: The synchronized writer.
+*/
+func NewSyncWriter(w io.Writer) *SyncWriter {
+	if sw, ok := w.(*SyncWriter); ok {
+		return sw
+	}
+	return &SyncWriter{w: w}
+}
+
+/*
+Write writes p to the underlying writer while holding the lock.
+
+Parameters:
+  - p: The bytes to write.
+
+Return:
+  - int: The number of bytes written.
+  - error: The error returned by the underlying writer, if any.
+*/
+func (s *SyncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+/*
+WriteBlock calls fn with the underlying writer while holding the lock, so that a multi-line block
+is written without interleaving with other goroutines. The writer must not be used after fn returns.
+
+Parameters:
+  - fn: The function writing the block.
+
+Return:
+  - error: The error returned by fn.
+
+Example:
+
+	sw.WriteBlock(func(w io.Writer) error {
+		fmt.Fprintln(w, header)
+		fmt.Fprintln(w, body)
+		return nil
+	})
+*/
+func (s *SyncWriter) WriteBlock(fn func(w io.Writer) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.w)
+}
+
+/*
+The Printer type offers the fmt printing helpers with formatting options, bound to a SyncWriter so
+that it can be shared by multiple goroutines.
+
+Each call formats the whole text first and writes it at once. If the text can't be formatted
+(e.g., the system does not support colors), it's written unformatted, and no error is reported:
+only write errors are returned.
+*/
+type Printer struct {
+	w *SyncWriter
+}
+
+/*
+NewPrinter returns a Printer writing to the given writer, which is wrapped in a SyncWriter (unless
+it already is one).
+
+Parameters:
+  - w: The writer to print to.
+
+Return:
+  - *Printer: The newly created printer.
+
+Example:
+
+	p := c.NewPrinter(os.Stdout)
+	for i := 0; i < 4; i++ {
+		go func(i int) {
+			p.Printf(&c.Options{FgColor: "#00FF00"}, "worker %d done\n", i)
+		}(i)
+	}
+*/
+func NewPrinter(w io.Writer) *Printer {
+	return &Printer{w: NewSyncWriter(w)}
+}
+
+/*
+Writer returns the SyncWriter the Printer writes to.
+
+Return:
+  - *SyncWriter: The synchronized writer.
+*/
+func (p *Printer) Writer() *SyncWriter {
+	return p.w
+}
+
+/*
+format formats the given text with the given options, returning the text unmodified if the
+options are nil or formatting fails. Trailing newlines are kept out of the formatted region, so the
+style never leaks into the next line.
+
+Parameters:
+  - text: The text to be formatted.
+  - opts: The formatting options, or nil.
+
+Return:
+  - string: The formatted text.
+*/
+func (p *Printer) format(text string, opts *Options) string {
+	if opts == nil {
+		return text
+	}
+	trimmed := strings.TrimRight(text, "\n")
+	formatted, _ := formatText(trimmed, opts, terminalAllows(p.w.w))
+	return formatted + text[len(trimmed):]
+}
+
+/*
+Print formats its operands like fmt.Print, applies the given options and writes the result.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (p *Printer) Print(opts *Options, a ...any) (int, error) {
+	return io.WriteString(p.w, p.format(fmt.Sprint(a...), opts))
+}
+
+/*
+Printf formats according to a format specifier like fmt.Printf, applies the given options and
+writes the result.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (p *Printer) Printf(opts *Options, format string, a ...any) (int, error) {
+	return io.WriteString(p.w, p.format(fmt.Sprintf(auditFormat(format, a), a...), opts))
+}
+
+/*
+Println formats its operands like fmt.Println, applies the given options and writes the result.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (p *Printer) Println(opts *Options, a ...any) (int, error) {
+	return io.WriteString(p.w, p.format(fmt.Sprintln(a...), opts))
+}
+
+/* stdout is the writer of the package-level printing functions (a variable so tests can replace it) */
+var stdout io.Writer = os.Stdout
+
+/*
+Print formats its operands like fmt.Print, applies the given options and writes the result to the
+standard output, with the color level detected for it as Fprint does (see DetectLevel): when the
+output is redirected to a file or a pipe, the text is written plain unless colors are forced. If the
+text can't be formatted, it's written unformatted.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func Print(opts *Options, a ...any) (int, error) {
+	return Fprint(stdout, opts, a...)
+}
+
+/*
+Printf formats according to a format specifier like fmt.Printf, applies the given options and
+writes the result to the standard output, with the color level detected for it (see Print). If the
+text can't be formatted, it's written unformatted.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+
+Example:
+
+	c.Printf(&c.Options{FgColor: "#00FF00"}, "%d tests passed\n", n)
+*/
+func Printf(opts *Options, format string, a ...any) (int, error) {
+	return Fprintf(stdout, opts, format, a...)
+}
+
+/*
+Println formats its operands like fmt.Println, applies the given options and writes the result to
+the standard output, with the color level detected for it (see Print). The trailing newline is
+written after the reset code.
+
+Parameters:
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+
+Example:
+
+	c.Println(&c.Options{FgColor: "#FF0000", Styles: []string{"bold"}}, "build failed:", err)
+*/
+func Println(opts *Options, a ...any) (int, error) {
+	return Fprintln(stdout, opts, a...)
+}
@@ -0,0 +1,142 @@
+package colorize
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+/* TestNewSyncWriter tests the NewSyncWriter function */
+func TestNewSyncWriter(t *testing.T) {
+	sw := NewSyncWriter(&bytes.Buffer{})
+	if NewSyncWriter(sw) != sw {
+		t.Error("Expected a SyncWriter not to be wrapped twice")
+	}
+}
+
+/* TestSyncWriterConcurrency tests that concurrent blocks are never interleaved */
+func TestSyncWriterConcurrency(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sw := NewSyncWriter(buf)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = sw.WriteBlock(func(w io.Writer) error {
+				for j := 0; j < 3; j++ {
+					fmt.Fprintf(w, "%d\n", i)
+				}
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 60 {
+		t.Fatalf("Expected 60 lines but got %d", len(lines))
+	}
+	for i := 0; i < len(lines); i += 3 {
+		if lines[i] != lines[i+1] || lines[i] != lines[i+2] {
+			t.Fatal("Expected the blocks not to be interleaved")
+		}
+	}
+}
+
+/* TestPrinter tests the Print, Printf and Println methods of the Printer type */
+func TestPrinter(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	buf := &bytes.Buffer{}
+	p := NewPrinter(buf)
+	bold := &Options{Styles: []string{"bold"}}
+
+	// print
+	_, _ = p.Print(bold, "a", 1)
+	if buf.String() != styles["bold"]+"a1"+reset {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// printf, the trailing newline is not formatted
+	buf.Reset()
+	_, _ = p.Printf(bold, "%s=%d\n", "a", 1)
+	if buf.String() != styles["bold"]+"a=1"+reset+"\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// println
+	buf.Reset()
+	n, err := p.Println(bold, "a", 1)
+	if buf.String() != styles["bold"]+"a 1"+reset+"\n" || n != buf.Len() || err != nil {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// nil options
+	buf.Reset()
+	_, _ = p.Println(nil, "plain")
+	if buf.String() != "plain\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// no color support
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+	buf.Reset()
+	_, _ = p.Print(bold, "plain")
+	if buf.String() != "plain" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	if p.Writer() == nil {
+		t.Error("Expected the SyncWriter to be returned")
+	}
+}
+
+/* TestPrintFunctions tests the package-level Print, Printf and Println functions */
+func TestPrintFunctions(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer func(prev func(io.Writer) bool) { writerIsTerminal = prev }(writerIsTerminal)
+	defer func(level int) { forceLevel = level }(forceLevel)
+	defer func(prev io.Writer) { stdout = prev }(stdout)
+	forceLevel = -1
+	trueColor = true
+	noColor = false
+	bold := &Options{Styles: []string{"bold"}}
+
+	buf := &bytes.Buffer{}
+	stdout = buf
+	for _, terminal := range []bool{true, false} {
+		writerIsTerminal = func(w io.Writer) bool { return terminal && w == buf }
+
+		// Print and Fprint to stdout write the same bytes, whether stdout is a terminal or a pipe
+		buf.Reset()
+		_, _ = Print(bold, "a", 1)
+		_, _ = Printf(bold, "%s=%d\n", "a", 1)
+		_, _ = Println(nil, "a", 1)
+		printed := buf.String()
+		buf.Reset()
+		_, _ = Fprint(stdout, bold, "a", 1)
+		_, _ = Fprintf(stdout, bold, "%s=%d\n", "a", 1)
+		_, _ = Fprintln(stdout, nil, "a", 1)
+		if printed != buf.String() {
+			t.Errorf("terminal=%v: expected Print to write %q like Fprint but got %q", terminal, buf.String(), printed)
+		}
+
+		expected := "a1a=1\na 1\n"
+		if terminal {
+			expected = styles["bold"] + "a1" + reset + styles["bold"] + "a=1" + reset + "\na 1\n"
+		}
+		if printed != expected {
+			t.Errorf("terminal=%v: expected %q but got %q", terminal, expected, printed)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package colorize
+
+import (
+	"regexp"
+)
+
+/* The PromptMode type represents the shell an output is embedded in (see RenderPrompt) */
+type PromptMode string
+
+const (
+	/* Supported prompt modes */
+	PromptBash PromptMode = "bash" // wraps escape sequences in \[ and \]
+	PromptZsh  PromptMode = "zsh"  // wraps escape sequences in %{ and %}
+)
+
+var (
+	// regex for runs of consecutive escape sequences
+	escapeRunRegex = regexp.MustCompile(`(?:` + ansiRegex.String() + `)+`)
+
+	// non-printing markers of each prompt mode
+	promptMarkers = map[PromptMode][2]string{
+		PromptBash: {`\[`, `\]`},
+		PromptZsh:  {"%{", "%}"},
+	}
+)
+
+/*
+RenderPrompt wraps every escape sequence of the given text in the non-printing markers of the
+given shell, so that colorized text can be embedded in a prompt (PS1, PROMPT) without corrupting the
+cursor position computed by the shell. Consecutive escape sequences are wrapped together.
+
+Text that has been formatted with Options.PromptMode set doesn't need to be rendered again.
+Unknown modes return the text unmodified.
+
+Parameters:
+  - text: The (already formatted) text.
+  - mode: The prompt mode (PromptBash or PromptZsh).
+
+Return:
+  - string: The text with its escape sequences wrapped.
+
+Example:
+
+	branch := c.GitBranch("main", nil)
+	fmt.Printf("PS1='%s $ '\n", c.RenderPrompt(branch, c.PromptBash))
+*/
+func RenderPrompt(text string, mode PromptMode) string {
+	markers, ok := promptMarkers[mode]
+	if !ok {
+		return text
+	}
+
+	return escapeRunRegex.ReplaceAllStringFunc(text, func(run string) string {
+		return markers[0] + run + markers[1]
+	})
+}
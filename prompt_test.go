@@ -0,0 +1,45 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestRenderPrompt tests the RenderPrompt function */
+func TestRenderPrompt(t *testing.T) {
+	text := styles["bold"] + "\033[38;2;255;0;0m" + "main" + reset
+
+	bash := RenderPrompt(text, PromptBash)
+	if bash != `\[`+styles["bold"]+"\033[38;2;255;0;0m"+`\]main\[`+reset+`\]` {
+		t.Errorf("Unexpected bash prompt: %q", bash)
+	}
+
+	zsh := RenderPrompt(text, PromptZsh)
+	if zsh != "%{"+styles["bold"]+"\033[38;2;255;0;0m"+"%}main%{"+reset+"%}" {
+		t.Errorf("Unexpected zsh prompt: %q", zsh)
+	}
+
+	// unknown mode
+	if RenderPrompt(text, "fish") != text {
+		t.Error("Expected the text to be returned unmodified")
+	}
+
+	// plain text
+	if RenderPrompt("main", PromptBash) != "main" {
+		t.Error("Expected plain text to be returned unmodified")
+	}
+}
+
+/* TestFormatTextPromptMode tests the PromptMode option of FormatText */
+func TestFormatTextPromptMode(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	text, err := FormatText("main", &Options{Styles: []string{"bold"}, PromptMode: PromptBash})
+	if err != nil {
+		t.Error("Expected no error but got", err)
+	}
+	if text != `\[`+styles["bold"]+`\]main\[`+reset+`\]` {
+		t.Errorf("Unexpected prompt: %q", text)
+	}
+}
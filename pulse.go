@@ -0,0 +1,104 @@
+package colorize
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// number of frames of a pulse cycle
+	pulseSteps = 8
+	// how far towards white the color is blended at the peak of a pulse
+	pulseMaxLighten = 0.5
+	// same, with the MotionReduced policy
+	pulseReducedLighten = 0.2
+)
+
+/*
+hex returns the hexadecimal color code of the color.
+
+Return:
+  - string: The hexadecimal color code (e.g., "#RRGGBB").
+*/
+func (col *color) hex() string {
+	return fmt.Sprintf("#%02X%02X%02X", col.r, col.g, col.b)
+}
+
+/*
+mixColor blends a color towards a target color.
+
+Parameters:
+  - col: The color.
+  - target: The target color.
+  - amount: The blend amount, from 0 (col) to 1 (target).
+
+Return:
+  - *color: The blended color.
+*/
+func mixColor(col *color, target color, amount float64) *color {
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*amount))
+	}
+	return &color{mix(col.r, target.r), mix(col.g, target.g), mix(col.b, target.b)}
+}
+
+/*
+Pulse returns the frames of a pulse effect: the text colored with the base color, fading brighter
+and back for the given number of cycles. The frames can be drawn in place (e.g., prefixed with
+"\r") at a fixed interval.
+
+The motion policy (see SetMotionPolicy) is honored: the fade is subtler with MotionReduced, and a
+single frame with the base color is returned with MotionNone.
+
+Parameters:
+  - text: The text to be pulsed.
+  - baseColor: The hexadecimal base color code (e.g., "#RRGGBB").
+  - cycles: The number of cycles (at least 1).
+
+Return:
+  - []string: The frames.
+  - error: An error if the base color is invalid or the text could not be formatted.
+
+Example:
+
+	frames, _ := c.Pulse("Recording", "#CC0000", 3)
+	for _, frame := range frames {
+		fmt.Print("\r" + frame)
+		time.Sleep(60 * time.Millisecond)
+	}
+*/
+func Pulse(text string, baseColor string, cycles int) ([]string, error) {
+	base, err := getColor(baseColor)
+	if err != nil {
+		return nil, err
+	}
+	cycles = max(cycles, 1)
+
+	lighten := pulseMaxLighten
+	switch motionPolicy {
+	case MotionReduced:
+		lighten = pulseReducedLighten
+	case MotionNone:
+		frame, err := FormatText(text, &Options{FgColor: base.hex()})
+		if err != nil {
+			return nil, err
+		}
+		return []string{frame}, nil
+	}
+
+	white := color{255, 255, 255}
+	frames := make([]string, 0, cycles*pulseSteps)
+	for i := 0; i < cycles*pulseSteps; i++ {
+		// eased in and out towards the peak, halfway through the cycle, and back to the base color
+		t := 2 * float64(i%pulseSteps) / pulseSteps
+		amount := lighten * Ease(min(t, 2-t), EaseInOut)
+
+		frame, err := FormatText(text, &Options{FgColor: mixColor(base, white, amount).hex()})
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
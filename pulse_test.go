@@ -0,0 +1,55 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestPulse tests the Pulse function */
+func TestPulse(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	defer SetMotionPolicy(motionPolicy)
+	motionPolicy = MotionFull
+
+	frames, err := Pulse("x", "#000000", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 2*pulseSteps {
+		t.Fatalf("Expected %d frames but got %d", 2*pulseSteps, len(frames))
+	}
+
+	base, _ := ForegroundText("x", "#000000")
+	peak, _ := ForegroundText("x", "#808080")
+	if frames[0] != base || frames[pulseSteps] != base {
+		t.Errorf("Expected cycles to start at the base color: %q", frames[0])
+	}
+	if frames[pulseSteps/2] != peak {
+		t.Errorf("Expected %q at the peak but got %q", peak, frames[pulseSteps/2])
+	}
+	if frames[1] != frames[pulseSteps-1] {
+		t.Error("Expected the fade to be symmetric")
+	}
+
+	// at least one cycle
+	if frames, _ := Pulse("x", "#000000", 0); len(frames) != pulseSteps {
+		t.Errorf("Expected one cycle but got %d frames", len(frames))
+	}
+
+	// motion policies
+	SetMotionPolicy(MotionReduced)
+	frames, _ = Pulse("x", "#000000", 1)
+	if subtle, _ := ForegroundText("x", "#333333"); len(frames) != pulseSteps || frames[pulseSteps/2] != subtle {
+		t.Errorf("Expected a subtler peak but got %q", frames[pulseSteps/2])
+	}
+	SetMotionPolicy(MotionNone)
+	if frames, _ := Pulse("x", "#000000", 3); len(frames) != 1 || frames[0] != base {
+		t.Errorf("Expected a single static frame but got %q", frames)
+	}
+
+	// invalid color
+	if _, err := Pulse("x", "bad", 1); err == nil {
+		t.Error("Expected an error")
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package colorize
+
+// raceEnabled reports whether the tests run with the race detector (see race_test.go)
+const raceEnabled = false
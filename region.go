@@ -0,0 +1,166 @@
+package colorize
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+/*
+The Region type represents a style region of a Printer's stream: the style is opened once by
+Begin and closed once by End, and everything printed in between inherits it.
+
+Resets written inside the region (e.g., by nested formatted texts) are followed by the region
+style, so the rest of the region stays styled.
+*/
+type Region struct {
+	mu    sync.Mutex
+	p     *Printer
+	open  string // escape sequence opening the region style
+	ended bool
+}
+
+/*
+styleCode returns the escape sequence setting the style described by the given options, or an
+empty string if the options are nil or can't be formatted, or escape codes aren't allowed on the
+writer (see SetTerminalOnly).
+
+Parameters:
+  - opts: The formatting options.
+  - w: The writer the sequence is meant for.
+
+Return:
+  - string: The escape sequence.
+*/
+func styleCode(opts *Options, w io.Writer) string {
+	if opts == nil {
+		return ""
+	}
+	plain := *opts
+	plain.PromptMode = ""
+	formatted, err := formatText("", &plain, terminalAllows(w))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(formatted, reset)
+}
+
+/*
+Begin opens a style region on the printer's stream.
+
+Parameters:
+  - opts: The formatting options of the region.
+
+Return:
+  - *Region: The region, to be closed with End. On error, it's still usable.
+  - error: The error returned by the writer when opening the style, if any.
+
+Example:
+
+	region, _ := p.Begin(&c.Options{FgColor: "#808080"})
+	region.Printf("step %d: %s\n", 1, c.StyleText("ok", []string{"bold"}))
+	region.Println("still gray")
+	region.End()
+*/
+func (p *Printer) Begin(opts *Options) (*Region, error) {
+	r := &Region{p: p, open: styleCode(opts, p.w.w)}
+	if r.open == "" {
+		return r, nil
+	}
+	_, err := io.WriteString(p.w, r.open)
+	return r, err
+}
+
+/*
+Write writes p to the printer's stream, restoring the region style after every reset. This makes
+the Region an io.Writer.
+
+Parameters:
+  - p: The bytes to write.
+
+Return:
+  - int: The number of bytes of p written.
+  - error: An error if the region has ended or the write failed.
+*/
+func (r *Region) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ended {
+		return 0, newColorizeErr("REGIONENDED", "write to an ended region")
+	}
+
+	text := string(p)
+	if r.open != "" {
+		text = strings.ReplaceAll(text, reset, reset+r.open)
+	}
+	if _, err := io.WriteString(r.p.w, text); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+/*
+Print formats its operands like fmt.Print and writes the result to the region.
+
+Parameters:
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: An error if the region has ended or the write failed.
+*/
+func (r *Region) Print(a ...any) (int, error) {
+	return io.WriteString(r, fmt.Sprint(a...))
+}
+
+/*
+Printf formats according to a format specifier like fmt.Printf and writes the result to the region.
+
+Parameters:
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: An error if the region has ended or the write failed.
+*/
+func (r *Region) Printf(format string, a ...any) (int, error) {
+	return io.WriteString(r, fmt.Sprintf(auditFormat(format, a), a...))
+}
+
+/*
+Println formats its operands like fmt.Println and writes the result to the region.
+
+Parameters:
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: An error if the region has ended or the write failed.
+*/
+func (r *Region) Println(a ...any) (int, error) {
+	return io.WriteString(r, fmt.Sprintln(a...))
+}
+
+/*
+End closes the region, resetting the style. Calling End more than once has no effect.
+
+Return:
+  - error: The write error, if any.
+*/
+func (r *Region) End() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ended {
+		return nil
+	}
+	r.ended = true
+	if r.open == "" {
+		return nil
+	}
+	_, err := io.WriteString(r.p.w, reset)
+	return err
+}
@@ -0,0 +1,58 @@
+package colorize
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+/* TestRegion tests the Begin method of the Printer type and the Region type */
+func TestRegion(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	buf := &bytes.Buffer{}
+	p := NewPrinter(buf)
+	bold := styles["bold"]
+	red, _ := GetColor("#FF0000", foreground)
+
+	region, err := p.Begin(&Options{Styles: []string{"bold"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested, _ := ForegroundText("red", "#FF0000")
+	region.Printf("a %s b\n", nested)
+	region.Println("c")
+	region.Print("d")
+	if err := region.End(); err != nil {
+		t.Fatal(err)
+	}
+	region.End()
+
+	expected := bold + "a " + red + "red" + reset + bold + " b\nc\nd" + reset
+	if buf.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, buf.String())
+	}
+
+	// writes after End fail
+	if _, err := region.Print("e"); err == nil {
+		t.Error("Expected an error after End")
+	}
+
+	// no style
+	buf.Reset()
+	region, _ = p.Begin(nil)
+	region.Print("plain" + reset)
+	region.End()
+	if buf.String() != "plain"+reset {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// write errors are returned
+	r, w := io.Pipe()
+	r.Close()
+	if region, err := NewPrinter(w).Begin(&Options{Styles: []string{"bold"}}); err == nil || region == nil {
+		t.Error("Expected the write error and a usable region")
+	}
+}
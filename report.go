@@ -0,0 +1,106 @@
+package colorize
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+/* width of the keys of the debug report */
+const reportKeyWidth = 22
+
+/* environment variables included in the debug report */
+var reportEnv = []string{
+	"TERM", "COLORTERM", "TERM_PROGRAM", "TERM_PROGRAM_VERSION", "COLUMNS",
+	"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE", "TMUX", "STY",
+}
+
+/*
+yesNo returns "yes" or "no".
+
+Parameters:
+  - b: The boolean.
+
+Return:
+  - string: "yes" if b is true, "no" otherwise.
+*/
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+/*
+DebugReport returns a report of the environment and the capabilities detected by the package
+(terminal variables, color profile, capabilities, package and Go versions), with styled headings.
+
+It's meant to be pasted into bug reports ("colors look wrong on my terminal"). Use
+DebugReportPlain for a report without escape sequences.
+
+Return:
+  - string: The report.
+
+Example:
+
+	if *debug {
+		fmt.Fprint(os.Stderr, c.DebugReport())
+	}
+*/
+func DebugReport() string {
+	builder := strings.Builder{}
+	heading := func(title string) {
+		builder.WriteString(DefaultTheme.Format("section.title", title) + "\n")
+	}
+	row := func(key string, value string) {
+		builder.WriteString(fmt.Sprintf("  %-*s%s\n", reportKeyWidth, key, value))
+	}
+
+	heading("colorize debug report")
+	row("version", version)
+	row("go", fmt.Sprintf("%s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH))
+
+	heading("environment")
+	for _, name := range reportEnv {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			row(name, "(unset)")
+			continue
+		}
+		row(name, fmt.Sprintf("%q", value))
+	}
+
+	profile, reason := getProfile()
+	heading("profile")
+	row("profile", profile)
+	row("reason", reason)
+
+	fonts := map[FontCapability]string{FontNerd: "nerd", FontUnicode: "unicode", FontASCII: "ascii"}
+	heading("capabilities")
+	row("truecolor", yesNo(trueColor))
+	row("xterm 256", yesNo(xTerm))
+	row("ansi 16", yesNo(ansi16))
+	row("colors enabled", yesNo(ColorsEnabled()))
+	row("hyperlinks", yesNo(HasFeature(FeatureHyperlinks)))
+	row("sixel", yesNo(HasFeature(FeatureSixel)))
+	row("osc52", yesNo(HasFeature(FeatureOSC52)))
+	row("stdout is a terminal", yesNo(IsTerminal(os.Stdout)))
+	row("terminal only", yesNo(terminalOnly))
+	row("icons", fonts[fontCapability])
+	row("strict mode", yesNo(strictMode))
+	row("strict validation", yesNo(strictValidation))
+	row("lenient mode", yesNo(lenientMode))
+
+	return builder.String()
+}
+
+/*
+DebugReportPlain returns the report of DebugReport without escape sequences.
+
+Return:
+  - string: The plain report.
+*/
+func DebugReportPlain() string {
+	return stripANSI(DebugReport())
+}
@@ -0,0 +1,39 @@
+package colorize
+
+import (
+	"strings"
+	"testing"
+)
+
+/* TestDebugReport tests the DebugReport and DebugReportPlain functions */
+func TestDebugReport(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	xTerm = false
+	ansi16 = false
+	t.Setenv("TERM", "xterm-kitty")
+	t.Setenv("COLORTERM", "truecolor")
+
+	report := DebugReport()
+	if !strings.Contains(report, DefaultTheme.Format("section.title", "capabilities")) {
+		t.Error("Expected styled headings")
+	}
+
+	plain := DebugReportPlain()
+	if strings.Contains(plain, "\033") {
+		t.Error("Expected no escape sequences in the plain report")
+	}
+	for _, expected := range []string{
+		"version               " + version,
+		`TERM                  "xterm-kitty"`,
+		"profile               truecolor",
+		"truecolor             yes",
+		"xterm 256             no",
+		"icons                 unicode",
+	} {
+		if !strings.Contains(plain, expected) {
+			t.Errorf("Expected the report to contain %q:\n%s", expected, plain)
+		}
+	}
+}
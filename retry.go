@@ -0,0 +1,89 @@
+package colorize
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+retryAttempt returns the attempt counter of a retry status (e.g., "attempt 2/5").
+
+Parameters:
+  - attempt: The current attempt, starting at 1.
+  - maxAttempts: The maximum number of attempts, or 0 or less if unlimited.
+
+Return:
+  - string: The counter.
+*/
+func retryAttempt(attempt int, maxAttempts int) string {
+	if maxAttempts <= 0 {
+		return fmt.Sprintf(translate("attempt %d"), attempt)
+	}
+	return fmt.Sprintf(translate("attempt %d/%d"), attempt, maxAttempts)
+}
+
+/*
+retryDelay rounds a retry delay for display: to the tenth of a second from one second, to the
+millisecond below.
+
+Parameters:
+  - d: The delay.
+
+Return:
+  - time.Duration: The rounded delay.
+*/
+func retryDelay(d time.Duration) time.Duration {
+	if d >= time.Second {
+		return d.Round(100 * time.Millisecond)
+	}
+	return d.Round(time.Millisecond)
+}
+
+/*
+RetryStatus renders a one-line status for retry loops, styled with the "retry.*" roles of
+DefaultTheme: the attempt counter dimmed, the wait before the next attempt in yellow, the final
+failure in red and a success in green.
+
+The line has no trailing newline, so that it can be updated in place by prefixing it with "\r" and
+the erase line sequence ("\033[2K").
+
+Parameters:
+  - attempt: The current attempt, starting at 1.
+  - maxAttempts: The maximum number of attempts, or 0 or less if unlimited.
+  - nextIn: The delay before the next attempt.
+  - err: The error of the attempt, or nil if it succeeded.
+
+Return:
+  - string: The status line.
+
+Example:
+
+	for attempt := 1; ; attempt++ {
+		err := connect()
+		fmt.Print("\r\033[2K" + c.RetryStatus(attempt, 5, backoff, err))
+		if err == nil || attempt == 5 {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	fmt.Println()
+	// attempt 2/5 failed: connection refused, retrying in 2s
+*/
+func RetryStatus(attempt int, maxAttempts int, nextIn time.Duration, err error) string {
+	counter := retryAttempt(attempt, maxAttempts)
+
+	if err == nil {
+		return DefaultTheme.Format("retry.success", fmt.Sprintf(translate("%s succeeded"), counter))
+	}
+	if maxAttempts > 0 && attempt >= maxAttempts {
+		return DefaultTheme.Format("retry.failed", fmt.Sprintf(translate("%s failed: %v, giving up"), counter, err))
+	}
+
+	wait := translate("retrying now")
+	if nextIn > 0 {
+		wait = fmt.Sprintf(translate("retrying in %s"), retryDelay(nextIn))
+	}
+	return DefaultTheme.Format("retry.attempt", fmt.Sprintf(translate("%s failed:"), counter)) + " " +
+		DefaultTheme.Format("retry.error", err.Error()) + ", " + DefaultTheme.Format("retry.wait", wait)
+}
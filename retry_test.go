@@ -0,0 +1,44 @@
+package colorize
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+/* TestRetryStatus tests the RetryStatus function */
+func TestRetryStatus(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	errRefused := errors.New("connection refused")
+	tests := []struct {
+		attempt  int
+		max      int
+		nextIn   time.Duration
+		err      error
+		expected string
+	}{
+		{2, 5, 2*time.Second + 30*time.Millisecond, errRefused, "attempt 2/5 failed: connection refused, retrying in 2s"},
+		{1, 0, 250 * time.Millisecond, errRefused, "attempt 1 failed: connection refused, retrying in 250ms"},
+		{3, 5, 0, errRefused, "attempt 3/5 failed: connection refused, retrying now"},
+		{5, 5, time.Second, errRefused, "attempt 5/5 failed: connection refused, giving up"},
+		{4, 5, time.Second, nil, "attempt 4/5 succeeded"},
+	}
+	for _, test := range tests {
+		if got := stripANSI(RetryStatus(test.attempt, test.max, test.nextIn, test.err)); got != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, got)
+		}
+	}
+
+	// roles
+	if got := RetryStatus(5, 5, 0, errRefused); got != DefaultTheme.Format("retry.failed", "attempt 5/5 failed: connection refused, giving up") {
+		t.Errorf("Unexpected final failure: %q", got)
+	}
+	expected := DefaultTheme.Format("retry.attempt", "attempt 1/2 failed:") + " " +
+		DefaultTheme.Format("retry.error", "connection refused") + ", " + DefaultTheme.Format("retry.wait", "retrying in 1s")
+	if got := RetryStatus(1, 2, time.Second, errRefused); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
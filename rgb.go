@@ -0,0 +1,119 @@
+package colorize
+
+import (
+	"fmt"
+	"os"
+)
+
+/*
+RGB returns the hexadecimal color code of the given RGB components, to be used in Options.
+
+Parameters:
+  - r: The red component.
+  - g: The green component.
+  - b: The blue component.
+
+Return:
+  - string: The hexadecimal color code (e.g., "#FF8000").
+
+Example:
+
+	text, err := c.FormatText("warning", &c.Options{FgColor: c.RGB(255, 128, 0), Styles: []string{"bold"}})
+*/
+func RGB(r uint8, g uint8, b uint8) string {
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
+}
+
+/*
+ForegroundRGB formats the given text with the foreground color of the given RGB components. Unlike
+ForegroundText, the color isn't parsed from a hexadecimal code.
+
+Parameters:
+  - text: The text to be formatted.
+  - r: The red component.
+  - g: The green component.
+  - b: The blue component.
+
+Return:
+  - string: The formatted text.
+  - error: An error if the system does not support true color, Xterm or the 16 ANSI colors.
+
+Example:
+
+	formattedText, err := c.ForegroundRGB("Hello, world!", 255, 0, 0)
+*/
+func ForegroundRGB(text string, r uint8, g uint8, b uint8) (string, error) {
+	return formatRGB(text, &color{r, g, b}, foreground)
+}
+
+/*
+BackgroundRGB formats the given text with the background color of the given RGB components. Unlike
+BackgroundText, the color isn't parsed from a hexadecimal code.
+
+Parameters:
+  - text: The text to be formatted.
+  - r: The red component.
+  - g: The green component.
+  - b: The blue component.
+
+Return:
+  - string: The formatted text.
+  - error: An error if the system does not support true color, Xterm or the 16 ANSI colors.
+
+Example:
+
+	formattedText, err := c.BackgroundRGB("Hello, world!", 0, 0, 255)
+*/
+func BackgroundRGB(text string, r uint8, g uint8, b uint8) (string, error) {
+	return formatRGB(text, &color{r, g, b}, background)
+}
+
+/*
+formatRGB formats the given text with the given color, downgraded to the palette of the color
+level, as FormatText does.
+
+Parameters:
+  - text: The text to be formatted.
+  - col: A pointer to the color struct representing the RGB color.
+  - ctx: The color context (background or foreground).
+
+Return:
+  - string: The formatted text.
+  - error: An error if the system does not support true color, Xterm or the 16 ANSI colors, or
+    the downgrade in strict mode.
+*/
+func formatRGB(text string, col *color, ctx ColorContext) (string, error) {
+	record(MetricFormatCalls, 1)
+
+	if noColor || !terminalAllows(os.Stdout) {
+		return text, nil
+	}
+	if !trueColor && !xTerm && !ansi16 {
+		if graceful {
+			return text, nil
+		}
+		return text, newColorizeErr("SYSNOCOLOR", "System does not support true color, xterm or ansi colors")
+	}
+
+	code := ""
+	switch ColorLevel() {
+	case LevelTrueColor:
+		code = getTCCode(col, ctx)
+	case LevelAnsi256:
+		code = getXTCode(col, ctx)
+		record(MetricDowngrades, 1)
+		if strictMode {
+			return text, Warnings{newWarning("DOWNGRADE", fmt.Sprintf("%s color %s approximated to xterm %d", ctx, RGB(col.r, col.g, col.b), rgbToXterm(col)))}
+		}
+	default:
+		code = getAnsi16Code(col, ctx)
+		record(MetricDowngrades, 1)
+		if strictMode {
+			return text, Warnings{newWarning("DOWNGRADE", fmt.Sprintf("%s color %s approximated to ansi %d", ctx, RGB(col.r, col.g, col.b), rgbToAnsi16(col)))}
+		}
+	}
+
+	formatted := code + text + reset
+	record(MetricEscapeBytes, len(formatted)-len(text))
+	return formatted, nil
+}
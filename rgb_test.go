@@ -0,0 +1,53 @@
+package colorize
+
+import (
+	"errors"
+	"testing"
+)
+
+/* TestRGB tests the RGB function */
+func TestRGB(t *testing.T) {
+	if hex := RGB(255, 128, 0); hex != "#FF8000" {
+		t.Errorf("Expected #FF8000 but got %s", hex)
+	}
+	if err := validateHex(RGB(1, 2, 3)); err != nil {
+		t.Error("Expected a valid hex code but got", err)
+	}
+}
+
+/* TestForegroundBackgroundRGB tests the ForegroundRGB and BackgroundRGB functions */
+func TestForegroundBackgroundRGB(t *testing.T) {
+	// defer restore
+	defer restore()
+
+	// the result matches the hexadecimal API at every level
+	for _, profile := range benchProfiles[:3] {
+		trueColor, xTerm, ansi16 = profile.trueColor, profile.xTerm, profile.ansi16
+		fg, err := ForegroundRGB("text", 255, 128, 0)
+		expected, _ := ForegroundText("text", "#FF8000")
+		if err != nil || fg != expected {
+			t.Errorf("%s: expected %q but got %q, %v", profile.name, expected, fg, err)
+		}
+		bg, err := BackgroundRGB("text", 0, 0, 255)
+		expected, _ = BackgroundText("text", "#0000FF")
+		if err != nil || bg != expected {
+			t.Errorf("%s: expected %q but got %q, %v", profile.name, expected, bg, err)
+		}
+	}
+
+	// strict mode reports the downgrade
+	trueColor, xTerm = false, true
+	SetStrictMode(true)
+	defer SetStrictMode(false)
+	var warnings Warnings
+	if text, err := ForegroundRGB("text", 255, 128, 0); text != "text" || !errors.As(err, &warnings) {
+		t.Errorf("Expected a downgrade warning but got %q, %v", text, err)
+	}
+	SetStrictMode(false)
+
+	// no color support
+	xTerm, ansi16 = false, false
+	if text, err := ForegroundRGB("text", 255, 0, 0); text != "text" || err == nil {
+		t.Errorf("Expected an error but got %q, %v", text, err)
+	}
+}
@@ -0,0 +1,169 @@
+package colorize
+
+import (
+	"io"
+	"reflect"
+)
+
+/* The Severity type represents the severity of a message, for routing it (see Router) */
+type Severity int
+
+const (
+	SeverityDebug Severity = iota
+	SeverityInfo
+	SeverityWarn
+	SeverityError
+)
+
+/*
+SeverityOf returns the severity of a log level name (e.g., "WARNING", "fatal"). Unknown levels are
+SeverityInfo.
+
+Parameters:
+  - level: The level name.
+
+Return:
+  - Severity: The severity.
+*/
+func SeverityOf(level string) Severity {
+	switch logLevel(level) {
+	case "debug":
+		return SeverityDebug
+	case "warn":
+		return SeverityWarn
+	case "error":
+		return SeverityError
+	}
+	return SeverityInfo
+}
+
+/*
+The Router type routes messages to a Colorizer by severity: by default, debug and info messages to
+one writer (e.g., stdout) and warnings and errors to another (e.g., stderr). Each writer has its
+own color detection (see DetectLevel), so that a CLI whose stdout is piped still colors its errors
+on the terminal.
+*/
+type Router struct {
+	colorizers [SeverityError + 1]*Colorizer
+}
+
+/*
+NewRouter returns a Router sending debug and info messages to out, and warnings and errors to
+errOut. If both are the same writer, they share the same Colorizer.
+
+Parameters:
+  - out: The writer of the debug and info messages.
+  - errOut: The writer of the warnings and errors.
+
+Return:
+  - *Router: The newly created router.
+
+Example:
+
+	r := c.NewRouter(os.Stdout, os.Stderr)
+	r.Println(c.SeverityInfo, nil, "listening on", addr)
+	r.Printf(c.SeverityError, &c.Options{FgColor: "#FF5F5F"}, "failed: %v\n", err)
+*/
+func NewRouter(out io.Writer, errOut io.Writer) *Router {
+	r := &Router{}
+	r.Route(SeverityDebug, out)
+	r.Route(SeverityInfo, out)
+	r.Route(SeverityWarn, errOut)
+	r.Route(SeverityError, errOut)
+	return r
+}
+
+/*
+Route sends the messages of a severity to the given writer, reusing the Colorizer of another
+severity if it writes to the same writer. Writers that can't be compared (e.g., a func type
+implementing io.Writer) always get their own Colorizer.
+
+Parameters:
+  - severity: The severity.
+  - w: The writer.
+
+Return:
+  - *Router: The router itself, for chaining.
+
+Example:
+
+	r := c.NewRouter(os.Stdout, os.Stderr).Route(c.SeverityDebug, io.Discard)
+*/
+func (r *Router) Route(severity Severity, w io.Writer) *Router {
+	severity = max(SeverityDebug, min(severity, SeverityError))
+	// comparing interfaces holding an uncomparable type panics
+	canCompare := w != nil && reflect.TypeOf(w).Comparable()
+	for _, c := range r.colorizers {
+		if canCompare && c != nil && (c.w == w || c.w.w == w) {
+			r.colorizers[severity] = c
+			return r
+		}
+	}
+	r.colorizers[severity] = NewColorizer(w)
+	return r
+}
+
+/*
+Colorizer returns the Colorizer of a severity.
+
+Parameters:
+  - severity: The severity.
+
+Return:
+  - *Colorizer: The colorizer.
+*/
+func (r *Router) Colorizer(severity Severity) *Colorizer {
+	return r.colorizers[max(SeverityDebug, min(severity, SeverityError))]
+}
+
+/*
+Print formats its operands like fmt.Print, applies the given options and writes the result to the
+writer of the severity (see Colorizer.Print).
+
+Parameters:
+  - severity: The severity.
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (r *Router) Print(severity Severity, opts *Options, a ...any) (int, error) {
+	return r.Colorizer(severity).Print(opts, a...)
+}
+
+/*
+Printf formats according to a format specifier like fmt.Printf, applies the given options and
+writes the result to the writer of the severity (see Colorizer.Printf).
+
+Parameters:
+  - severity: The severity.
+  - opts: The formatting options, or nil for plain text.
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (r *Router) Printf(severity Severity, opts *Options, format string, a ...any) (int, error) {
+	return r.Colorizer(severity).Printf(opts, format, a...)
+}
+
+/*
+Println formats its operands like fmt.Println, applies the given options and writes the result to
+the writer of the severity (see Colorizer.Println).
+
+Parameters:
+  - severity: The severity.
+  - opts: The formatting options, or nil for plain text.
+  - a: The operands.
+
+Return:
+  - int: The number of bytes written.
+  - error: The write error, if any.
+*/
+func (r *Router) Println(severity Severity, opts *Options, a ...any) (int, error) {
+	return r.Colorizer(severity).Println(opts, a...)
+}
@@ -0,0 +1,90 @@
+package colorize
+
+import (
+	"bytes"
+	"testing"
+)
+
+/* TestSeverityOf tests the SeverityOf function */
+func TestSeverityOf(t *testing.T) {
+	tests := map[string]Severity{"TRACE": SeverityDebug, "info": SeverityInfo, "Warning": SeverityWarn, "fatal": SeverityError, "audit": SeverityInfo}
+	for level, expected := range tests {
+		if got := SeverityOf(level); got != expected {
+			t.Errorf("%s: expected %d but got %d", level, expected, got)
+		}
+	}
+}
+
+/* TestRouter tests the routing of messages by severity */
+func TestRouter(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	r := NewRouter(out, errOut)
+	red := &Options{FgColor: "#FF0000"}
+	_, _ = r.Println(SeverityDebug, red, "debug")
+	_, _ = r.Printf(SeverityInfo, red, "info %d\n", 1)
+	_, _ = r.Print(SeverityWarn, red, "warn\n")
+	_, _ = r.Println(SeverityError, nil, "error")
+
+	// buffers aren't terminals: no colors
+	if out.String() != "debug\ninfo 1\n" {
+		t.Errorf("Expected %q but got %q", "debug\ninfo 1\n", out.String())
+	}
+	if errOut.String() != "warn\nerror\n" {
+		t.Errorf("Expected %q but got %q", "warn\nerror\n", errOut.String())
+	}
+	if r.Colorizer(SeverityDebug) != r.Colorizer(SeverityInfo) || r.Colorizer(SeverityInfo) == r.Colorizer(SeverityWarn) {
+		t.Error("Expected one colorizer per writer")
+	}
+
+	// out of range severities are clamped
+	if r.Colorizer(Severity(-1)) != r.Colorizer(SeverityDebug) || r.Colorizer(Severity(9)) != r.Colorizer(SeverityError) {
+		t.Error("Expected out of range severities to be clamped")
+	}
+}
+
+/* TestRouterRoute tests the configuration of the routes */
+func TestRouterRoute(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	buf := &bytes.Buffer{}
+	r := NewRouter(buf, buf)
+	if r.Colorizer(SeverityDebug) != r.Colorizer(SeverityError) {
+		t.Error("Expected the same writer to share a colorizer")
+	}
+
+	// independent color levels per writer
+	colored := &bytes.Buffer{}
+	r.Route(SeverityError, colored)
+	r.colorizers[SeverityError] = r.Colorizer(SeverityError).WithLevel(LevelTrueColor)
+	red := &Options{FgColor: "#FF0000"}
+	_, _ = r.Print(SeverityInfo, red, "plain")
+	_, _ = r.Print(SeverityError, red, "red")
+	expected, _ := FormatText("red", red)
+	if buf.String() != "plain" || colored.String() != expected {
+		t.Errorf("Expected %q and %q but got %q and %q", "plain", expected, buf.String(), colored.String())
+	}
+}
+
+/* writerFunc is an io.Writer that can't be compared */
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+/* TestRouterUncomparable tests routing to writers that can't be compared */
+func TestRouterUncomparable(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r := NewRouter(writerFunc(buf.Write), writerFunc(buf.Write))
+	_, _ = r.Print(SeverityInfo, nil, "info ")
+	_, _ = r.Print(SeverityError, nil, "error")
+	if buf.String() != "info error" {
+		t.Errorf("Expected %q but got %q", "info error", buf.String())
+	}
+}
@@ -0,0 +1,163 @@
+package colorize
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// default terminal width, used when it can't be detected
+	defaultTermWidth = 80
+	// number of rule characters before a left aligned title
+	ruleTitleIndent = 2
+)
+
+/* The Alignment type represents the horizontal alignment of a text */
+type Alignment int
+
+const (
+	/* Supported alignments */
+	AlignCenter Alignment = iota
+	AlignLeft
+	AlignRight
+)
+
+/* The RuleOptions type represents the options for drawing a horizontal rule */
+type RuleOptions struct {
+	Width      int       // width of the rule in cells (the terminal width if 0)
+	Char       string    // character the line is drawn with (the Horizontal glyph if empty)
+	Glyphs     string    // name of the glyph set of the rule (the current set if empty, see SetGlyphSet)
+	Align      Alignment // alignment of the title
+	Style      *Options  // formatting options of the line
+	TitleStyle *Options  // formatting options of the title
+}
+
+/*
+terminalWidth returns the width of the terminal, as reported by the COLUMNS environment variable,
+or 80 if it's not set.
+
+Return:
+  - int: The width of the terminal in cells.
+*/
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return defaultTermWidth
+}
+
+/*
+repeatToWidth repeats the given string to fill the given width.
+
+Parameters:
+  - s: The string to repeat.
+  - width: The width to fill, in cells.
+
+Return:
+  - string: The repeated string.
+*/
+func repeatToWidth(s string, width int) string {
+	w := visibleWidth(s)
+	if w == 0 || width <= 0 {
+		return ""
+	}
+	return strings.Repeat(s, width/w)
+}
+
+/*
+applyOptions formats the given text with the given options, returning the text unmodified if the
+options are nil or formatting fails.
+
+Parameters:
+  - text: The text to be formatted.
+  - opts: The formatting options, or nil.
+
+Return:
+  - string: The formatted text.
+*/
+func applyOptions(text string, opts *Options) string {
+	if opts == nil || text == "" {
+		return text
+	}
+	formatted, _ := FormatText(text, opts)
+	return formatted
+}
+
+/*
+Rule draws a styled horizontal rule filling the terminal width (or RuleOptions.Width), with an
+optional title. Titles too long for the rule are shortened with ShortenMiddle.
+
+Parameters:
+  - title: The title, or an empty string for a plain line.
+  - opts: The rule options, or nil for the defaults.
+
+Return:
+  - string: The rule, without a trailing newline.
+
+Example:
+
+	fmt.Println(c.Rule("Results", &c.RuleOptions{Style: &c.Options{FgColor: "#808080"}}))
+	// ──────────────────────────────── Results ─────────────────────────────────
+*/
+func Rule(title string, opts *RuleOptions) string {
+	if opts == nil {
+		opts = &RuleOptions{}
+	}
+
+	width := opts.Width
+	if width <= 0 {
+		width = terminalWidth()
+	}
+	char := opts.Char
+	if char == "" {
+		set, _ := GetGlyphSet(opts.Glyphs)
+		char = set.Horizontal
+	}
+
+	if title == "" {
+		return withDebugGrid(applyOptions(repeatToWidth(char, width), opts.Style))
+	}
+
+	// the title is surrounded by spaces and at least one rule character on each side
+	title = ShortenMiddle(title, width-4)
+	titleWidth := visibleWidth(title) + 2
+	free := width - titleWidth
+
+	left := 0
+	switch opts.Align {
+	case AlignLeft:
+		left = min(ruleTitleIndent, free)
+	case AlignRight:
+		left = free - min(ruleTitleIndent, free)
+	default:
+		left = free / 2
+	}
+
+	return withDebugGrid(applyOptions(repeatToWidth(char, left), opts.Style) +
+		" " + applyOptions(title, opts.TitleStyle) + " " +
+		applyOptions(repeatToWidth(char, free-left), opts.Style))
+}
+
+/*
+Section returns a section header: a left aligned title on a rule filling the terminal width,
+styled with the "section.title" and "section.rule" roles of DefaultTheme.
+
+Parameters:
+  - title: The title of the section.
+
+Return:
+  - string: The section header, without a trailing newline.
+
+Example:
+
+	fmt.Println(c.Section("Build"))
+	// ── Build ──────────────────────────────────────────────────────────────────
+*/
+func Section(title string) string {
+	return Rule(title, &RuleOptions{
+		Align:      AlignLeft,
+		Style:      DefaultTheme["section.rule"],
+		TitleStyle: DefaultTheme["section.title"],
+	})
+}
@@ -0,0 +1,73 @@
+package colorize
+
+import (
+	"strings"
+	"testing"
+)
+
+/* TestRule tests the Rule function */
+func TestRule(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	// plain line
+	if got := Rule("", &RuleOptions{Width: 5}); got != "─────" {
+		t.Errorf("Unexpected rule: %q", got)
+	}
+	if got := Rule("", &RuleOptions{Width: 5, Char: "=-"}); got != "=-=-" {
+		t.Errorf("Unexpected rule: %q", got)
+	}
+
+	// alignments
+	tests := []struct {
+		align    Alignment
+		expected string
+	}{
+		{AlignCenter, "─── abc ───"},
+		{AlignLeft, "── abc ────"},
+		{AlignRight, "──── abc ──"},
+	}
+	for _, test := range tests {
+		if got := Rule("abc", &RuleOptions{Width: 11, Align: test.align}); got != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, got)
+		}
+	}
+
+	// long titles are shortened
+	if got := Rule("abcdefghij", &RuleOptions{Width: 10}); visibleWidth(got) != 10 {
+		t.Errorf("Expected a width of 10 but got %d: %q", visibleWidth(got), got)
+	}
+
+	// styles
+	got := Rule("abc", &RuleOptions{Width: 9, TitleStyle: &Options{Styles: []string{"bold"}}})
+	if got != "── "+styles["bold"]+"abc"+reset+" ──" {
+		t.Errorf("Unexpected rule: %q", got)
+	}
+
+	// terminal width
+	t.Setenv("COLUMNS", "20")
+	if got := Rule("", nil); visibleWidth(got) != 20 {
+		t.Errorf("Expected a width of 20 but got %d", visibleWidth(got))
+	}
+	t.Setenv("COLUMNS", "bad")
+	if got := Rule("", nil); visibleWidth(got) != defaultTermWidth {
+		t.Errorf("Expected the default width but got %d", visibleWidth(got))
+	}
+}
+
+/* TestSection tests the Section function */
+func TestSection(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	t.Setenv("COLUMNS", "30")
+
+	got := Section("Build")
+	if visibleWidth(got) != 30 || !strings.Contains(got, styles["bold"]+"Build"+reset) {
+		t.Errorf("Unexpected section: %q", got)
+	}
+	if !strings.HasPrefix(stripANSI(got), "── Build ") {
+		t.Errorf("Expected a left aligned title: %q", stripANSI(got))
+	}
+}
@@ -0,0 +1,119 @@
+package colorize
+
+import (
+	"strconv"
+	"strings"
+)
+
+/* debugGrid makes the block renderers overlay a column ruler under their output */
+var debugGrid = false
+
+/*
+Ruler returns a two-line column ruler: a tick line with a "+" every 5 cells and a "|" every 10
+cells, and the column numbers under the "|" ticks. It's styled with the "ruler" role of
+DefaultTheme.
+
+Parameters:
+  - width: The width of the ruler in cells (the terminal width if 0 or negative).
+
+Return:
+  - string: The ruler, without a trailing newline.
+
+Example:
+
+	fmt.Println(c.Ruler(25))
+	// ----+----|----+----|----+
+	//         10        20
+*/
+func Ruler(width int) string {
+	if width <= 0 {
+		width = terminalWidth()
+	}
+
+	ticks := strings.Builder{}
+	numbers := strings.Builder{}
+	for col := 1; col <= width; col++ {
+		switch {
+		case col%10 == 0:
+			ticks.WriteByte('|')
+			// the number ends under its tick
+			label := strconv.Itoa(col)
+			if pad := col - len(label) - numbers.Len(); pad >= 0 {
+				numbers.WriteString(strings.Repeat(" ", pad) + label)
+			}
+		case col%5 == 0:
+			ticks.WriteByte('+')
+		default:
+			ticks.WriteByte('-')
+		}
+	}
+
+	ruler := DefaultTheme.Format("ruler", ticks.String())
+	if numbers.Len() > 0 {
+		ruler += "\n" + DefaultTheme.Format("ruler", numbers.String())
+	}
+	return ruler
+}
+
+/*
+Grid appends a column ruler (see Ruler) as wide as its widest line under a rendered block, to
+debug the alignment of a layout.
+
+Parameters:
+  - block: The rendered block.
+
+Return:
+  - string: The block followed by the ruler. A trailing newline of the block is kept after the
+    ruler.
+
+Example:
+
+	fmt.Println(c.Grid(c.DumpEnv([]string{"APP_"})))
+*/
+func Grid(block string) string {
+	trimmed, newline := strings.CutSuffix(block, "\n")
+	width := 0
+	for _, line := range strings.Split(trimmed, "\n") {
+		width = max(width, visibleWidth(line))
+	}
+	if width == 0 {
+		return block
+	}
+
+	grid := trimmed + "\n" + Ruler(width)
+	if newline {
+		grid += "\n"
+	}
+	return grid
+}
+
+/*
+SetDebugGrid enables or disables the debug grid mode. When enabled, the blocks rendered by the
+package (e.g., Rule, ExampleBlock, DumpEnv) are followed by a column ruler, as with Grid.
+
+Parameters:
+  - enabled: true to overlay the ruler, false otherwise.
+
+Example:
+
+	c.SetDebugGrid(os.Getenv("APP_DEBUG_LAYOUT") != "")
+*/
+func SetDebugGrid(enabled bool) {
+	debugGrid = enabled
+}
+
+/*
+withDebugGrid applies Grid to a rendered block if the debug grid mode is enabled.
+
+Parameters:
+  - block: The rendered block.
+
+Return:
+  - string: The block, followed by a ruler in debug grid mode.
+*/
+func withDebugGrid(block string) string {
+	if !debugGrid {
+		return block
+	}
+	return Grid(block)
+}
@@ -0,0 +1,52 @@
+package colorize
+
+import "testing"
+
+/* TestRuler tests the Ruler function */
+func TestRuler(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	tests := map[int]string{
+		4:  "----",
+		10: "----+----|\n        10",
+		25: "----+----|----+----|----+\n        10        20",
+	}
+	for width, expected := range tests {
+		if got := stripANSI(Ruler(width)); got != expected {
+			t.Errorf("%d: expected %q but got %q", width, expected, got)
+		}
+	}
+
+	t.Setenv("COLUMNS", "12")
+	if got := stripANSI(Ruler(0)); got != "----+----|--\n        10" {
+		t.Errorf("Expected a ruler of the terminal width but got %q", got)
+	}
+}
+
+/* TestGrid tests the Grid function and the debug grid mode */
+func TestGrid(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = false
+	ansi16 = false
+
+	if got := Grid("ab\nabcdef\n"); got != "ab\nabcdef\n----+-\n" {
+		t.Errorf("Expected the ruler under the block but got %q", got)
+	}
+	if got := Grid(""); got != "" {
+		t.Errorf("Expected an empty block but got %q", got)
+	}
+
+	// debug grid mode
+	rule := Rule("", &RuleOptions{Width: 10})
+	if withDebugGrid(rule) != rule {
+		t.Error("Expected no ruler without the debug grid mode")
+	}
+	SetDebugGrid(true)
+	if got := Rule("", &RuleOptions{Width: 10}); got != rule+"\n"+Ruler(10) {
+		t.Errorf("Expected the rule followed by a ruler but got %q", got)
+	}
+}
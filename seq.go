@@ -0,0 +1,68 @@
+//go:build go1.23
+
+package colorize
+
+import (
+	"iter"
+)
+
+/*
+FormatSeq returns a sequence formatting every text of the given sequence with the given options,
+lazily (each text is formatted when the consumer asks for it).
+
+Following the package convention, texts that can't be formatted are yielded unmodified. Use
+FormatItemsSeq to get the errors.
+
+Parameters:
+  - seq: The sequence of texts to be formatted.
+  - opts: The formatting options.
+
+Return:
+  - iter.Seq[string]: The sequence of formatted texts.
+
+Example:
+
+	lines := slices.Values(strings.Split(logs, "\n"))
+	for line := range c.FormatSeq(lines, &c.Options{FgColor: "#808080"}) {
+		fmt.Println(line)
+	}
+*/
+func FormatSeq(seq iter.Seq[string], opts *Options) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for text := range seq {
+			formatted, _ := FormatText(text, opts)
+			if !yield(formatted) {
+				return
+			}
+		}
+	}
+}
+
+/*
+FormatItemsSeq returns a sequence formatting every item of the given sequence, lazily, yielding
+each formatted text along with the error returned by FormatText.
+
+Parameters:
+  - seq: The sequence of items to be formatted.
+
+Return:
+  - iter.Seq2[string, error]: The sequence of formatted texts and errors.
+
+Example:
+
+	for text, err := range c.FormatItemsSeq(items) {
+		if err != nil {
+			log.Println(err)
+		}
+		fmt.Println(text)
+	}
+*/
+func FormatItemsSeq(seq iter.Seq[Item]) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for item := range seq {
+			if !yield(FormatText(item.Text, item.Options)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+//go:build go1.23
+
+package colorize
+
+import (
+	"slices"
+	"testing"
+)
+
+/* TestFormatSeq tests the FormatSeq function */
+func TestFormatSeq(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	bold := &Options{Styles: []string{"bold"}}
+	texts := slices.Collect(FormatSeq(slices.Values([]string{"a", "b", "c"}), bold))
+	if len(texts) != 3 || texts[2] != styles["bold"]+"c"+reset {
+		t.Errorf("Unexpected texts: %q", texts)
+	}
+
+	// early break
+	count := 0
+	for range FormatSeq(slices.Values([]string{"a", "b", "c"}), bold) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Error("Expected the sequence to stop")
+	}
+
+	// invalid options
+	texts = slices.Collect(FormatSeq(slices.Values([]string{"a"}), &Options{FgColor: "bad"}))
+	if texts[0] != "a" {
+		t.Error("Expected the text to be yielded unmodified")
+	}
+}
+
+/* TestFormatItemsSeq tests the FormatItemsSeq function */
+func TestFormatItemsSeq(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	items := slices.Values([]Item{
+		{Text: "a", Options: &Options{Styles: []string{"bold"}}},
+		{Text: "b", Options: &Options{FgColor: "bad"}},
+		{Text: "c", Options: &Options{Styles: []string{"bold"}}},
+	})
+
+	errs := 0
+	count := 0
+	for text, err := range FormatItemsSeq(items) {
+		count++
+		if err != nil {
+			errs++
+			if text != "b" {
+				t.Error("Expected the failed text to be yielded unmodified")
+			}
+		}
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 || errs != 1 {
+		t.Errorf("Expected 2 items and 1 error but got %d and %d", count, errs)
+	}
+}
@@ -0,0 +1,172 @@
+package colorize
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+/*
+The PtyRequest type describes the terminal of a remote session, as sent by the client of an SSH
+server in its PTY request, so that server-side terminal apps color every connection for its own
+terminal rather than the terminal of the server.
+*/
+type PtyRequest struct {
+	Term   string   // TERM of the client (e.g., "xterm-256color")
+	Width  int      // width of the window, in columns
+	Height int      // height of the window, in lines
+	Env    []string // environment sent by the client, as "KEY=VALUE" (e.g., COLORTERM, NO_COLOR)
+}
+
+/*
+getenv returns the value of a variable of the environment of the session (the last one if it's set
+more than once).
+
+Parameters:
+  - key: The name of the variable.
+
+Return:
+  - string: The value of the variable, or an empty string if it's not set.
+*/
+func (p PtyRequest) getenv(key string) string {
+	for i := len(p.Env) - 1; i >= 0; i-- {
+		if value, ok := strings.CutPrefix(p.Env[i], key+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+/*
+SessionLevel returns the color level of the terminal of a remote session, detected like the level
+of the package (see ColorLevel) but from the PTY request instead of the environment of the process:
+FORCE_COLOR and CLICOLOR_FORCE, NO_COLOR, COLORTERM, TERM_PROGRAM, then the TERM of the request.
+
+Parameters:
+  - pty: The PTY request of the session.
+
+Return:
+  - Level: The color level.
+*/
+func SessionLevel(pty PtyRequest) Level {
+	level := termLevel(pty.Term)
+	if value := pty.getenv("COLORTERM"); value == "truecolor" || value == "24bit" {
+		level = LevelTrueColor
+	} else if slices.Contains(trueColorPrograms, pty.getenv("TERM_PROGRAM")) {
+		level = LevelTrueColor
+	}
+
+	switch force, _ := forceColorFrom(pty.getenv); force {
+	case -1:
+		if pty.getenv("NO_COLOR") != "" {
+			return LevelNone
+		}
+		return level
+	case 0:
+		return LevelNone
+	case 3:
+		return LevelTrueColor
+	case 2:
+		return max(level, LevelAnsi256)
+	}
+	return max(level, LevelAnsi16)
+}
+
+/*
+The Session type is a Colorizer for the terminal of a remote session (e.g., an SSH connection),
+keeping track of the size of its window.
+
+A Session is safe for concurrent use.
+*/
+type Session struct {
+	*Colorizer
+	mu        sync.Mutex
+	width     int
+	height    int
+	listeners []func(width, height int)
+}
+
+/*
+NewSession returns a Session writing to the channel of a remote session, with the color level of
+its terminal (see SessionLevel).
+
+Parameters:
+  - w: The writer of the session (e.g., the SSH channel).
+  - pty: The PTY request of the session.
+
+Return:
+  - *Session: The newly created session.
+
+Example:
+
+	// with github.com/gliderlabs/ssh
+	ssh.Handle(func(s ssh.Session) {
+		ptyReq, windows, _ := s.Pty()
+		session := c.NewSession(s, c.PtyRequest{
+			Term: ptyReq.Term, Width: ptyReq.Window.Width, Height: ptyReq.Window.Height, Env: s.Environ(),
+		})
+		go func() {
+			for win := range windows {
+				session.Resize(win.Width, win.Height)
+			}
+		}()
+		session.Println(&c.Options{FgColor: "#5FD7FF"}, "Welcome!")
+	})
+*/
+func NewSession(w io.Writer, pty PtyRequest) *Session {
+	return &Session{
+		Colorizer: &Colorizer{w: NewSyncWriter(w), level: SessionLevel(pty)},
+		width:     pty.Width,
+		height:    pty.Height,
+	}
+}
+
+/*
+Size returns the size of the window of the session.
+
+Return:
+  - int: The width of the window, in columns.
+  - int: The height of the window, in lines.
+*/
+func (s *Session) Size() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.width, s.height
+}
+
+/*
+Resize records a new size of the window of the session (e.g., from the window-change requests of
+an SSH session) and calls the functions subscribed with OnResize.
+
+Parameters:
+  - width: The width of the window, in columns.
+  - height: The height of the window, in lines.
+*/
+func (s *Session) Resize(width int, height int) {
+	s.mu.Lock()
+	s.width, s.height = width, height
+	listeners := slices.Clone(s.listeners)
+	s.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(width, height)
+	}
+}
+
+/*
+OnResize subscribes a function to the resizes of the window of the session (see Resize).
+
+Parameters:
+  - fn: The function, called with the new width and height.
+
+Example:
+
+	screen := c.NewSplitScreen(session.Writer(), 2)
+	session.OnResize(func(_, height int) { screen.Resize(height) })
+*/
+func (s *Session) OnResize(fn func(width, height int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
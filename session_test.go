@@ -0,0 +1,57 @@
+package colorize
+
+import (
+	"bytes"
+	"testing"
+)
+
+/* TestSessionLevel tests the SessionLevel function */
+func TestSessionLevel(t *testing.T) {
+	tests := []struct {
+		pty      PtyRequest
+		expected Level
+	}{
+		{PtyRequest{Term: "xterm-256color"}, LevelAnsi256},
+		{PtyRequest{Term: "dumb"}, LevelNone},
+		{PtyRequest{Term: "xterm-256color", Env: []string{"COLORTERM=truecolor"}}, LevelTrueColor},
+		{PtyRequest{Term: "xterm-256color", Env: []string{"TERM_PROGRAM=WezTerm"}}, LevelTrueColor},
+		{PtyRequest{Term: "xterm-256color", Env: []string{"NO_COLOR=1"}}, LevelNone},
+		{PtyRequest{Term: "dumb", Env: []string{"NO_COLOR=1", "FORCE_COLOR=1"}}, LevelAnsi16},
+		{PtyRequest{Term: "dumb", Env: []string{"CLICOLOR_FORCE=1"}}, LevelAnsi16},
+		{PtyRequest{Term: "xterm-256color", Env: []string{"FORCE_COLOR=3", "FORCE_COLOR=0"}}, LevelNone},
+		{PtyRequest{Term: "dumb", Env: []string{"FORCE_COLOR=2"}}, LevelAnsi256},
+	}
+	for _, test := range tests {
+		if level := SessionLevel(test.pty); level != test.expected {
+			t.Errorf("Expected level %d for %+v but got %d", test.expected, test.pty, level)
+		}
+	}
+}
+
+/* TestSession tests the Session type */
+func TestSession(t *testing.T) {
+	// defer restore
+	defer restore()
+	noColor = true
+
+	// the level of the session doesn't depend on the process
+	buf := &bytes.Buffer{}
+	session := NewSession(buf, PtyRequest{Term: "xterm-256color", Width: 80, Height: 24, Env: []string{"COLORTERM=24bit"}})
+	red := &Options{FgColor: "#FF0000"}
+	_, _ = session.Print(red, "red")
+	expected, _ := renderText("red", red, LevelTrueColor)
+	if session.Level() != LevelTrueColor || buf.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, buf.String())
+	}
+
+	// resizes are recorded and notified
+	if width, height := session.Size(); width != 80 || height != 24 {
+		t.Errorf("Expected 80x24 but got %dx%d", width, height)
+	}
+	var notified []int
+	session.OnResize(func(width, height int) { notified = append(notified, width, height) })
+	session.Resize(120, 40)
+	if width, height := session.Size(); width != 120 || height != 40 || len(notified) != 2 || notified[0] != 120 || notified[1] != 40 {
+		t.Errorf("Expected the resize to be recorded and notified but got %dx%d, %v", width, height, notified)
+	}
+}
@@ -0,0 +1,234 @@
+package colorize
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// default terminal height, used when it can't be detected
+	defaultTermHeight = 24
+
+	// cursor and scroll region escape codes
+	cursorSave        = "\0337"
+	cursorRestore     = "\0338"
+	clearLine         = "\033[2K"
+	scrollRegionReset = "\033[r"
+)
+
+/*
+terminalHeight returns the height of the terminal, from the LINES environment variable.
+
+Return:
+  - int: The height of the terminal, in lines (defaultTermHeight if it can't be detected).
+*/
+func terminalHeight() int {
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		return lines
+	}
+	return defaultTermHeight
+}
+
+/*
+The SplitScreen type splits the terminal in two areas: the logs written to it scroll in the top
+area, while a status panel stays fixed in the bottom lines (see SetStatus). It relies on the scroll
+region of the terminal (DECSTBM), which every VT100 compatible terminal supports.
+
+A SplitScreen is safe for concurrent use.
+*/
+type SplitScreen struct {
+	mu           sync.Mutex
+	w            io.Writer
+	requested    int // height of the panel requested by NewSplitScreen
+	statusHeight int // height of the panel, clamped to the terminal height
+	rows         int
+	status       []string
+	closed       bool
+}
+
+/*
+NewSplitScreen reserves the bottom lines of the terminal for a status panel and confines the
+scrolling to the lines above. The height of the terminal is read from the LINES environment
+variable: call Resize when it changes (e.g., on SIGWINCH).
+
+Parameters:
+  - w: The writer connected to the terminal.
+  - statusHeight: The height of the status panel, in lines (at least 1, and at most the height of
+    the terminal minus 1).
+
+Return:
+  - *SplitScreen: The split screen, to be closed with Close.
+
+Example:
+
+	screen := c.NewSplitScreen(os.Stdout, 2)
+	defer screen.Close()
+
+	log.SetOutput(screen)
+	for i, job := range jobs {
+		screen.SetStatus(
+			c.StyleText(fmt.Sprintf("%d/%d jobs", i, len(jobs)), []string{"bold"}),
+			"running "+job.Name,
+		)
+		job.Run()
+	}
+*/
+func NewSplitScreen(w io.Writer, statusHeight int) *SplitScreen {
+	rows := max(terminalHeight(), 2)
+	s := &SplitScreen{w: w, requested: max(statusHeight, 1)}
+	s.statusHeight = min(s.requested, rows-1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// scroll the current output up to make room for the panel, then confine the scrolling above it
+	io.WriteString(s.w, strings.Repeat("\n", s.statusHeight)+fmt.Sprintf("\033[%dA", s.statusHeight))
+	s.resize(rows)
+	return s
+}
+
+/*
+resize sets the scroll region for the given terminal height and draws the status panel, as high as
+requested if the terminal is high enough. The caller must hold the lock.
+
+Parameters:
+  - rows: The height of the terminal, in lines.
+
+Return:
+  - error: The error returned by the writer, if any.
+*/
+func (s *SplitScreen) resize(rows int) error {
+	s.rows = max(rows, 2)
+	s.statusHeight = min(s.requested, s.rows-1)
+
+	// setting the scroll region moves the cursor home, so it's saved and restored around it
+	region := fmt.Sprintf("%s\033[1;%dr%s", cursorSave, s.rows-s.statusHeight, cursorRestore)
+	if _, err := io.WriteString(s.w, region); err != nil {
+		return err
+	}
+	return s.draw()
+}
+
+/*
+draw writes the status panel in the bottom lines, leaving the cursor in the log area. The caller
+must hold the lock.
+
+Return:
+  - error: The error returned by the writer, if any.
+*/
+func (s *SplitScreen) draw() error {
+	width := terminalWidth()
+	builder := strings.Builder{}
+	builder.WriteString(cursorSave)
+	for i := 0; i < s.statusHeight; i++ {
+		fmt.Fprintf(&builder, "\033[%d;1H%s", s.rows-s.statusHeight+1+i, clearLine)
+		if i >= len(s.status) {
+			continue
+		}
+		// longer lines would wrap and scroll the panel away
+		line := fitWidth(s.status[i], width)
+		builder.WriteString(line)
+		if strings.Contains(line, "\033[") {
+			builder.WriteString(reset)
+		}
+	}
+	builder.WriteString(cursorRestore)
+
+	_, err := io.WriteString(s.w, builder.String())
+	return err
+}
+
+/*
+Write writes p to the log area. This makes the SplitScreen an io.Writer.
+
+Parameters:
+  - p: The bytes to write.
+
+Return:
+  - int: The number of bytes written.
+  - error: The error returned by the writer, if any.
+*/
+func (s *SplitScreen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+/*
+SetStatus replaces the lines of the status panel. Lines beyond the height of the panel are dropped,
+and lines wider than the terminal are truncated.
+
+Parameters:
+  - lines: The lines of the panel, possibly formatted (e.g., with FormatText).
+
+Return:
+  - error: The error returned by the writer, if any.
+*/
+func (s *SplitScreen) SetStatus(lines ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = append([]string(nil), lines...)
+	if s.closed {
+		return nil
+	}
+	return s.draw()
+}
+
+/*
+Resize adapts the split screen to a new terminal height: the scroll region is set again and the
+status panel is redrawn in the new bottom lines.
+
+Parameters:
+  - rows: The height of the terminal, in lines, or 0 to read it from the LINES environment variable.
+
+Return:
+  - error: The error returned by the writer, if any.
+
+Example:
+
+	resized := make(chan os.Signal, 1)
+	signal.Notify(resized, syscall.SIGWINCH)
+	go func() {
+		for range resized {
+			_, rows, _ := term.GetSize(int(os.Stdout.Fd()))
+			screen.Resize(rows)
+		}
+	}()
+*/
+func (s *SplitScreen) Resize(rows int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	if rows <= 0 {
+		rows = terminalHeight()
+	}
+	return s.resize(rows)
+}
+
+/*
+Close clears the status panel and restores the scroll region to the whole terminal. Calling Close
+more than once is safe.
+
+Return:
+  - error: The error returned by the writer, if any.
+*/
+func (s *SplitScreen) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.status = nil
+	if err := s.draw(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(s.w, cursorSave+scrollRegionReset+cursorRestore)
+	return err
+}
@@ -0,0 +1,91 @@
+package colorize
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+/* TestSplitScreen tests the SplitScreen type */
+func TestSplitScreen(t *testing.T) {
+	t.Setenv("LINES", "10")
+	t.Setenv("COLUMNS", "8")
+
+	buf := &bytes.Buffer{}
+	screen := NewSplitScreen(buf, 2)
+
+	// room is made for the panel, and the scrolling is confined above it
+	setup := buf.String()
+	if !strings.HasPrefix(setup, "\n\n\033[2A") {
+		t.Errorf("Expected room to be made for the panel but got %q", setup)
+	}
+	if !strings.Contains(setup, cursorSave+"\033[1;8r"+cursorRestore) {
+		t.Errorf("Expected the scroll region to end above the panel but got %q", setup)
+	}
+
+	// logs are written as is
+	buf.Reset()
+	screen.Write([]byte("log line\n"))
+	if buf.String() != "log line\n" {
+		t.Errorf("Expected the log to be written as is but got %q", buf.String())
+	}
+
+	// the panel is drawn in the bottom lines, truncated and reset
+	buf.Reset()
+	screen.SetStatus("\033[1mprogress: 50%", "ok", "dropped")
+	expected := cursorSave +
+		"\033[9;1H" + clearLine + "\033[1mprogress" + reset +
+		"\033[10;1H" + clearLine + "ok" +
+		cursorRestore
+	if buf.String() != expected {
+		t.Errorf("Expected the panel to be drawn\n%q\nbut got\n%q", expected, buf.String())
+	}
+
+	// resizing moves the region and the panel
+	buf.Reset()
+	screen.Resize(6)
+	if !strings.HasPrefix(buf.String(), cursorSave+"\033[1;4r"+cursorRestore) ||
+		!strings.Contains(buf.String(), "\033[6;1H"+clearLine+"ok") {
+		t.Errorf("Expected the panel to follow the resize but got %q", buf.String())
+	}
+
+	// closing clears the panel and resets the region, once
+	buf.Reset()
+	screen.Close()
+	if !strings.Contains(buf.String(), "\033[5;1H"+clearLine+"\033[6;1H"+clearLine) ||
+		!strings.HasSuffix(buf.String(), cursorSave+scrollRegionReset+cursorRestore) {
+		t.Errorf("Expected the panel to be cleared and the region reset but got %q", buf.String())
+	}
+	buf.Reset()
+	screen.Close()
+	screen.SetStatus("late")
+	screen.Resize(0)
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be written after Close but got %q", buf.String())
+	}
+}
+
+/* TestSplitScreenHeight tests that the status panel leaves room for the logs */
+func TestSplitScreenHeight(t *testing.T) {
+	t.Setenv("LINES", "3")
+
+	buf := &bytes.Buffer{}
+	screen := NewSplitScreen(buf, 5)
+	if !strings.Contains(buf.String(), "\033[1;1r") {
+		t.Errorf("Expected a single log line to be kept but got %q", buf.String())
+	}
+
+	// the requested height is restored once the terminal is high enough
+	buf.Reset()
+	screen.Resize(20)
+	if !strings.HasPrefix(buf.String(), cursorSave+"\033[1;15r"+cursorRestore) {
+		t.Errorf("Expected the panel to get its requested height back but got %q", buf.String())
+	}
+
+	buf.Reset()
+	NewSplitScreen(buf, 0)
+	if !strings.HasPrefix(buf.String(), "\n\033[1A") || !strings.Contains(buf.String(), "\033[1;2r") {
+		t.Errorf("Expected a panel of at least one line but got %q", buf.String())
+	}
+	screen.Close()
+}
@@ -0,0 +1,57 @@
+package colorize
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Sprint formats its operands like fmt.Sprint and applies the options. If the text can't be formatted
+(e.g., the system does not support colors), it's returned unformatted.
+
+Parameters:
+  - a: The operands.
+
+Return:
+  - string: The formatted text.
+
+Example:
+
+	warn := &c.Options{FgColor: "#FFD700"}
+	log.Printf("disk usage at %s", warn.Sprint(usage, "%"))
+*/
+func (o *Options) Sprint(a ...any) string {
+	return applyOptions(fmt.Sprint(a...), o)
+}
+
+/*
+Sprintf formats according to a format specifier like fmt.Sprintf and applies the options. If the
+text can't be formatted, it's returned unformatted.
+
+Parameters:
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - string: The formatted text.
+*/
+func (o *Options) Sprintf(format string, a ...any) string {
+	return applyOptions(fmt.Sprintf(auditFormat(format, a), a...), o)
+}
+
+/*
+Sprintln formats its operands like fmt.Sprintln and applies the options. The trailing newline is
+kept out of the formatted region, so the style never leaks into the next line. If the text can't
+be formatted, it's returned unformatted.
+
+Parameters:
+  - a: The operands.
+
+Return:
+  - string: The formatted text.
+*/
+func (o *Options) Sprintln(a ...any) string {
+	text := fmt.Sprintln(a...)
+	trimmed := strings.TrimSuffix(text, "\n")
+	return applyOptions(trimmed, o) + "\n"
+}
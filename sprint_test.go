@@ -0,0 +1,34 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestOptionsSprint tests the Sprint, Sprintf and Sprintln methods of the Options type */
+func TestOptionsSprint(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	bold := &Options{Styles: []string{"bold"}}
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{bold.Sprint("a", 1, 2, "b"), styles["bold"] + "a1 2b" + reset},
+		{bold.Sprintf("%s=%d", "a", 1), styles["bold"] + "a=1" + reset},
+		{bold.Sprintln("a", 1), styles["bold"] + "a 1" + reset + "\n"},
+		{(&Options{}).Sprint("plain"), "plain"},
+	}
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, test.got)
+		}
+	}
+
+	// without colors, the text is returned unformatted
+	trueColor, xTerm, ansi16 = false, false, false
+	if got := (&Options{FgColor: "#FF0000"}).Sprintln("plain"); got != "plain\n" {
+		t.Errorf("Expected %q but got %q", "plain\n", got)
+	}
+}
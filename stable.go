@@ -0,0 +1,67 @@
+package colorize
+
+import "hash/fnv"
+
+const (
+	// number of lightness levels keys sharing a hue are spread across
+	stableLightnessSteps = 4
+	// saturation range of the stable colors, in percent
+	stableMinSaturation = 60
+	stableMaxSaturation = 85
+)
+
+var (
+	// lightness bounds of the stable colors, in percent (readable on a dark background by default)
+	stableMinLightness = 50.0
+	stableMaxLightness = 75.0
+)
+
+/*
+SetStableLightness sets the lightness bounds of the colors derived by StableTheme (50% to 75% by
+default, readable on dark backgrounds). Processes must use the same bounds to agree on colors.
+
+Parameters:
+  - minLightness: The minimum lightness, in percent.
+  - maxLightness: The maximum lightness, in percent.
+
+Example:
+
+	c.SetStableLightness(25, 45) // light background
+*/
+func SetStableLightness(minLightness float64, maxLightness float64) {
+	stableMinLightness = max(0, min(minLightness, maxLightness))
+	stableMaxLightness = min(100, max(minLightness, maxLightness))
+}
+
+/*
+StableTheme derives a deterministic foreground color from a key (e.g., a job or host name), so that
+distributed processes coloring output by key agree on colors without coordination.
+
+The hue is derived from an FNV-1a hash of the key, and independent bits of the hash pick the
+saturation and one of several lightness levels within the bounds (see SetStableLightness), so that
+keys whose hues collide are still told apart.
+
+Parameters:
+  - key: The key.
+
+Return:
+  - *Options: The formatting options of the key.
+
+Example:
+
+	for _, job := range jobs {
+		fmt.Println(c.Theme{"job": c.StableTheme(job.ID)}.Format("job", job.ID), job.Status)
+	}
+*/
+func StableTheme(key string) *Options {
+	hash := fnv.New64a()
+	hash.Write([]byte(key))
+	sum := hash.Sum64()
+
+	hue := float64(sum%360) + float64(sum>>9%10)/10
+	saturation := stableMinSaturation + float64(sum>>17%uint64(stableMaxSaturation-stableMinSaturation+1))
+	step := float64(sum >> 25 % stableLightnessSteps)
+	lightness := stableMinLightness + (stableMaxLightness-stableMinLightness)*step/(stableLightnessSteps-1)
+
+	return &Options{FgColor: HSL(hue, saturation, lightness)}
+}
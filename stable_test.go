@@ -0,0 +1,37 @@
+package colorize
+
+import "testing"
+
+/* TestStableTheme tests the StableTheme function */
+func TestStableTheme(t *testing.T) {
+	defer SetStableLightness(50, 75)
+
+	// deterministic, and distinct for distinct keys
+	if StableTheme("job-42").FgColor != StableTheme("job-42").FgColor {
+		t.Error("Expected the same color for the same key")
+	}
+	colors := map[string]bool{}
+	for _, key := range []string{"job-1", "job-2", "job-3", "job-4", "job-5", "job-6", "job-7", "job-8"} {
+		colors[StableTheme(key).FgColor] = true
+	}
+	if len(colors) != 8 {
+		t.Errorf("Expected 8 distinct colors but got %d", len(colors))
+	}
+
+	// the color is stable across processes and versions
+	if hex := StableTheme("build").FgColor; hex != "#E816E9" {
+		t.Errorf("Expected #E816E9 but got %s", hex)
+	}
+
+	// lightness bounds
+	SetStableLightness(90, 80)
+	if stableMinLightness != 80 || stableMaxLightness != 90 {
+		t.Errorf("Expected the bounds to be ordered but got %v-%v", stableMinLightness, stableMaxLightness)
+	}
+	for key := range colors {
+		col, _ := getColor(StableTheme(key).FgColor)
+		if lightness := (int(max(col.r, col.g, col.b)) + int(min(col.r, col.g, col.b))) * 100 / 510; lightness < 79 || lightness > 90 {
+			t.Errorf("%s: lightness %d%% out of bounds", key, lightness)
+		}
+	}
+}
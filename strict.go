@@ -0,0 +1,196 @@
+package colorize
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// strictMode reports silently-degraded rendering as errors (see SetStrictMode)
+	strictMode = false
+	// strictValidation reports unknown styles as errors (see SetValidationMode)
+	strictValidation = false
+	// lenientMode skips invalid colors instead of reporting them (see SetValidationMode)
+	lenientMode = false
+)
+
+/* The ValidationMode type represents how invalid formatting options are handled */
+type ValidationMode int
+
+const (
+	/* Validation modes */
+	ValidationDefault ValidationMode = iota // unknown styles are skipped, invalid colors are errors
+	ValidationStrict                        // unknown styles are errors too
+	ValidationLenient                       // invalid colors are skipped too, the rest is formatted
+)
+
+/*
+Warning describes a feature that was (or would have been) silently degraded while formatting.
+
+Fields:
+
+	Name string: A name categorizing the warning (e.g., "UNKNOWNSTYLE", "DOWNGRADE").
+	Msg  string: A message describing the warning.
+*/
+type Warning struct {
+	Name string
+	Msg  string
+}
+
+/*
+newWarning creates a new Warning with the provided name and message.
+
+Parameters:
+  - name: A name categorizing the warning.
+  - msg: A message describing the warning.
+
+Return:
+  - Warning: The newly created Warning.
+*/
+func newWarning(name string, msg string) Warning {
+	return Warning{Name: name, Msg: msg}
+}
+
+/*
+Error returns the string representation of the Warning, with the pattern "<name>: <message>".
+
+Return:
+  - string: The string representation of the warning.
+*/
+func (w Warning) Error() string {
+	return fmt.Sprintf("%s: %s", w.Name, w.Msg)
+}
+
+/*
+Is reports whether the warning belongs to the category of the target, so that errors.Is matches
+the sentinel errors (e.g., an unknown style matches ErrInvalidStyle).
+
+Parameters:
+  - target: The error to compare with.
+
+Return:
+  - bool: true if the target is an Error or a Warning of the same name, false otherwise.
+*/
+func (w Warning) Is(target error) bool {
+	return newColorizeErr(w.Name, w.Msg).Is(target)
+}
+
+/*
+Warnings is the error returned in strict mode, listing every feature that would have been
+silently degraded.
+
+Use errors.As to retrieve it:
+
+	var warnings c.Warnings
+	if errors.As(err, &warnings) {
+		for _, w := range warnings {
+			fmt.Println(w.Name, w.Msg)
+		}
+	}
+*/
+type Warnings []Warning
+
+/*
+Error returns the string representation of the Warnings, joined by "; ".
+
+Return:
+  - string: The string representation of the warnings.
+*/
+func (w Warnings) Error() string {
+	msgs := make([]string, len(w))
+	for i, warning := range w {
+		msgs[i] = warning.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+/*
+Unwrap returns the warnings as a list of errors, so that errors.Is and errors.As inspect each of
+them (e.g., errors.Is(err, ErrInvalidStyle)).
+
+Return:
+  - []error: The warnings.
+*/
+func (w Warnings) Unwrap() []error {
+	errs := make([]error, len(w))
+	for i, warning := range w {
+		errs[i] = warning
+	}
+	return errs
+}
+
+/*
+SetStrictMode enables or disables strict mode.
+
+By default, some features are degraded silently: unknown styles are skipped and true colors are
+approximated to the Xterm palette (or the 16 ANSI colors) when the system does not support them. In
+strict mode, those cases are reported as a Warnings error instead.
+
+Following the package convention, whenever a Warnings error is returned the original text is
+returned unmodified (and GetColor returns an empty code).
+
+Parameters:
+  - strict: Whether strict mode is enabled.
+
+Example:
+
+	c.SetStrictMode(true)
+	text, err := c.FormatText("Hello, world!", &c.Options{Styles: []string{"bold", "shiny"}})
+	if err != nil {
+		fmt.Println("Error:", err) // UNKNOWNSTYLE: unknown style: shiny
+	}
+*/
+func SetStrictMode(strict bool) {
+	strictMode = strict
+	if strict {
+		lenientMode = false
+	}
+}
+
+/*
+SetValidationMode sets how invalid formatting options are handled, so that unknown styles and
+invalid colors are treated consistently:
+  - ValidationDefault: unknown styles are skipped, invalid colors return an error.
+  - ValidationStrict: both return an error. Downgraded colors are not reported: that is the job
+    of SetStrictMode.
+  - ValidationLenient: both are skipped, and the rest of the options is formatted without an
+    error. Options.Lenient enables the same behavior for a single call.
+
+Parameters:
+  - mode: The validation mode.
+
+Example:
+
+	c.SetValidationMode(c.ValidationLenient)
+	text, err := c.FormatText("done", &c.Options{FgColor: "#GGGGGG", Styles: []string{"bold"}}) // bold, err is nil
+*/
+func SetValidationMode(mode ValidationMode) {
+	strictValidation = mode == ValidationStrict
+	lenientMode = mode == ValidationLenient
+}
+
+/*
+withoutInvalidColors returns a copy of the options whose invalid hex colors are cleared, or the
+options themselves if their colors are valid.
+
+Return:
+  - *Options: The options to render.
+*/
+func (o *Options) withoutInvalidColors() *Options {
+	invalid := func(col string) bool {
+		_, err := getColor(col)
+		return col != "" && err != nil
+	}
+	bgErr, fgErr := invalid(o.BgColor), invalid(o.FgColor)
+	if !bgErr && !fgErr {
+		return o
+	}
+	copied := *o
+	if bgErr {
+		copied.BgColor = ""
+	}
+	if fgErr {
+		copied.FgColor = ""
+	}
+	return &copied
+}
@@ -0,0 +1,121 @@
+package colorize
+
+import (
+	"errors"
+	"testing"
+)
+
+/* TestSetStrictMode tests the SetStrictMode function */
+func TestSetStrictMode(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer SetStrictMode(false)
+
+	SetStrictMode(true)
+
+	// unknown style
+	trueColor = true
+	text, err := FormatText("test", &Options{Styles: []string{"bold", "shiny"}})
+	var warnings Warnings
+	if !errors.As(err, &warnings) {
+		t.Fatal("Expected a Warnings error but got", err)
+	}
+	if len(warnings) != 1 || warnings[0].Name != "UNKNOWNSTYLE" {
+		t.Errorf("Expected an UNKNOWNSTYLE warning but got '%v'", warnings)
+	}
+	if text != "test" {
+		t.Error("Expected the original text to be returned")
+	}
+
+	// valid options
+	_, err = FormatText("test", &Options{FgColor: "#FF0000", Styles: []string{"bold"}})
+	if err != nil {
+		t.Error("Expected no error but got", err)
+	}
+
+	// downgraded colors
+	trueColor = false
+	xTerm = true
+	_, err = FormatText("test", &Options{FgColor: "#FF0000", BgColor: "#0000FF", Styles: []string{"shiny"}})
+	if !errors.As(err, &warnings) || len(warnings) != 3 {
+		t.Errorf("Expected 3 warnings but got '%v'", err)
+	}
+	code, err := GetColor("#FF0000", foreground)
+	if !errors.As(err, &warnings) || warnings[0].Name != "DOWNGRADE" || code != "" {
+		t.Errorf("Expected a DOWNGRADE warning but got '%v'", err)
+	}
+
+	// lenient mode
+	SetStrictMode(false)
+	_, err = FormatText("test", &Options{FgColor: "#FF0000", Styles: []string{"shiny"}})
+	if err != nil {
+		t.Error("Expected no error but got", err)
+	}
+}
+
+/* TestWarningsError tests the Error method of the Warnings type */
+func TestWarningsError(t *testing.T) {
+	warnings := Warnings{newWarning("A", "first"), newWarning("B", "second")}
+	if warnings.Error() != "A: first; B: second" {
+		t.Errorf("Expected error message to be 'A: first; B: second' but got '%s'", warnings.Error())
+	}
+}
+
+/* TestSetValidationMode tests the handling of invalid options by validation mode */
+func TestSetValidationMode(t *testing.T) {
+	// defer restore
+	defer restore()
+	defer SetValidationMode(ValidationDefault)
+	trueColor = true
+
+	opts := &Options{FgColor: "#GGGGGG", BgColor: "#0000FF", Styles: []string{"bold", "shiny"}}
+	valid, _ := FormatText("text", &Options{BgColor: "#0000FF", Styles: []string{"bold"}})
+
+	// default: invalid colors are errors, unknown styles are skipped
+	if got, err := FormatText("text", opts); !errors.Is(err, ErrInvalidHex) || got != "text" {
+		t.Errorf("Expected an invalid hex error but got %q, %v", got, err)
+	}
+
+	// strict: both are errors
+	SetValidationMode(ValidationStrict)
+	if _, err := FormatText("text", &Options{Styles: []string{"shiny"}}); !errors.Is(err, ErrInvalidStyle) {
+		t.Errorf("Expected an invalid style error but got %v", err)
+	}
+
+	// strict validation doesn't report downgrades
+	trueColor, xTerm = false, true
+	if _, err := FormatText("text", &Options{FgColor: "#FF0000", Styles: []string{"bold"}}); err != nil {
+		t.Errorf("Expected no error at the xterm level but got %v", err)
+	}
+	if code, err := GetColor("#FF0000", foreground); err != nil || code == "" {
+		t.Errorf("Expected an xterm code but got %q, %v", code, err)
+	}
+	trueColor = true
+
+	// lenient: both are skipped
+	SetValidationMode(ValidationLenient)
+	if got, err := FormatText("text", opts); err != nil || got != valid {
+		t.Errorf("Expected %q but got %q, %v", valid, got, err)
+	}
+	if code, err := GetColor("#GGGGGG", foreground); err != nil || code != "" {
+		t.Errorf("Expected an empty code but got %q, %v", code, err)
+	}
+	if got, err := FormatText("text", &Options{FgColor: "nope"}); err != nil || got != "text" {
+		t.Errorf("Expected %q but got %q, %v", "text", got, err)
+	}
+
+	// enabling strict mode disables the lenient mode
+	SetStrictMode(true)
+	if _, err := FormatText("text", opts); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+
+	// per call
+	SetStrictMode(false)
+	SetValidationMode(ValidationDefault)
+	lenient := *opts
+	lenient.Lenient = true
+	if got, err := FormatText("text", &lenient); err != nil || got != valid {
+		t.Errorf("Expected %q but got %q, %v", valid, got, err)
+	}
+}
@@ -0,0 +1,40 @@
+package colorize
+
+/*
+The Style type represents a text style. Unlike the style names of Options.Styles, a misspelled
+Style constant doesn't compile.
+*/
+type Style string
+
+const (
+	/* Supported text styles */
+	Bold      Style = "bold"
+	Italic    Style = "italic"
+	Underline Style = "underline"
+	Blink     Style = "blink"
+	Reverse   Style = "reverse"
+	Hidden    Style = "hidden"
+	Stroke    Style = "stroke"
+)
+
+/*
+Styles returns the names of the given styles, for Options.Styles and the functions taking style
+names (e.g., StyleText).
+
+Parameters:
+  - styles: The styles.
+
+Return:
+  - []string: The style names.
+
+Example:
+
+	text, err := c.FormatText("Hello, world!", &c.Options{FgColor: "#FF0000", Styles: c.Styles(c.Bold, c.Underline)})
+*/
+func Styles(styles ...Style) []string {
+	names := make([]string, len(styles))
+	for i, style := range styles {
+		names[i] = string(style)
+	}
+	return names
+}
@@ -0,0 +1,36 @@
+package colorize
+
+import (
+	"slices"
+	"testing"
+)
+
+/* TestStyles tests the Style constants and the Styles function */
+func TestStyles(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	// every constant is a supported style
+	for _, style := range []Style{Bold, Italic, Underline, Blink, Reverse, Hidden, Stroke} {
+		if _, ok := styles[string(style)]; !ok {
+			t.Errorf("Expected %q to be a supported style", style)
+		}
+	}
+	if len(styles) != 7 {
+		t.Errorf("Expected a constant for each of the %d supported styles", len(styles))
+	}
+
+	if names := Styles(Bold, Underline); !slices.Equal(names, []string{"bold", "underline"}) {
+		t.Errorf("Expected the style names but got %v", names)
+	}
+	if names := Styles(); names == nil || len(names) != 0 {
+		t.Errorf("Expected an empty list but got %v", names)
+	}
+
+	typed, _ := FormatText("x", &Options{Styles: Styles(Bold, Italic)})
+	plain, _ := FormatText("x", &Options{Styles: []string{"bold", "italic"}})
+	if typed != plain {
+		t.Errorf("Expected %q but got %q", plain, typed)
+	}
+}
@@ -0,0 +1,104 @@
+package colorize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// placeholder of the text when rendering the escape sequences of a Styler
+const stylerMarker = "\x00"
+
+/*
+The Styler type wraps text with escape sequences computed once (see NewStyler), for hot paths
+(e.g., logging) where FormatText would validate the colors and rebuild the sequences on every call.
+
+The sequences reflect the color level when the Styler was created: create it again after changing
+the level (e.g., with SetColorLevel).
+*/
+type Styler struct {
+	prefix string
+	suffix string
+}
+
+/*
+NewStyler computes the escape sequences of the given options once, at the current color level.
+
+Parameters:
+  - opts: The formatting options.
+
+Return:
+  - *Styler: The styler. On error, it's still usable and leaves the text unmodified.
+  - error: An error if the options are invalid or the system does not support colors (as
+    FormatText reports it).
+
+Example:
+
+	errStyle, _ := c.NewStyler(&c.Options{FgColor: "#FF5F5F", Styles: []string{"bold"}})
+	for _, line := range failures {
+		fmt.Println(errStyle.Style(line))
+	}
+*/
+func NewStyler(opts *Options) (*Styler, error) {
+	rendered, err := FormatText(stylerMarker, opts)
+	if err != nil {
+		return &Styler{}, err
+	}
+	prefix, suffix, _ := strings.Cut(rendered, stylerMarker)
+	return &Styler{prefix: prefix, suffix: suffix}, nil
+}
+
+/*
+Compile computes the escape sequences of the options once (see NewStyler).
+
+Return:
+  - *Styler: The styler. On error, it's still usable and leaves the text unmodified.
+  - error: An error if the options are invalid or the system does not support colors.
+*/
+func (o *Options) Compile() (*Styler, error) {
+	return NewStyler(o)
+}
+
+/*
+Style wraps the given text with the escape sequences of the styler.
+
+Parameters:
+  - text: The text to be formatted.
+
+Return:
+  - string: The formatted text.
+*/
+func (s *Styler) Style(text string) string {
+	if s.prefix == "" && s.suffix == "" {
+		return text
+	}
+	return s.prefix + text + s.suffix
+}
+
+/*
+Sprint formats its operands like fmt.Sprint and wraps the result with the escape sequences of the
+styler.
+
+Parameters:
+  - a: The operands.
+
+Return:
+  - string: The formatted text.
+*/
+func (s *Styler) Sprint(a ...any) string {
+	return s.Style(fmt.Sprint(a...))
+}
+
+/*
+Sprintf formats according to a format specifier like fmt.Sprintf and wraps the result with the
+escape sequences of the styler.
+
+Parameters:
+  - format: The format specifier.
+  - a: The operands.
+
+Return:
+  - string: The formatted text.
+*/
+func (s *Styler) Sprintf(format string, a ...any) string {
+	return s.Style(fmt.Sprintf(auditFormat(format, a), a...))
+}
@@ -0,0 +1,55 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestNewStyler tests that a Styler formats text as FormatText does */
+func TestNewStyler(t *testing.T) {
+	// defer restore
+	defer restore()
+
+	opts := []*Options{
+		{Styles: []string{"bold"}},
+		{FgColor: "#FF0000"},
+		{FgColor: "#FF0000", BgColor: "#00FF00", Styles: []string{"bold", "italic"}},
+		{FgColor256: Index256(202), PromptMode: PromptBash},
+	}
+	for _, profile := range benchProfiles[:3] {
+		trueColor, xTerm, ansi16 = profile.trueColor, profile.xTerm, profile.ansi16
+		for _, opt := range opts {
+			styler, err := opt.Compile()
+			if err != nil {
+				t.Fatalf("%s: expected no error but got %v", profile.name, err)
+			}
+			expected, _ := FormatText("Hello, world!", opt)
+			if got := styler.Style("Hello, world!"); got != expected {
+				t.Errorf("%s %+v: expected %q but got %q", profile.name, opt, expected, got)
+			}
+			if got := styler.Sprintf("Hello, %s!", "world"); got != expected {
+				t.Errorf("%s %+v: expected %q but got %q", profile.name, opt, expected, got)
+			}
+		}
+	}
+}
+
+/* TestNewStylerErrors tests that a Styler leaves the text unmodified on error */
+func TestNewStylerErrors(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	styler, err := NewStyler(&Options{FgColor: "#GGGGGG"})
+	if err == nil {
+		t.Error("Expected an error but got nil")
+	}
+	if got := styler.Sprint("a", 1); got != "a1" {
+		t.Errorf("Expected %q but got %q", "a1", got)
+	}
+
+	trueColor, xTerm, ansi16 = false, false, false
+	styler, err = NewStyler(&Options{FgColor: "#FF0000"})
+	if err == nil || styler.Style("plain") != "plain" {
+		t.Errorf("Expected an error and the text unmodified but got %v and %q", err, styler.Style("plain"))
+	}
+}
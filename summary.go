@@ -0,0 +1,90 @@
+package colorize
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// default separator of the entries of a summary line
+	defaultSummarySeparator = ", "
+	// default label of the total of a summary line
+	defaultSummaryTotalLabel = "total"
+)
+
+/* The SummaryEntry type represents an entry of a summary line (e.g., "3 passed") */
+type SummaryEntry struct {
+	Label string // label following the count (e.g., "passed")
+	Count int    // count of the entry
+	Role  string // theme role styling the entry when its count isn't zero (e.g., "summary.failed")
+}
+
+/* The SummaryOptions type represents the options of a summary line */
+type SummaryOptions struct {
+	HideZero   bool   // omits the entries with a zero count
+	Total      bool   // appends the sum of the counts
+	TotalLabel string // label of the total ("total" if empty)
+	Separator  string // separator of the entries (", " if empty)
+	Theme      Theme  // theme styling the line (DefaultTheme if nil)
+}
+
+/*
+Summary renders the familiar summary line of test runners and batch tools (e.g., "3 passed,
+1 failed, 2 skipped (6 total)"). Entries are styled with their role, except for zero counts which
+use the "count.zero" role; the separators and the total use the "summary.separator" and
+"summary.total" roles.
+
+Parameters:
+  - entries: The entries, in display order.
+  - opts: The summary options, or nil for the defaults.
+
+Return:
+  - string: The summary line, or an empty string if there's nothing to show.
+
+Example:
+
+	fmt.Println(c.Summary([]c.SummaryEntry{
+		{Label: "passed", Count: 3, Role: "summary.passed"},
+		{Label: "failed", Count: 1, Role: "summary.failed"},
+		{Label: "skipped", Count: 0, Role: "summary.skipped"},
+	}, &c.SummaryOptions{HideZero: true, Total: true}))
+	// 3 passed, 1 failed (4 total)
+*/
+func Summary(entries []SummaryEntry, opts *SummaryOptions) string {
+	if opts == nil {
+		opts = &SummaryOptions{}
+	}
+	theme := opts.Theme.orDefault()
+	separator := opts.Separator
+	if separator == "" {
+		separator = defaultSummarySeparator
+	}
+	totalLabel := opts.TotalLabel
+	if totalLabel == "" {
+		totalLabel = translate(defaultSummaryTotalLabel)
+	}
+
+	parts := []string{}
+	total := 0
+	for _, entry := range entries {
+		total += entry.Count
+		if entry.Count == 0 && opts.HideZero {
+			continue
+		}
+		role := entry.Role
+		if entry.Count == 0 {
+			role = "count.zero"
+		}
+		parts = append(parts, theme.Format(role, strconv.Itoa(entry.Count)+" "+entry.Label))
+	}
+
+	line := strings.Join(parts, theme.Format("summary.separator", separator))
+	if opts.Total {
+		totalText := theme.Format("summary.total", "("+strconv.Itoa(total)+" "+totalLabel+")")
+		if line == "" {
+			return totalText
+		}
+		line += " " + totalText
+	}
+	return line
+}
@@ -0,0 +1,42 @@
+package colorize
+
+import "testing"
+
+/* TestSummary tests the Summary function */
+func TestSummary(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	entries := []SummaryEntry{
+		{Label: "passed", Count: 3, Role: "summary.passed"},
+		{Label: "failed", Count: 0, Role: "summary.failed"},
+		{Label: "skipped", Count: 2, Role: "summary.skipped"},
+	}
+
+	got := Summary(entries, nil)
+	separator := DefaultTheme.Format("summary.separator", ", ")
+	expected := DefaultTheme.Format("summary.passed", "3 passed") + separator +
+		DefaultTheme.Format("count.zero", "0 failed") + separator +
+		DefaultTheme.Format("summary.skipped", "2 skipped")
+	if got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+
+	tests := []struct {
+		entries  []SummaryEntry
+		opts     *SummaryOptions
+		expected string
+	}{
+		{entries, &SummaryOptions{HideZero: true, Total: true}, "3 passed, 2 skipped (5 total)"},
+		{entries, &SummaryOptions{Separator: " | ", Total: true, TotalLabel: "tests"}, "3 passed | 0 failed | 2 skipped (5 tests)"},
+		{entries[1:2], &SummaryOptions{HideZero: true}, ""},
+		{entries[1:2], &SummaryOptions{HideZero: true, Total: true}, "(0 total)"},
+		{nil, nil, ""},
+	}
+	for _, test := range tests {
+		if got := stripANSI(Summary(test.entries, test.opts)); got != test.expected {
+			t.Errorf("Expected %q but got %q", test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package colorize
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+/* tailPollInterval is the delay between two reads of a followed source at its end */
+var tailPollInterval = 250 * time.Millisecond
+
+/* The HighlightRule type represents a rule highlighting the lines matching a pattern */
+type HighlightRule struct {
+	Pattern *regexp.Regexp // pattern to look for
+	Options *Options       // formatting options of the matches (or of the line)
+	Line    bool           // formats the whole line instead of the matches only
+}
+
+/*
+The ReSeeker interface is implemented by followed sources able to detect rotation or truncation
+(e.g., FileFollower). Tail calls ReSeek whenever it reaches the end of the source.
+*/
+type ReSeeker interface {
+	// ReSeek returns the reader to continue from if the source was rotated or truncated, or nil
+	// to keep waiting for new data on the current one.
+	ReSeek() (io.Reader, error)
+}
+
+/*
+highlightLine applies highlight rules to a line. The first matching Line rule formats the whole
+line; otherwise the matches of the other rules are formatted, earlier rules winning overlaps.
+
+Parameters:
+  - line: The line, without its line break.
+  - rules: The highlight rules.
+  - format: The function formatting a piece of text (e.g., the format method of a Colorizer).
+
+Return:
+  - string: The highlighted line.
+*/
+func highlightLine(line string, rules []HighlightRule, format func(text string, opts *Options) string) string {
+	type match struct {
+		start, end int
+		opts       *Options
+	}
+	var matches []match
+	for _, rule := range rules {
+		if rule.Pattern == nil {
+			continue
+		}
+		if rule.Line {
+			if rule.Pattern.MatchString(line) {
+				return format(line, rule.Options)
+			}
+			continue
+		}
+	next:
+		for _, loc := range rule.Pattern.FindAllStringIndex(line, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			for _, m := range matches {
+				if loc[0] < m.end && m.start < loc[1] {
+					continue next
+				}
+			}
+			matches = append(matches, match{loc[0], loc[1], rule.Options})
+		}
+	}
+	if len(matches) == 0 {
+		return line
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	builder := strings.Builder{}
+	last := 0
+	for _, m := range matches {
+		builder.WriteString(line[last:m.start])
+		builder.WriteString(format(line[m.start:m.end], m.opts))
+		last = m.end
+	}
+	builder.WriteString(line[last:])
+	return builder.String()
+}
+
+/*
+Tail copies the lines of a reader to a writer, applying highlight rules to every line (see
+HighlightRule). Colors follow the color level of the writer (see DetectLevel).
+
+If the reader implements ReSeeker (e.g., FileFollower), Tail follows it like "tail -f": at the end
+of the data it waits for more, switching to the reader returned by ReSeek when the source is
+rotated, until the context is canceled. Partial lines are held back until their line break
+arrives, or written as is when the source is rotated. Otherwise, Tail returns at the end of the data, writing the last line even without a line
+break.
+
+Parameters:
+  - ctx: The context, canceling the follow.
+  - r: The reader.
+  - rules: The highlight rules, applied in order.
+  - w: The writer.
+
+Return:
+  - error: The read or write error, if any, or the error of the context once canceled while
+    following.
+
+Example:
+
+	f, err := c.FollowFile("/var/log/app.log")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	err = c.Tail(ctx, f, []c.HighlightRule{
+		{Pattern: regexp.MustCompile(`ERROR`), Options: &c.Options{FgColor: "#FF0000"}, Line: true},
+		{Pattern: regexp.MustCompile(`\d+ms`), Options: &c.Options{Styles: []string{"bold"}}},
+	}, os.Stdout)
+*/
+func Tail(ctx context.Context, r io.Reader, rules []HighlightRule, w io.Writer) error {
+	colorizer := NewColorizer(w)
+	reseeker, follow := r.(ReSeeker)
+	reader := bufio.NewReader(r)
+	partial := ""
+
+	writeLine := func(line string) error {
+		_, err := colorizer.Writer().Write([]byte(highlightLine(line, rules, colorizer.format) + "\n"))
+		return err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		chunk, err := reader.ReadString('\n')
+		if line, complete := strings.CutSuffix(partial+chunk, "\n"); complete {
+			partial = ""
+			if err := writeLine(strings.TrimSuffix(line, "\r")); err != nil {
+				return err
+			}
+		} else {
+			partial = line
+		}
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		if !follow {
+			if partial != "" {
+				return writeLine(partial)
+			}
+			return nil
+		}
+
+		// end of the followed source: switch on rotation, or wait for more data
+		next, err := reseeker.ReSeek()
+		if err != nil {
+			return err
+		}
+		if next != nil {
+			// a partial line of the previous file is not continued by the next one
+			if partial != "" {
+				if err := writeLine(partial); err != nil {
+					return err
+				}
+				partial = ""
+			}
+			reader.Reset(next)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(tailPollInterval):
+		}
+	}
+}
+
+/*
+The FileFollower type follows a file by path, like "tail -F": it implements ReSeeker, reopening the
+file when it's replaced (e.g., by log rotation), once the replaced file is read to its end, and
+reading it again from the start when it's truncated.
+*/
+type FileFollower struct {
+	path   string
+	file   *os.File
+	offset int64
+}
+
+/*
+FollowFile opens a file to be followed by Tail.
+
+Parameters:
+  - path: The path of the file.
+
+Return:
+  - *FileFollower: The follower, reading the file from the start.
+  - error: The error opening the file, if any.
+*/
+func FollowFile(path string) (*FileFollower, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileFollower{path: path, file: file}, nil
+}
+
+/*
+Read reads from the current file.
+
+Parameters:
+  - p: The buffer.
+
+Return:
+  - int: The number of bytes read.
+  - error: The read error, if any (io.EOF at the end of the file).
+*/
+func (f *FileFollower) Read(p []byte) (int, error) {
+	n, err := f.file.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+/*
+ReSeek detects the rotation or the truncation of the file (see ReSeeker).
+
+Return:
+  - io.Reader: The follower itself if the file was reopened or rewound, nil otherwise.
+  - error: The error inspecting or rewinding the current file, if any.
+*/
+func (f *FileFollower) ReSeek() (io.Reader, error) {
+	current, err := f.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	latest, err := os.Stat(f.path)
+	if err != nil {
+		// the file is being rotated: keep waiting for the new one
+		return nil, nil
+	}
+
+	if !os.SameFile(current, latest) {
+		if current.Size() > f.offset {
+			// lines written before the rotation are read first, continuing the current file
+			return nil, nil
+		}
+		file, err := os.Open(f.path)
+		if err != nil {
+			return nil, nil
+		}
+		f.file.Close()
+		f.file, f.offset = file, 0
+		return f, nil
+	}
+	if latest.Size() < f.offset {
+		if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		f.offset = 0
+		return f, nil
+	}
+	return nil, nil
+}
+
+/*
+Close closes the current file.
+
+Return:
+  - error: The error closing the file, if any.
+*/
+func (f *FileFollower) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,260 @@
+package colorize
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// highlight rules of the tests
+var tailRules = []HighlightRule{
+	{Pattern: regexp.MustCompile(`ERROR`), Options: &Options{FgColor: "#FF0000"}, Line: true},
+	{Pattern: regexp.MustCompile(`\d+ms`), Options: &Options{Styles: []string{"bold"}}},
+	{Pattern: regexp.MustCompile(`\d+`), Options: &Options{Styles: []string{"underline"}}},
+}
+
+/* TestHighlightLine tests the highlightLine function */
+func TestHighlightLine(t *testing.T) {
+	// marks the formatted pieces with the first style of their options
+	format := func(text string, opts *Options) string {
+		if len(opts.Styles) == 0 {
+			return "<" + text + ">"
+		}
+		return "<" + opts.Styles[0] + ":" + text + ">"
+	}
+
+	tests := map[string]string{
+		"took 12ms, 3 retries": "took <bold:12ms>, <underline:3> retries",
+		"ERROR after 12ms":     "<ERROR after 12ms>",
+		"nothing":              "nothing",
+	}
+	for line, expected := range tests {
+		if got := highlightLine(line, tailRules, format); got != expected {
+			t.Errorf("Expected %q but got %q", expected, got)
+		}
+	}
+}
+
+/* TestTail tests the Tail function on a plain reader */
+func TestTail(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	SetColorLevel(LevelTrueColor)
+
+	buf := &bytes.Buffer{}
+	err := Tail(context.Background(), strings.NewReader("ok 5ms\r\nERROR\nlast"), tailRules, buf)
+	if err != nil {
+		t.Fatal("Expected no error but got", err)
+	}
+	bold, _ := FormatText("5ms", tailRules[1].Options)
+	red, _ := FormatText("ERROR", tailRules[0].Options)
+	if expected := "ok " + bold + "\n" + red + "\nlast\n"; buf.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, buf.String())
+	}
+
+	// canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Tail(ctx, strings.NewReader("line\n"), nil, buf); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+}
+
+/* lockedBuffer is a bytes.Buffer safe for concurrent use */
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+/* TestTailFollow tests the Tail function following a file, with partial lines and rotation */
+func TestTailFollow(t *testing.T) {
+	prevInterval := tailPollInterval
+	tailPollInterval = time.Millisecond
+	defer func() { tailPollInterval = prevInterval }()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("first\npart"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := FollowFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	out := &lockedBuffer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Tail(ctx, f, nil, out) }()
+
+	waitFor := func(expected string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for out.String() != expected {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %q but got %q", expected, out.String())
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// the partial line is held back until its line break
+	waitFor("first\n")
+	appendFile(t, path, "ial\n")
+	waitFor("first\npartial\n")
+
+	// rotation: the end of the old file is read first, and its partial line isn't continued
+	appendFile(t, path, "before rotation\nheld")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("rotated\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("first\npartial\nbefore rotation\nheld\nrotated\n")
+
+	// truncation
+	if err := os.WriteFile(path, []byte("new\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("first\npartial\nbefore rotation\nheld\nrotated\nnew\n")
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Expected context.Canceled but got %v", err)
+	}
+}
+
+/* TestFileFollowerRotation tests that a rotated file is read to its end before switching */
+func TestFileFollowerRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := FollowFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if data, _ := io.ReadAll(f); string(data) != "a\n" {
+		t.Fatalf("Unexpected data: %q", data)
+	}
+
+	// written after the last read, right before the rotation
+	appendFile(t, path, "b\n")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("c\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// the current file continues
+	if next, err := f.ReSeek(); err != nil || next != nil {
+		t.Fatalf("Expected to continue the current file but got %v, %v", next, err)
+	}
+	if data, _ := io.ReadAll(f); string(data) != "b\n" {
+		t.Errorf("Expected %q but got %q", "b\n", data)
+	}
+
+	// then the new one is opened
+	next, err := f.ReSeek()
+	if err != nil || next == nil {
+		t.Fatalf("Expected to switch to the new file but got %v, %v", next, err)
+	}
+	if data, _ := io.ReadAll(next); string(data) != "c\n" {
+		t.Errorf("Expected %q but got %q", "c\n", data)
+	}
+	if next, _ := f.ReSeek(); next != nil {
+		t.Error("Expected to wait for new data")
+	}
+}
+
+/* appendFile appends data to a file */
+func appendFile(t *testing.T, path string, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+/* rotatingFollower is a FileFollower whose file is rotated right before its first ReSeek */
+type rotatingFollower struct {
+	*FileFollower
+	rotate func()
+}
+
+func (r *rotatingFollower) ReSeek() (io.Reader, error) {
+	if r.rotate != nil {
+		r.rotate()
+		r.rotate = nil
+	}
+	return r.FileFollower.ReSeek()
+}
+
+/* TestTailFollowPartialRotation tests that a partial line completed right before a rotation is kept whole */
+func TestTailFollowPartialRotation(t *testing.T) {
+	prevInterval := tailPollInterval
+	tailPollInterval = time.Millisecond
+	defer func() { tailPollInterval = prevInterval }()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("first\npart"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := FollowFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r := &rotatingFollower{FileFollower: f, rotate: func() {
+		appendFile(t, path, "ial\n")
+		if err := os.Rename(path, path+".1"); err != nil {
+			t.Error(err)
+		}
+		if err := os.WriteFile(path, []byte("rotated\n"), 0o644); err != nil {
+			t.Error(err)
+		}
+	}}
+
+	out := &lockedBuffer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Tail(ctx, r, nil, out) }()
+
+	expected := "first\npartial\nrotated\n"
+	deadline := time.Now().Add(2 * time.Second)
+	for out.String() != expected && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+	if out.String() != expected {
+		t.Errorf("Expected %q but got %q", expected, out.String())
+	}
+}
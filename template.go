@@ -0,0 +1,68 @@
+package colorize
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+/*
+TemplateFuncs returns template functions for colorizing text/template output (e.g., -o template
+flags of kubectl-style CLIs). Texts are the last argument, so that the functions can be used in
+pipelines:
+  - color: {{ .Name | color "#FF0000" }} sets the foreground color.
+  - bg: {{ .Name | bg "#0000FF" }} sets the background color.
+  - style: {{ .Name | style "bold" "italic" }} applies one or more styles.
+  - role: {{ .Branch | role "git.branch" }} formats with a role of the theme.
+  - badge: {{ .Status | badge "git.staged" }} formats with a role of the theme, padded with a
+    space on each side.
+  - pad: {{ .Name | color "#FF0000" | pad 12 }} pads the text to the given width (escape sequences
+    don't count).
+
+Values are converted to text with fmt.Sprint. Formatting errors never stop the template: the text
+is printed unmodified.
+
+Parameters:
+  - theme: The theme used by role and badge, or nil for DefaultTheme.
+
+Return:
+  - template.FuncMap: The template functions.
+
+Example:
+
+	tmpl := template.Must(template.New("status").Funcs(c.TemplateFuncs(nil)).Parse(
+		`{{ .Branch | role "git.branch" }} {{ .Message | style "italic" }}`))
+	tmpl.Execute(os.Stdout, status)
+*/
+func TemplateFuncs(theme Theme) template.FuncMap {
+	theme = theme.orDefault()
+
+	return template.FuncMap{
+		"color": func(hex string, v any) string {
+			return applyOptions(fmt.Sprint(v), &Options{FgColor: hex})
+		},
+		"bg": func(hex string, v any) string {
+			return applyOptions(fmt.Sprint(v), &Options{BgColor: hex})
+		},
+		"style": func(args ...any) string {
+			if len(args) == 0 {
+				return ""
+			}
+			names := make([]string, len(args)-1)
+			for i, arg := range args[:len(args)-1] {
+				names[i] = fmt.Sprint(arg)
+			}
+			return StyleText(fmt.Sprint(args[len(args)-1]), names)
+		},
+		"role": func(role string, v any) string {
+			return theme.Format(role, fmt.Sprint(v))
+		},
+		"badge": func(role string, v any) string {
+			return theme.Format(role, " "+fmt.Sprint(v)+" ")
+		},
+		"pad": func(width int, v any) string {
+			text := fmt.Sprint(v)
+			return text + strings.Repeat(" ", max(width-visibleWidth(text), 0))
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package colorize
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+/* TestTemplateFuncs tests the TemplateFuncs function */
+func TestTemplateFuncs(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	theme := Theme{"ok": {Styles: []string{"bold"}}}
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{`{{ .Name | color "#FF0000" }}`, getTCCode(&color{255, 0, 0}, foreground) + "app" + reset},
+		{`{{ .Name | bg "#FF0000" }}`, getTCCode(&color{255, 0, 0}, background) + "app" + reset},
+		{`{{ .Name | style "bold" "italic" }}`, styles["bold"] + styles["italic"] + "app" + reset},
+		{`{{ .Count | role "ok" }}`, styles["bold"] + "3" + reset},
+		{`{{ .Name | badge "ok" }}`, styles["bold"] + " app " + reset},
+		{`{{ .Name | role "missing" }}`, "app"},
+		{`[{{ .Name | style "bold" | pad 5 }}]`, "[" + styles["bold"] + "app" + reset + "  ]"},
+		{`{{ .Name | color "bad" }}`, "app"},
+		{`{{ style }}`, ""},
+	}
+	for _, test := range tests {
+		tmpl := template.Must(template.New("test").Funcs(TemplateFuncs(theme)).Parse(test.src))
+		builder := strings.Builder{}
+		if err := tmpl.Execute(&builder, map[string]any{"Name": "app", "Count": 3}); err != nil {
+			t.Fatal(err)
+		}
+		if builder.String() != test.expected {
+			t.Errorf("%s: expected %q but got %q", test.src, test.expected, builder.String())
+		}
+	}
+
+	// default theme
+	if TemplateFuncs(nil)["role"].(func(string, any) string)("git.branch", "main") != DefaultTheme.Format("git.branch", "main") {
+		t.Error("Expected the default theme")
+	}
+}
@@ -0,0 +1,189 @@
+package colorize
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// magic numbers of compiled terminfo files (16-bit and 32-bit numbers)
+	terminfoMagic   = 0432
+	terminfoMagic32 = 01036
+	// index of the "colors" numeric capability
+	terminfoColorsIndex = 13
+)
+
+var (
+	// TERM values of terminals supporting true color
+	trueColorTerms = []string{"xterm-kitty", "xterm-ghostty", "alacritty", "wezterm", "foot", "contour", "iterm2"}
+	// TERM_PROGRAM values of terminals supporting true color
+	trueColorPrograms = []string{"iTerm.app", "WezTerm", "vscode", "ghostty"}
+	// TERM prefixes of terminals supporting the 16 basic colors
+	basicColorTerms = []string{"xterm", "screen", "tmux", "rxvt", "linux", "ansi", "cygwin", "konsole", "putty", "eterm", "vt220"}
+	// default directories of the terminfo database
+	terminfoDefaultDirs = []string{"/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo", "/usr/lib/terminfo"}
+)
+
+/*
+terminfoDirs returns the directories of the terminfo database, in lookup order: $TERMINFO,
+~/.terminfo, $TERMINFO_DIRS and the system directories.
+
+Return:
+  - []string: The directories.
+*/
+func terminfoDirs() []string {
+	dirs := []string{}
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	for _, dir := range filepath.SplitList(os.Getenv("TERMINFO_DIRS")) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return append(dirs, terminfoDefaultDirs...)
+}
+
+/*
+parseTerminfoColors reads the "colors" capability of a compiled terminfo entry.
+
+Parameters:
+  - data: The content of the terminfo file.
+
+Return:
+  - int: The number of colors, or -1 if the entry is malformed or doesn't define it.
+*/
+func parseTerminfoColors(data []byte) int {
+	if len(data) < 12 {
+		return -1
+	}
+	header := make([]int, 6)
+	for i := range header {
+		header[i] = int(binary.LittleEndian.Uint16(data[2*i:]))
+	}
+
+	numSize := 2
+	switch header[0] {
+	case terminfoMagic:
+	case terminfoMagic32:
+		numSize = 4
+	default:
+		return -1
+	}
+
+	// the numbers follow the header, the names and the booleans, aligned on an even offset
+	offset := 12 + header[1] + header[2]
+	offset += offset % 2
+	if header[3] <= terminfoColorsIndex {
+		return -1
+	}
+	offset += terminfoColorsIndex * numSize
+	if offset+numSize > len(data) {
+		return -1
+	}
+
+	if numSize == 4 {
+		return int(int32(binary.LittleEndian.Uint32(data[offset:])))
+	}
+	return int(int16(binary.LittleEndian.Uint16(data[offset:])))
+}
+
+/*
+terminfoColors looks up the number of colors of a terminal in the terminfo database.
+
+Parameters:
+  - term: The name of the terminal (TERM).
+
+Return:
+  - int: The number of colors, or -1 if the terminal isn't found.
+*/
+func terminfoColors(term string) int {
+	if term == "" || strings.ContainsAny(term, `/\`) {
+		return -1
+	}
+	for _, dir := range terminfoDirs() {
+		// entries are grouped by first letter, or by its hexadecimal code (macOS)
+		for _, sub := range []string{term[:1], fmt.Sprintf("%x", term[0])} {
+			if data, err := os.ReadFile(filepath.Join(dir, sub, term)); err == nil {
+				return parseTerminfoColors(data)
+			}
+		}
+	}
+	return -1
+}
+
+/*
+termLevel classifies a terminal by its name: well-known names and patterns first (e.g.,
+"*-256color", "*-direct"), then the "colors" capability of its terminfo entry.
+
+"xterm" is classified as a 256-color terminal, since virtually every emulator advertising it
+supports the Xterm palette.
+
+Parameters:
+  - term: The name of the terminal (TERM).
+
+Return:
+  - Level: The color level of the terminal.
+*/
+func termLevel(term string) Level {
+	term = strings.ToLower(term)
+	switch {
+	case term == "" || term == "dumb":
+		return LevelNone
+	case strings.HasSuffix(term, "-direct") || strings.Contains(term, "truecolor") || slices.Contains(trueColorTerms, term):
+		return LevelTrueColor
+	case term == "xterm" || strings.Contains(term, "256color"):
+		return LevelAnsi256
+	}
+
+	switch colors := terminfoColors(term); {
+	case colors >= 1<<24:
+		return LevelTrueColor
+	case colors >= 256:
+		return LevelAnsi256
+	case colors >= 8:
+		return LevelAnsi16
+	case colors >= 0:
+		return LevelNone
+	}
+
+	for _, prefix := range basicColorTerms {
+		if strings.HasPrefix(term, prefix) {
+			return LevelAnsi16
+		}
+	}
+	if strings.Contains(term, "color") {
+		return LevelAnsi16
+	}
+	return LevelNone
+}
+
+/*
+detectTermLevel detects the color level of the terminal from the environment: COLORTERM
+("truecolor" or "24bit"), TERM_PROGRAM (terminals known to support true color), terminal
+multiplexers (see multiplexerLevel) and TERM (see termLevel).
+
+Return:
+  - Level: The color level of the terminal.
+  - string: The variable the level was detected from (e.g., TERM="xterm-256color").
+*/
+func detectTermLevel() (Level, string) {
+	if value := os.Getenv("COLORTERM"); value == "truecolor" || value == "24bit" {
+		return LevelTrueColor, fmt.Sprintf("COLORTERM=%q", value)
+	}
+	if value := os.Getenv("TERM_PROGRAM"); slices.Contains(trueColorPrograms, value) {
+		return LevelTrueColor, fmt.Sprintf("TERM_PROGRAM=%q", value)
+	}
+	term := os.Getenv("TERM")
+	if multiplexer := detectMultiplexer(); multiplexer != "" {
+		return multiplexerLevel(multiplexer, term)
+	}
+	return termLevel(term), fmt.Sprintf("TERM=%q", term)
+}
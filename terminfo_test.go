@@ -0,0 +1,127 @@
+package colorize
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+terminfoEntry builds a compiled terminfo entry defining the "colors" capability.
+
+Parameters:
+  - magic: The magic number of the entry.
+  - colors: The number of colors.
+
+Return:
+  - []byte: The entry.
+*/
+func terminfoEntry(magic int, colors int) []byte {
+	names := "fake|fake terminal\x00" // odd length, so the numbers are aligned
+	numSize := 2
+	if magic == terminfoMagic32 {
+		numSize = 4
+	}
+	data := []byte{}
+	for _, v := range []int{magic, len(names), 0, terminfoColorsIndex + 1, 0, 0} {
+		data = binary.LittleEndian.AppendUint16(data, uint16(v))
+	}
+	data = append(data, names...)
+	data = append(data, 0)
+	for i := 0; i <= terminfoColorsIndex; i++ {
+		value := -1
+		if i == terminfoColorsIndex {
+			value = colors
+		}
+		if numSize == 4 {
+			data = binary.LittleEndian.AppendUint32(data, uint32(int32(value)))
+		} else {
+			data = binary.LittleEndian.AppendUint16(data, uint16(int16(value)))
+		}
+	}
+	return data
+}
+
+/* TestParseTerminfoColors tests the parseTerminfoColors function */
+func TestParseTerminfoColors(t *testing.T) {
+	if colors := parseTerminfoColors(terminfoEntry(terminfoMagic, 256)); colors != 256 {
+		t.Errorf("Expected 256 colors but got %d", colors)
+	}
+	if colors := parseTerminfoColors(terminfoEntry(terminfoMagic32, 1<<24)); colors != 1<<24 {
+		t.Errorf("Expected 16777216 colors but got %d", colors)
+	}
+
+	// malformed entries
+	for _, data := range [][]byte{nil, []byte("not a terminfo entry"), terminfoEntry(terminfoMagic, 8)[:20]} {
+		if colors := parseTerminfoColors(data); colors != -1 {
+			t.Errorf("Expected -1 for %q but got %d", data, colors)
+		}
+	}
+}
+
+/* TestTermLevel tests the termLevel function, with a terminfo database */
+func TestTermLevel(t *testing.T) {
+	dir := t.TempDir()
+	entries := map[string][]byte{
+		"f/fake-mono":  terminfoEntry(terminfoMagic, -1),
+		"f/fake-eight": terminfoEntry(terminfoMagic, 8),
+		"66/fake-rich": terminfoEntry(terminfoMagic, 256),
+		"f/fake-true":  terminfoEntry(terminfoMagic32, 1<<24),
+	}
+	for name, data := range entries {
+		os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755)
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("TERMINFO", dir)
+
+	tests := []struct {
+		term  string
+		level Level
+	}{
+		{"", LevelNone},
+		{"dumb", LevelNone},
+		{"xterm", LevelAnsi256},
+		{"xterm-256color", LevelAnsi256},
+		{"screen-256color", LevelAnsi256},
+		{"xterm-direct", LevelTrueColor},
+		{"xterm-kitty", LevelTrueColor},
+		{"alacritty", LevelTrueColor},
+		{"fake-mono", LevelNone},
+		{"fake-eight", LevelAnsi16},
+		{"fake-rich", LevelAnsi256},
+		{"fake-true", LevelTrueColor},
+		{"linux-unknown-variant", LevelAnsi16},
+		{"vt52-unknown", LevelNone},
+	}
+	for _, test := range tests {
+		if level := termLevel(test.term); level != test.level {
+			t.Errorf("TERM=%q: expected level %d but got %d", test.term, test.level, level)
+		}
+	}
+}
+
+/* TestDetectTermLevel tests the detectTermLevel function */
+func TestDetectTermLevel(t *testing.T) {
+	tests := []struct {
+		env    map[string]string
+		level  Level
+		source string
+	}{
+		{map[string]string{"COLORTERM": "truecolor", "TERM": "dumb"}, LevelTrueColor, `COLORTERM="truecolor"`},
+		{map[string]string{"COLORTERM": "24bit"}, LevelTrueColor, `COLORTERM="24bit"`},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app", "TERM": "xterm-256color"}, LevelTrueColor, `TERM_PROGRAM="iTerm.app"`},
+		{map[string]string{"TERM": "xterm-256color"}, LevelAnsi256, `TERM="xterm-256color"`},
+		{map[string]string{"TERM": "dumb"}, LevelNone, `TERM="dumb"`},
+	}
+	for _, test := range tests {
+		for _, name := range []string{"COLORTERM", "TERM_PROGRAM", "TERM", "TMUX", "STY"} {
+			t.Setenv(name, test.env[name])
+		}
+		if level, source := detectTermLevel(); level != test.level || source != test.source {
+			t.Errorf("%v: expected %d (%s) but got %d (%s)", test.env, test.level, test.source, level, source)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package colorize
+
+/*
+The Theme type maps semantic roles (e.g., "git.branch") to formatting options, so that helpers
+render consistently across an application and can be restyled in a single place.
+
+Roles missing from a theme are rendered as plain text.
+*/
+type Theme map[string]*Options
+
+/*
+DefaultTheme is the theme used by the helpers of the package when no theme is provided.
+
+It can be modified (or replaced) at program start to restyle every helper at once.
+*/
+var DefaultTheme = Theme{
+	"git.staged":             {FgColor: "#5FD700"},
+	"git.unstaged":           {FgColor: "#FF5F5F"},
+	"git.untracked":          {FgColor: "#FF5F5F"},
+	"git.conflict":           {FgColor: "#FF0000", Styles: []string{"bold"}},
+	"git.ignored":            {FgColor: "#808080"},
+	"git.branch":             {FgColor: "#5FD700", Styles: []string{"bold"}},
+	"git.detached":           {FgColor: "#FF5F5F", Styles: []string{"bold"}},
+	"git.ahead":              {FgColor: "#5FD700"},
+	"git.behind":             {FgColor: "#FF5F5F"},
+	"section.title":          {Styles: []string{"bold"}},
+	"section.rule":           {FgColor: "#808080"},
+	"diff.added":             {FgColor: "#5FD700"},
+	"diff.removed":           {FgColor: "#FF5F5F"},
+	"diff.changed":           {FgColor: "#FFD700"},
+	"diff.added.inline":      {FgColor: "#5FD700", Styles: []string{"reverse"}},
+	"diff.removed.inline":    {FgColor: "#FF5F5F", Styles: []string{"reverse"}},
+	"conflict.marker":        {FgColor: "#808080", Styles: []string{"bold"}},
+	"conflict.ours":          {FgColor: "#5FD7FF"},
+	"conflict.ours.inline":   {FgColor: "#5FD7FF", Styles: []string{"reverse"}},
+	"conflict.base":          {FgColor: "#808080"},
+	"conflict.theirs":        {FgColor: "#FFAF5F"},
+	"conflict.theirs.inline": {FgColor: "#FFAF5F", Styles: []string{"reverse"}},
+	"error.message":          {FgColor: "#FF5F5F", Styles: []string{"bold"}},
+	"error.cause":            {FgColor: "#FF5F5F"},
+	"error.location":         {FgColor: "#808080"},
+	"error.tree":             {FgColor: "#808080"},
+	"example.command":        {FgColor: "#00FFFF"},
+	"example.comment":        {FgColor: "#808080"},
+	"inline.code":            {FgColor: "#5FD7FF"},
+	"inline.quote":           {FgColor: "#FFD75F"},
+	"inline.path":            {FgColor: "#5F87FF", Styles: []string{"underline"}},
+	"count.zero":             {FgColor: "#808080"},
+	"count.normal":           {Styles: []string{"bold"}},
+	"count.warning":          {FgColor: "#FFD700", Styles: []string{"bold"}},
+	"count.error":            {FgColor: "#FF5F5F", Styles: []string{"bold"}},
+	"summary.passed":         {FgColor: "#5FD700"},
+	"summary.failed":         {FgColor: "#FF5F5F", Styles: []string{"bold"}},
+	"summary.skipped":        {FgColor: "#FFD700"},
+	"summary.separator":      {FgColor: "#808080"},
+	"summary.total":          {FgColor: "#808080"},
+	"retry.attempt":          {FgColor: "#808080"},
+	"retry.error":            {FgColor: "#FF5F5F"},
+	"retry.wait":             {FgColor: "#FFD700"},
+	"retry.failed":           {FgColor: "#FF0000", Styles: []string{"bold"}},
+	"retry.success":          {FgColor: "#5FD700"},
+	"dump.key":               {FgColor: "#5FD7FF"},
+	"dump.value":             {Styles: []string{"bold"}},
+	"dump.secret":            {FgColor: "#FFAF5F"},
+	"dump.note":              {FgColor: "#808080"},
+	"secret.mask":            {FgColor: "#808080"},
+	"link":                   {FgColor: "#5F87FF", Styles: []string{"underline"}},
+	"ruler":                  {FgColor: "#808080"},
+	"whitespace":             {FgColor: "#585858"},
+	"control":                {FgColor: "#D787D7"},
+	"grep.line":              {FgColor: "#5F8700"},
+	"grep.separator":         {FgColor: "#585858"},
+	"log.level.debug":        {FgColor: "#808080"},
+	"log.level.info":         {FgColor: "#5FD700"},
+	"log.level.warn":         {FgColor: "#FFD700"},
+	"log.level.error":        {FgColor: "#FF5F5F", Styles: []string{"bold"}},
+	"log.key":                {FgColor: "#5FD7FF"},
+}
+
+/*
+Format formats the given text with the options of the given role.
+
+If the role is not part of the theme, or formatting fails (e.g., the system does not support
+colors), the text is returned unmodified.
+
+Parameters:
+  - role: The semantic role (e.g., "git.branch").
+  - text: The text to be formatted.
+
+Return:
+  - string: The formatted text.
+
+Example:
+
+	fmt.Println(c.DefaultTheme.Format("git.branch", "main"))
+*/
+func (t Theme) Format(role string, text string) string {
+	opts, ok := t[role]
+	if !ok || text == "" {
+		return text
+	}
+	formatted, _ := FormatText(text, opts)
+	return formatted
+}
+
+/*
+orDefault returns the theme itself, or DefaultTheme if the theme is nil.
+
+Return:
+  - Theme: The theme to use.
+*/
+func (t Theme) orDefault() Theme {
+	if t == nil {
+		return DefaultTheme
+	}
+	return t
+}
@@ -0,0 +1,193 @@
+package colorize
+
+import (
+	"fmt"
+	imagecolor "image/color"
+	"slices"
+	"sort"
+)
+
+/* The ThemeLimits type represents the limits enforced when compiling a theme (see Theme.Compile) */
+type ThemeLimits struct {
+	MaxColors int // maximum number of distinct colors, foreground and background (no limit if 0)
+	MaxStyles int // maximum number of distinct styles (no limit if 0)
+}
+
+/* themeUsage counts the roles using a color or a style, in order of first use */
+type themeUsage struct {
+	keys   []string
+	counts map[string]int
+}
+
+/*
+add counts a use of the given key.
+
+Parameters:
+  - key: The color or style.
+*/
+func (u *themeUsage) add(key string) {
+	if u.counts[key] == 0 {
+		u.keys = append(u.keys, key)
+	}
+	u.counts[key]++
+}
+
+/*
+kept returns the most used keys, up to the given limit (ties are broken by order of first use).
+
+Parameters:
+  - limit: The number of keys to keep, or 0 for all of them.
+
+Return:
+  - []string: The kept keys.
+*/
+func (u *themeUsage) kept(limit int) []string {
+	keys := slices.Clone(u.keys)
+	sort.SliceStable(keys, func(i, j int) bool { return u.counts[keys[i]] > u.counts[keys[j]] })
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
+
+/*
+themeColor resolves the color of an entry of a theme, in order of precedence: the Xterm palette
+index, the color of the image/color package, then the hex color.
+
+Parameters:
+  - index: The Xterm palette index, or nil.
+  - value: The color of the image/color package, or nil.
+  - hex: The hex color, or "".
+
+Return:
+  - *color: A pointer to the color struct representing the RGB color, or nil if there is no color.
+  - error: An error if the hex color is invalid.
+*/
+func themeColor(index *uint8, value imagecolor.Color, hex string) (*color, error) {
+	switch {
+	case index != nil:
+		return xtermToRGB(*index), nil
+	case value != nil:
+		return imageToColor(value), nil
+	case hex == "":
+		return nil, nil
+	}
+	return getColor(hex)
+}
+
+/*
+Compile validates a theme and enforces the given limits on the number of distinct colors and
+styles it uses (e.g., to comply with a style guide).
+
+When the theme exceeds a limit, the most used colors (or styles) are kept: the other colors
+collapse to the nearest kept color, and the other styles are dropped. The compiled theme is a copy,
+with its colors normalized to "#RRGGBB" (Xterm palette indexes and colors of the image/color package
+included); the theme itself isn't modified.
+
+Parameters:
+  - limits: The limits to enforce.
+
+Return:
+  - Theme: The compiled theme, or the theme unmodified if one of its colors is invalid.
+  - error: An error if one of the colors is invalid, or a Warnings error (see SetStrictMode)
+    listing the entries that collapsed. In the latter case, the compiled theme is returned along
+    with the error.
+
+Example:
+
+	theme, err := myTheme.Compile(c.ThemeLimits{MaxColors: 4})
+	var warnings c.Warnings
+	if errors.As(err, &warnings) {
+		for _, w := range warnings {
+			log.Println(w.Msg) // role "log.debug": foreground color #5F87FF collapsed to #5FD7FF
+		}
+	} else if err != nil {
+		log.Fatal(err)
+	}
+*/
+func (t Theme) Compile(limits ThemeLimits) (Theme, error) {
+	roles := make([]string, 0, len(t))
+	for role := range t {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	// resolve the colors and count the uses of every color and style
+	resolved := map[string][2]*color{}
+	colors := themeUsage{counts: map[string]int{}}
+	styleUsage := themeUsage{counts: map[string]int{}}
+	for _, role := range roles {
+		opts := t[role]
+		if opts == nil {
+			continue
+		}
+		fg, err := themeColor(opts.FgColor256, opts.Fg, opts.FgColor)
+		if err != nil {
+			return t, fmt.Errorf("role %q: %w", role, err)
+		}
+		bg, err := themeColor(opts.BgColor256, opts.Bg, opts.BgColor)
+		if err != nil {
+			return t, fmt.Errorf("role %q: %w", role, err)
+		}
+		resolved[role] = [2]*color{fg, bg}
+		for _, col := range resolved[role] {
+			if col != nil {
+				colors.add(RGB(col.r, col.g, col.b))
+			}
+		}
+		for _, style := range opts.Styles {
+			styleUsage.add(style)
+		}
+	}
+
+	keptColors := colors.kept(limits.MaxColors)
+	keptStyles := styleUsage.kept(limits.MaxStyles)
+
+	var warnings Warnings
+	// collapse returns the kept color nearest to the given color
+	collapse := func(role string, ctx ColorContext, col *color) string {
+		if col == nil {
+			return ""
+		}
+		normalized := RGB(col.r, col.g, col.b)
+		if slices.Contains(keptColors, normalized) {
+			return normalized
+		}
+		nearest, nearestDist := "", -1
+		for _, kept := range keptColors {
+			keptCol, _ := getColor(kept)
+			if dist := colorDistance(col, keptCol); nearestDist < 0 || dist < nearestDist {
+				nearest, nearestDist = kept, dist
+			}
+		}
+		warnings = append(warnings, newWarning("COLLAPSE", fmt.Sprintf("role %q: %s color %s collapsed to %s", role, ctx, normalized, nearest)))
+		return nearest
+	}
+
+	compiled := make(Theme, len(t))
+	for _, role := range roles {
+		opts := t[role]
+		if opts == nil {
+			compiled[role] = nil
+			continue
+		}
+		copied := *opts
+		copied.FgColor = collapse(role, foreground, resolved[role][0])
+		copied.BgColor = collapse(role, background, resolved[role][1])
+		copied.FgColor256, copied.BgColor256, copied.Fg, copied.Bg = nil, nil, nil, nil
+		copied.Styles = nil
+		for _, style := range opts.Styles {
+			if slices.Contains(keptStyles, style) {
+				copied.Styles = append(copied.Styles, style)
+			} else {
+				warnings = append(warnings, newWarning("COLLAPSE", fmt.Sprintf("role %q: style %s dropped", role, style)))
+			}
+		}
+		compiled[role] = &copied
+	}
+
+	if len(warnings) > 0 {
+		return compiled, warnings
+	}
+	return compiled, nil
+}
@@ -0,0 +1,81 @@
+package colorize
+
+import (
+	"errors"
+	imagecolor "image/color"
+	"slices"
+	"testing"
+)
+
+/* TestThemeCompile tests the Compile method of Theme */
+func TestThemeCompile(t *testing.T) {
+	theme := Theme{
+		"error":   {FgColor: "#FF0000", Styles: []string{"bold"}},
+		"fatal":   {FgColor: "#f00", BgColor: "#FFFFFF", Styles: []string{"bold", "underline"}},
+		"warning": {FgColor: "#FF5F00", Styles: []string{"bold"}},
+		"info":    {FgColor: "eeeeff"},
+		"plain":   nil,
+	}
+
+	// no limits: the colors are normalized
+	compiled, err := theme.Compile(ThemeLimits{})
+	if err != nil {
+		t.Fatal("Expected no error but got", err)
+	}
+	if compiled["fatal"].FgColor != "#FF0000" || compiled["info"].FgColor != "#EEEEFF" {
+		t.Errorf("Expected normalized colors but got %+v", compiled)
+	}
+	if _, ok := compiled["plain"]; !ok {
+		t.Error("Expected the nil roles to be kept")
+	}
+
+	// limits: #FF0000 (used twice) and the first used colors are kept
+	compiled, err = theme.Compile(ThemeLimits{MaxColors: 2, MaxStyles: 1})
+	var warnings Warnings
+	if !errors.As(err, &warnings) || len(warnings) != 3 {
+		t.Fatalf("Expected 3 warnings but got %v", err)
+	}
+	if compiled["warning"].FgColor != "#FF0000" || compiled["info"].FgColor != "#FFFFFF" {
+		t.Errorf("Expected the colors to collapse to the nearest kept color but got %+v, %+v", compiled["warning"], compiled["info"])
+	}
+	if !slices.Equal(compiled["fatal"].Styles, []string{"bold"}) {
+		t.Errorf("Expected the least used style to be dropped but got %v", compiled["fatal"].Styles)
+	}
+	expected := `COLLAPSE: role "fatal": style underline dropped`
+	if warnings[1].Error() != expected && warnings[0].Error() != expected {
+		t.Errorf("Expected %q among the warnings but got %v", expected, warnings)
+	}
+
+	// the theme itself isn't modified
+	if theme["warning"].FgColor != "#FF5F00" || len(theme["fatal"].Styles) != 2 {
+		t.Error("Expected the theme to be unmodified")
+	}
+
+	// invalid color
+	theme["broken"] = &Options{FgColor: "#FF00"}
+	if compiled, err = theme.Compile(ThemeLimits{}); err == nil || compiled["broken"].FgColor != "#FF00" {
+		t.Errorf("Expected an error and the theme unmodified but got %v", err)
+	}
+}
+
+/* TestThemeCompileColorFields tests that Compile counts the Xterm indexes and image/color colors */
+func TestThemeCompileColorFields(t *testing.T) {
+	theme := Theme{
+		"error":  {FgColor256: Index256(196), Bg: imagecolor.RGBA{R: 0, G: 0, B: 255, A: 255}},
+		"banner": {FgColor: "#FF0000"},
+	}
+
+	compiled, err := theme.Compile(ThemeLimits{MaxColors: 1})
+	var warnings Warnings
+	if !errors.As(err, &warnings) || len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning but got %v", err)
+	}
+	expected := `COLLAPSE: role "error": background color #0000FF collapsed to #FF0000`
+	if warnings[0].Error() != expected {
+		t.Errorf("Expected %q but got %q", expected, warnings[0].Error())
+	}
+	got := compiled["error"]
+	if got.FgColor != "#FF0000" || got.BgColor != "#FF0000" || got.FgColor256 != nil || got.Bg != nil {
+		t.Errorf("Expected the colors to be normalized to hex but got %+v", got)
+	}
+}
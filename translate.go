@@ -0,0 +1,46 @@
+package colorize
+
+/* translator is the message catalog hook set with SetTranslator, nil for the built-in English */
+var translator func(key string) string
+
+/*
+SetTranslator sets a message catalog hook translating the built-in strings of the package, so that
+non-English CLIs aren't stuck with hard-coded English. The keys are the English strings
+themselves; those containing verbs (e.g., "retrying in %s") are format specifiers, whose
+translations must keep the same verbs in the same order. The keys are:
+  - RetryStatus: "attempt %d", "attempt %d/%d", "%s succeeded", "%s failed:",
+    "%s failed: %v, giving up", "retrying now" and "retrying in %s".
+  - Summary: "total" (the default total label).
+  - DumpFlags: "(default)".
+  - FormatErrorChain: "%d errors".
+
+Parameters:
+  - fn: The function returning the translation of a key, or an empty string to keep the English
+    text. nil restores the built-in English.
+
+Example:
+
+	catalog := map[string]string{"retrying in %s": "nouvel essai dans %s", "total": "au total"}
+	c.SetTranslator(func(key string) string { return catalog[key] })
+*/
+func SetTranslator(fn func(key string) string) {
+	translator = fn
+}
+
+/*
+translate returns the translation of a built-in string (see SetTranslator).
+
+Parameters:
+  - key: The English string.
+
+Return:
+  - string: The translation, or the key itself if there is none.
+*/
+func translate(key string) string {
+	if translator != nil {
+		if translated := translator(key); translated != "" {
+			return translated
+		}
+	}
+	return key
+}
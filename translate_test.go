@@ -0,0 +1,45 @@
+package colorize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+/* TestSetTranslator tests the translation of the built-in strings */
+func TestSetTranslator(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor, xTerm, ansi16 = false, false, false
+
+	catalog := map[string]string{
+		"attempt %d/%d":  "essai %d/%d",
+		"%s failed:":     "%s échoué :",
+		"retrying in %s": "nouvel essai dans %s",
+		"total":          "au total",
+		"%d errors":      "%d erreurs",
+	}
+	SetTranslator(func(key string) string { return catalog[key] })
+
+	if got, expected := RetryStatus(2, 5, 2*time.Second, errors.New("refused")), "essai 2/5 échoué : refused, nouvel essai dans 2s"; got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+	if got := Summary([]SummaryEntry{{Label: "ok", Count: 1}, {Label: "ko", Count: 1}}, &SummaryOptions{Total: true}); !strings.Contains(got, "au total") {
+		t.Errorf("Expected a translated total but got %q", got)
+	}
+	if got := errorMessage(errors.New("x"), []error{errors.New("a"), errors.New("b")}); got != "2 erreurs" {
+		t.Errorf("Expected %q but got %q", "2 erreurs", got)
+	}
+
+	// untranslated keys are kept in English
+	if got := RetryStatus(1, 0, 0, nil); got != "attempt 1 succeeded" {
+		t.Errorf("Expected %q but got %q", "attempt 1 succeeded", got)
+	}
+
+	// nil restores English
+	SetTranslator(nil)
+	if got := translate("total"); got != "total" {
+		t.Errorf("Expected %q but got %q", "total", got)
+	}
+}
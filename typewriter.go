@@ -0,0 +1,83 @@
+package colorize
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+/*
+Typewriter writes the text formatted with the given options to the writer progressively, one
+grapheme at a time with the given delay in between (typewriter effect).
+
+When the writer isn't a terminal (e.g., output piped to a file), or the motion policy isn't
+MotionFull (see SetMotionPolicy), the text is written at once.
+
+Parameters:
+  - w: The writer.
+  - text: The text to be revealed.
+  - delay: The delay between graphemes.
+  - opts: The formatting options, or nil for plain text.
+
+Return:
+  - error: An error if the text could not be formatted or written, nil otherwise.
+
+Example:
+
+	c.Typewriter(os.Stdout, "Welcome aboard!\n", 40*time.Millisecond, &c.Options{FgColor: "#00FF00"})
+*/
+func Typewriter(w io.Writer, text string, delay time.Duration, opts *Options) error {
+	return TypewriterContext(context.Background(), w, text, delay, opts)
+}
+
+/*
+TypewriterContext is like Typewriter but stops when the context is done. The styles are reset
+before returning, so a cancelled reveal doesn't leave the terminal styled.
+
+Parameters:
+  - ctx: The context.
+  - w: The writer.
+  - text: The text to be revealed.
+  - delay: The delay between graphemes.
+  - opts: The formatting options, or nil for plain text.
+
+Return:
+  - error: The context error if it was cancelled, an error if the text could not be formatted or
+    written, nil otherwise.
+*/
+func TypewriterContext(ctx context.Context, w io.Writer, text string, delay time.Duration, opts *Options) error {
+	formatted := text
+	if opts != nil {
+		var err error
+		if formatted, err = formatText(text, opts, terminalAllows(w)); err != nil {
+			return err
+		}
+	}
+
+	if delay <= 0 || motionPolicy != MotionFull || !writerIsTerminal(w) {
+		_, err := io.WriteString(w, formatted)
+		return err
+	}
+
+	styled := false
+	for _, seg := range segmentize(formatted) {
+		if _, err := io.WriteString(w, seg.text); err != nil {
+			return err
+		}
+		if seg.escape {
+			styled = seg.text != reset
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			if styled {
+				io.WriteString(w, reset)
+			}
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return nil
+}
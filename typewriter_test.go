@@ -0,0 +1,76 @@
+package colorize
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+)
+
+/* TestTypewriter tests the Typewriter and TypewriterContext functions */
+func TestTypewriter(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+	defer func(prev func(io.Writer) bool) { writerIsTerminal = prev }(writerIsTerminal)
+	defer SetMotionPolicy(motionPolicy)
+	motionPolicy = MotionFull
+
+	bold := &Options{Styles: []string{"bold"}}
+
+	// no TTY: written at once
+	var buf bytes.Buffer
+	start := time.Now()
+	if err := Typewriter(&buf, "hello", time.Second, bold); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("Expected no delay without a terminal")
+	}
+	if buf.String() != styles["bold"]+"hello"+reset {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// TTY: revealed progressively
+	writerIsTerminal = func(io.Writer) bool { return true }
+	buf.Reset()
+	if err := Typewriter(&buf, "héllo", time.Millisecond, bold); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != styles["bold"]+"héllo"+reset {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// static output without motion
+	SetMotionPolicy(MotionNone)
+	buf.Reset()
+	if err := Typewriter(&buf, "hello", time.Hour, bold); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != styles["bold"]+"hello"+reset {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+	SetMotionPolicy(MotionFull)
+
+	// cancellation resets the styles
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	buf.Reset()
+	if err := TypewriterContext(ctx, &buf, "hello", time.Hour, bold); err != context.Canceled {
+		t.Errorf("Expected context.Canceled but got %v", err)
+	}
+	if buf.String() != styles["bold"]+"h"+reset {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// invalid options
+	if err := Typewriter(&buf, "hello", 0, &Options{FgColor: "bad"}); err == nil {
+		t.Error("Expected an error")
+	}
+
+	// buffers aren't terminals
+	if IsTerminal(&buf) {
+		t.Error("Expected a buffer not to be a terminal")
+	}
+}
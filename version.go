@@ -0,0 +1,104 @@
+package colorize
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+/* version is the version of the package */
+const version = "0.1.0"
+
+/* The Feature type identifies a terminal capability that can be queried with HasFeature */
+type Feature int
+
+const (
+	/* Supported features */
+	FeatureTrueColor  Feature = iota // 24-bit colors
+	FeatureColor256                  // the Xterm 256-color palette
+	FeatureStyles                    // text styles (bold, italic...)
+	FeatureHyperlinks                // OSC 8 hyperlinks
+	FeatureSixel                     // sixel graphics
+	FeatureOSC52                     // clipboard access through OSC 52
+)
+
+var (
+	// terminals (TERM_PROGRAM, or TERM for the ones setting their own) known to support hyperlinks
+	hyperlinkTerms = []string{"iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "xterm-kitty", "foot", "alacritty"}
+	// terminals known to support sixel graphics
+	sixelTerms = []string{"iTerm.app", "WezTerm", "mlterm", "foot", "contour", "yaft-256color"}
+	// terminals known to support OSC 52
+	osc52Terms = []string{"iTerm.app", "WezTerm", "ghostty", "xterm-kitty", "foot", "alacritty", "contour"}
+)
+
+/*
+Version returns the version of the package, so that downstream libraries can branch on it at
+runtime.
+
+Return:
+  - string: The version (e.g., "0.1.0").
+*/
+func Version() string {
+	return version
+}
+
+/*
+knownTerminal reports whether the terminal (identified by TERM_PROGRAM or TERM) is part of the given
+list.
+
+Parameters:
+  - terms: The list of terminals.
+
+Return:
+  - bool: true if the terminal is part of the list, false otherwise.
+*/
+func knownTerminal(terms []string) bool {
+	program := os.Getenv("TERM_PROGRAM")
+	term := os.Getenv("TERM")
+	for _, t := range terms {
+		if t == program || t == term {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+HasFeature reports whether the terminal supports the given feature, so that downstream libraries
+don't need to duplicate the detection logic of the package.
+
+The color features follow the color profile detected by the package. The other features are
+detected from the terminal identification (TERM_PROGRAM and TERM, plus WT_SESSION for Windows
+Terminal and VTE_VERSION for VTE based terminals): terminals not known to support a feature are
+reported as not supporting it.
+
+Parameters:
+  - f: The feature.
+
+Return:
+  - bool: true if the feature is supported, false otherwise.
+
+Example:
+
+	if c.HasFeature(c.FeatureHyperlinks) {
+		// print a hyperlink
+	}
+*/
+func HasFeature(f Feature) bool {
+	switch f {
+	case FeatureTrueColor:
+		return trueColor && !noColor
+	case FeatureColor256:
+		return (trueColor || xTerm) && ColorsEnabled()
+	case FeatureStyles:
+		return ColorsEnabled()
+	case FeatureHyperlinks:
+		vte, _ := strconv.Atoi(os.Getenv("VTE_VERSION"))
+		return knownTerminal(hyperlinkTerms) || os.Getenv("WT_SESSION") != "" || vte >= 5000
+	case FeatureSixel:
+		return knownTerminal(sixelTerms) || strings.Contains(os.Getenv("TERM"), "sixel")
+	case FeatureOSC52:
+		return knownTerminal(osc52Terms) || os.Getenv("WT_SESSION") != ""
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestVersion tests the Version function */
+func TestVersion(t *testing.T) {
+	if Version() != version {
+		t.Errorf("Expected %s but got %s", version, Version())
+	}
+}
+
+/* TestHasFeature tests the HasFeature function */
+func TestHasFeature(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = false
+	xTerm = true
+	for _, name := range []string{"TERM", "TERM_PROGRAM", "WT_SESSION", "VTE_VERSION"} {
+		t.Setenv(name, "")
+	}
+
+	if HasFeature(FeatureTrueColor) || !HasFeature(FeatureColor256) || !HasFeature(FeatureStyles) {
+		t.Error("Expected the color features to follow the color profile")
+	}
+	xTerm = false
+	ansi16 = true
+	if HasFeature(FeatureColor256) || !HasFeature(FeatureStyles) {
+		t.Error("Expected the 16-color profile not to support the Xterm palette")
+	}
+	xTerm = true
+	if HasFeature(FeatureHyperlinks) || HasFeature(FeatureSixel) || HasFeature(FeatureOSC52) {
+		t.Error("Expected unknown terminals not to support any feature")
+	}
+
+	t.Setenv("TERM_PROGRAM", "WezTerm")
+	if !HasFeature(FeatureHyperlinks) || !HasFeature(FeatureSixel) || !HasFeature(FeatureOSC52) {
+		t.Error("Expected WezTerm to support every feature")
+	}
+
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("VTE_VERSION", "6003")
+	if !HasFeature(FeatureHyperlinks) || HasFeature(FeatureOSC52) {
+		t.Error("Expected VTE terminals to support hyperlinks only")
+	}
+
+	t.Setenv("TERM", "xterm-sixel")
+	if !HasFeature(FeatureSixel) {
+		t.Error("Expected sixel support")
+	}
+
+	if HasFeature(Feature(-1)) {
+		t.Error("Expected unknown features to be unsupported")
+	}
+}
@@ -0,0 +1,75 @@
+package colorize
+
+import "strings"
+
+// default glyphs of the whitespace characters
+var defaultWhitespaceGlyphs = map[rune]string{'\t': "→", ' ': "·", '\r': "␍", '\n': "¶"}
+
+/* The WhitespaceOptions type represents the options for visualizing whitespace */
+type WhitespaceOptions struct {
+	Tab       string // glyph replacing tabs ("→" if empty)
+	Space     string // glyph replacing spaces ("·" if empty)
+	Return    string // glyph replacing carriage returns ("␍" if empty)
+	Newline   string // glyph marking the end of lines ("¶" if empty)
+	AllSpaces bool   // replaces every space, not only the trailing ones
+	Theme     Theme  // theme styling the glyphs ("whitespace" role, DefaultTheme if nil)
+}
+
+/*
+ShowWhitespace makes the invisible characters of a text visible, replacing tabs, carriage returns
+and trailing spaces with dim glyphs and marking the end of lines, as diff and lint tools do.
+
+Parameters:
+  - s: The text.
+  - opts: The options, or nil for the defaults.
+
+Return:
+  - string: The text with its whitespace visible. Line breaks are kept after the newline glyph.
+
+Example:
+
+	fmt.Println(c.ShowWhitespace("key:\tvalue  \n", nil))
+	// key:→value··¶
+*/
+func ShowWhitespace(s string, opts *WhitespaceOptions) string {
+	if opts == nil {
+		opts = &WhitespaceOptions{}
+	}
+	glyphs := map[rune]string{'\t': opts.Tab, ' ': opts.Space, '\r': opts.Return, '\n': opts.Newline}
+	for r, glyph := range defaultWhitespaceGlyphs {
+		if glyphs[r] == "" {
+			glyphs[r] = glyph
+		}
+	}
+	theme := opts.Theme.orDefault()
+
+	builder := strings.Builder{}
+	run := strings.Builder{}
+	// consecutive glyphs are styled at once
+	flush := func() {
+		if run.Len() > 0 {
+			builder.WriteString(theme.Format("whitespace", run.String()))
+			run.Reset()
+		}
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		trailing := len(strings.TrimRight(line, " \t\r"))
+		for j, r := range line {
+			if r == '\t' || r == '\r' || (r == ' ' && (opts.AllSpaces || j >= trailing)) {
+				run.WriteString(glyphs[r])
+				continue
+			}
+			flush()
+			builder.WriteRune(r)
+		}
+		if i < len(lines)-1 {
+			run.WriteString(glyphs['\n'])
+			flush()
+			builder.WriteString("\n")
+		}
+	}
+	flush()
+	return builder.String()
+}
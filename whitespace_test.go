@@ -0,0 +1,33 @@
+package colorize
+
+import "testing"
+
+/* TestShowWhitespace tests the ShowWhitespace function */
+func TestShowWhitespace(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	tests := []struct {
+		s        string
+		opts     *WhitespaceOptions
+		expected string
+	}{
+		{"key:\tvalue  \n", nil, "key:→value··¶\n"},
+		{"a b \r\nc", nil, "a b·␍¶\nc"},
+		{"a b\t", &WhitespaceOptions{AllSpaces: true}, "a·b→"},
+		{"a \n", &WhitespaceOptions{Tab: ">", Space: "_", Newline: "$"}, "a_$\n"},
+		{"plain", nil, "plain"},
+	}
+	for _, test := range tests {
+		if got := stripANSI(ShowWhitespace(test.s, test.opts)); got != test.expected {
+			t.Errorf("%q: expected %q but got %q", test.s, test.expected, got)
+		}
+	}
+
+	// consecutive glyphs are styled at once
+	expected := "x" + DefaultTheme.Format("whitespace", "··¶") + "\n"
+	if got := ShowWhitespace("x  \n", nil); got != expected {
+		t.Errorf("Expected %q but got %q", expected, got)
+	}
+}
@@ -0,0 +1,386 @@
+package colorize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// regex for ANSI escape sequences (CSI and OSC)
+	ansiRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+	// wide (two cells) rune ranges: East Asian wide/fullwidth characters and emoji
+	wideRanges = [][2]rune{
+		{0x1100, 0x115F},
+		{0x231A, 0x231B},
+		{0x2329, 0x232A},
+		{0x23E9, 0x23EC},
+		{0x23F0, 0x23F0},
+		{0x23F3, 0x23F3},
+		{0x25FD, 0x25FE},
+		{0x2614, 0x2615},
+		{0x2648, 0x2653},
+		{0x267F, 0x267F},
+		{0x2693, 0x2693},
+		{0x26A1, 0x26A1},
+		{0x26AA, 0x26AB},
+		{0x26BD, 0x26BE},
+		{0x26C4, 0x26C5},
+		{0x26CE, 0x26CE},
+		{0x26D4, 0x26D4},
+		{0x26EA, 0x26EA},
+		{0x26F2, 0x26F3},
+		{0x26F5, 0x26F5},
+		{0x26FA, 0x26FA},
+		{0x26FD, 0x26FD},
+		{0x2705, 0x2705},
+		{0x270A, 0x270B},
+		{0x2728, 0x2728},
+		{0x274C, 0x274C},
+		{0x274E, 0x274E},
+		{0x2753, 0x2755},
+		{0x2757, 0x2757},
+		{0x2795, 0x2797},
+		{0x27B0, 0x27B0},
+		{0x27BF, 0x27BF},
+		{0x2B1B, 0x2B1C},
+		{0x2B50, 0x2B50},
+		{0x2B55, 0x2B55},
+		{0x2E80, 0x303E},
+		{0x3041, 0x33FF},
+		{0x3400, 0x4DBF},
+		{0x4E00, 0x9FFF},
+		{0xA000, 0xA4CF},
+		{0xAC00, 0xD7A3},
+		{0xF900, 0xFAFF},
+		{0xFE30, 0xFE4F},
+		{0xFF00, 0xFF60},
+		{0xFFE0, 0xFFE6},
+		{0x1F004, 0x1F004},
+		{0x1F0CF, 0x1F0CF},
+		{0x1F18E, 0x1F18E},
+		{0x1F191, 0x1F19A},
+		{0x1F200, 0x1F2FF},
+		{0x1F300, 0x1F64F},
+		{0x1F680, 0x1F6FF},
+		{0x1F7E0, 0x1F7EB},
+		{0x1F90C, 0x1F9FF},
+		{0x1FA70, 0x1FAFF},
+		{0x20000, 0x3FFFD},
+	}
+)
+
+const (
+	zeroWidthJoiner = '\u200d'
+	ellipsis        = "…"
+)
+
+/*
+stripANSI removes every ANSI escape sequence from the given string.
+
+Parameters:
+  - s: The string to strip.
+
+Return:
+  - string: The string without escape sequences.
+*/
+func stripANSI(s string) string {
+	return ansiRegex.ReplaceAllString(s, "")
+}
+
+/*
+runeWidth returns the number of terminal cells the given rune occupies: 0 for control and
+combining characters, 2 for wide characters and emoji, and 1 otherwise.
+
+Parameters:
+  - r: The rune to measure.
+
+Return:
+  - int: The width of the rune in cells.
+*/
+func runeWidth(r rune) int {
+	if r == 0 || unicode.IsControl(r) || isExtender(r) {
+		return 0
+	}
+	for _, rng := range wideRanges {
+		if r < rng[0] {
+			break
+		}
+		if r <= rng[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+/*
+isExtender reports whether the given rune extends the previous grapheme cluster (combining marks,
+variation selectors, the zero width joiner and emoji skin tone modifiers).
+
+Parameters:
+  - r: The rune to check.
+
+Return:
+  - bool: Whether the rune extends the previous grapheme.
+*/
+func isExtender(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || r == zeroWidthJoiner ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) || (r >= 0xE0020 && r <= 0xE007F)
+}
+
+/*
+isRegionalIndicator reports whether the given rune is a regional indicator (flag emoji half).
+
+Parameters:
+  - r: The rune to check.
+
+Return:
+  - bool: Whether the rune is a regional indicator.
+*/
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+/* The segment type represents either an escape sequence or a single grapheme cluster */
+type segment struct {
+	text   string
+	width  int  // width of the grapheme in cells (0 for escape sequences)
+	escape bool // whether the segment is an escape sequence
+}
+
+/*
+segmentize splits the given string into escape sequences and grapheme clusters.
+
+The grapheme segmentation is an approximation of Unicode UAX #29 covering combining marks,
+variation selectors, emoji modifiers, ZWJ sequences and flags.
+
+Parameters:
+  - s: The string to split.
+
+Return:
+  - []segment: The segments, in order.
+*/
+func segmentize(s string) []segment {
+	segments := []segment{}
+	locs := ansiRegex.FindAllStringIndex(s, -1)
+
+	pos := 0
+	for _, loc := range locs {
+		segments = appendGraphemes(segments, s[pos:loc[0]])
+		segments = append(segments, segment{text: s[loc[0]:loc[1]], escape: true})
+		pos = loc[1]
+	}
+
+	return appendGraphemes(segments, s[pos:])
+}
+
+/*
+appendGraphemes splits the given plain string (without escape sequences) into grapheme clusters
+and appends them to the provided segments.
+
+Parameters:
+  - segments: The segments to append to.
+  - s: The plain string to split.
+
+Return:
+  - []segment: The resulting segments.
+*/
+func appendGraphemes(segments []segment, s string) []segment {
+	start := 0
+	for start < len(s) {
+		r, size := utf8.DecodeRuneInString(s[start:])
+		end := start + size
+		width := runeWidth(r)
+		joined := false
+
+		if isRegionalIndicator(r) && end < len(s) {
+			// flags are made of two regional indicators
+			next, nextSize := utf8.DecodeRuneInString(s[end:])
+			if isRegionalIndicator(next) {
+				end += nextSize
+				width = 2
+			}
+		}
+
+		for end < len(s) {
+			next, nextSize := utf8.DecodeRuneInString(s[end:])
+			if joined {
+				// the rune following a zero width joiner belongs to the cluster
+				joined = false
+				end += nextSize
+				continue
+			}
+			if !isExtender(next) {
+				break
+			}
+			if next == 0xFE0F && width == 1 {
+				// emoji presentation selector
+				width = 2
+			}
+			joined = next == zeroWidthJoiner
+			end += nextSize
+		}
+
+		segments = append(segments, segment{text: s[start:end], width: width})
+		start = end
+	}
+
+	return segments
+}
+
+/*
+visibleWidth returns the number of terminal cells the given string occupies, ignoring escape
+sequences. Short strings are cached (see widthCache).
+
+Parameters:
+  - s: The string to measure.
+
+Return:
+  - int: The width of the string in cells.
+*/
+func visibleWidth(s string) int {
+	return cachedWidth(s)
+}
+
+/*
+measureWidth measures the number of terminal cells the given string occupies, ignoring escape
+sequences.
+
+Parameters:
+  - s: The string to measure.
+
+Return:
+  - int: The width of the string in cells.
+*/
+func measureWidth(s string) int {
+	width := 0
+	for _, seg := range segmentize(s) {
+		width += seg.width
+	}
+	return width
+}
+
+/*
+ShortenMiddle shortens the given string to fit in width terminal cells, replacing its middle part
+with an ellipsis ("begin…end"). It's suited for paths and URLs, where both ends carry meaning.
+
+The function is ANSI-aware (escape sequences don't count towards the width and are preserved, so
+styles keep applying to the remaining text) and grapheme-aware (emoji, flags and combining
+characters are never split). Strings that already fit are returned unmodified.
+
+Parameters:
+  - s: The string to shorten.
+  - width: The maximum width, in terminal cells.
+
+Return:
+  - string: The shortened string.
+
+Example:
+
+	fmt.Println(c.ShortenMiddle("/home/user/projects/colorize/colorize.go", 20)) // /home/user…lorize.go
+*/
+func ShortenMiddle(s string, width int) string {
+	return ShortenMiddleWith(s, width, ellipsis)
+}
+
+/*
+ShortenMiddleWith works like ShortenMiddle, using the provided ellipsis (e.g., "...") instead of "…".
+
+If width is too small to fit the ellipsis, the ellipsis itself is shortened.
+
+Parameters:
+  - s: The string to shorten.
+  - width: The maximum width, in terminal cells.
+  - ellipsis: The string replacing the middle part.
+
+Return:
+  - string: The shortened string.
+*/
+func ShortenMiddleWith(s string, width int, ellipsis string) string {
+	if width <= 0 {
+		return ""
+	}
+
+	segments := segmentize(s)
+	total := 0
+	for _, seg := range segments {
+		total += seg.width
+	}
+	if total <= width {
+		return s
+	}
+
+	ellipsisWidth := visibleWidth(ellipsis)
+	if ellipsisWidth >= width {
+		return takeWidth(segmentize(ellipsis), width)
+	}
+
+	available := width - ellipsisWidth
+	leftBudget := (available + 1) / 2
+	rightBudget := available - leftBudget
+
+	// left part
+	left := 0
+	leftWidth := 0
+	for left < len(segments) && leftWidth+segments[left].width <= leftBudget {
+		leftWidth += segments[left].width
+		left++
+	}
+
+	// right part
+	right := len(segments)
+	rightWidth := 0
+	for right > left && rightWidth+segments[right-1].width <= rightBudget {
+		rightWidth += segments[right-1].width
+		right--
+	}
+
+	builder := strings.Builder{}
+	for _, seg := range segments[:left] {
+		builder.WriteString(seg.text)
+	}
+	builder.WriteString(ellipsis)
+	// escape sequences of the dropped part are kept so the right part keeps its styles
+	for _, seg := range segments[left:right] {
+		if seg.escape {
+			builder.WriteString(seg.text)
+		}
+	}
+	for _, seg := range segments[right:] {
+		builder.WriteString(seg.text)
+	}
+
+	return builder.String()
+}
+
+/*
+takeWidth returns the leading segments fitting in the given width, keeping every escape sequence.
+
+Parameters:
+  - segments: The segments to take from.
+  - width: The maximum width, in terminal cells.
+
+Return:
+  - string: The resulting string.
+*/
+func takeWidth(segments []segment, width int) string {
+	builder := strings.Builder{}
+	used := 0
+	full := false
+	for _, seg := range segments {
+		if seg.escape {
+			builder.WriteString(seg.text)
+			continue
+		}
+		if full || used+seg.width > width {
+			full = true
+			continue
+		}
+		used += seg.width
+		builder.WriteString(seg.text)
+	}
+	return builder.String()
+}
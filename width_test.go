@@ -0,0 +1,70 @@
+package colorize
+
+import (
+	"testing"
+)
+
+/* TestVisibleWidth tests the visibleWidth function */
+func TestVisibleWidth(t *testing.T) {
+	cases := map[string]int{
+		"":                             0,
+		"hello":                        5,
+		"\033[1mhello\033[0m":          5,
+		"日本":                           4,
+		"é":                           1, // e + combining acute accent
+		"👍🏽":                           2, // emoji + skin tone modifier
+		"👩‍💻":                          2, // ZWJ sequence
+		"🇪🇸":                           2, // flag
+		"\033]8;;url\033\\link":        4, // OSC 8 hyperlink
+		"\033[38;2;255;0;0mred\033[0m": 3,
+	}
+
+	for s, want := range cases {
+		if got := visibleWidth(s); got != want {
+			t.Errorf("Expected width of %q to be %d but got %d", s, want, got)
+		}
+	}
+}
+
+/* TestShortenMiddle tests the ShortenMiddle function */
+func TestShortenMiddle(t *testing.T) {
+	// fitting strings are returned unmodified
+	if ShortenMiddle("short", 10) != "short" {
+		t.Error("Expected the string to be returned unmodified")
+	}
+
+	// plain strings
+	got := ShortenMiddle("/home/user/projects/colorize/colorize.go", 20)
+	if got != "/home/user…lorize.go" {
+		t.Errorf("Expected '/home/user…lorize.go' but got '%s'", got)
+	}
+	if visibleWidth(got) != 20 {
+		t.Errorf("Expected width 20 but got %d", visibleWidth(got))
+	}
+
+	// escape sequences are preserved
+	got = ShortenMiddle("\033[1mabcdefghij\033[0m", 5)
+	if got != "\033[1mab…ij\033[0m" {
+		t.Errorf("Expected the escape sequences to be preserved but got %q", got)
+	}
+
+	// graphemes are never split
+	got = ShortenMiddle("👩‍💻👩‍💻👩‍💻👩‍💻", 5)
+	if got != "👩‍💻…👩‍💻" {
+		t.Errorf("Expected graphemes not to be split but got %q", got)
+	}
+
+	// too narrow
+	if ShortenMiddle("abcdef", 0) != "" {
+		t.Error("Expected an empty string for a zero width")
+	}
+	if ShortenMiddleWith("abcdef", 2, "...") != ".." {
+		t.Error("Expected the ellipsis to be shortened")
+	}
+
+	// custom ellipsis
+	got = ShortenMiddleWith("abcdefghij", 7, "...")
+	if got != "ab...ij" {
+		t.Errorf("Expected 'ab...ij' but got '%s'", got)
+	}
+}
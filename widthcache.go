@@ -0,0 +1,120 @@
+package colorize
+
+import (
+	"container/list"
+	"sync"
+)
+
+const (
+	// maximum number of strings in the width cache
+	widthCacheSize = 4096
+	// longer strings are measured without caching (they're rarely repeated)
+	widthCacheMaxLen = 256
+)
+
+/* widthMemo caches the widths measured by visibleWidth */
+var widthMemo = newWidthCache(widthCacheSize)
+
+/* The widthEntry type represents an entry of the width cache */
+type widthEntry struct {
+	key   string
+	width int
+}
+
+/*
+The widthCache type is a bounded LRU cache of string widths, so that layouts measuring the same
+headers and labels over and over don't segment them every time. It's safe for concurrent use.
+*/
+type widthCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // most recently used first
+	items    map[string]*list.Element
+}
+
+/*
+newWidthCache creates a new width cache holding up to capacity strings.
+
+Parameters:
+  - capacity: The maximum number of strings.
+
+Return:
+  - *widthCache: The newly created cache.
+*/
+func newWidthCache(capacity int) *widthCache {
+	return &widthCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element, capacity),
+	}
+}
+
+/*
+get returns the cached width of the given string.
+
+Parameters:
+  - s: The string.
+
+Return:
+  - int: The cached width.
+  - bool: Whether the string was cached.
+*/
+func (c *widthCache) get(s string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[s]
+	if !ok {
+		return 0, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*widthEntry).width, true
+}
+
+/*
+put caches the width of the given string, evicting the least recently used string if the cache is
+full.
+
+Parameters:
+  - s: The string.
+  - width: Its width.
+*/
+func (c *widthCache) put(s string, width int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[s]; ok {
+		c.order.MoveToFront(elem)
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*widthEntry).key)
+	}
+	c.items[s] = c.order.PushFront(&widthEntry{key: s, width: width})
+}
+
+/*
+cachedWidth returns the width of the given string, measuring it with measureWidth and caching it if
+it's not cached yet.
+
+Parameters:
+  - s: The string to measure.
+
+Return:
+  - int: The width of the string in cells.
+*/
+func cachedWidth(s string) int {
+	if len(s) > widthCacheMaxLen {
+		return measureWidth(s)
+	}
+	if width, ok := widthMemo.get(s); ok {
+		record(MetricWidthCacheHits, 1)
+		return width
+	}
+	record(MetricWidthCacheMisses, 1)
+	width := measureWidth(s)
+	widthMemo.put(s, width)
+	return width
+}
@@ -0,0 +1,63 @@
+package colorize
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+/* TestWidthCache tests the eviction of the widthCache type */
+func TestWidthCache(t *testing.T) {
+	cache := newWidthCache(2)
+	cache.put("a", 1)
+	cache.put("b", 2)
+	cache.get("a") // b is now the least recently used
+	cache.put("c", 3)
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if width, ok := cache.get("a"); !ok || width != 1 {
+		t.Errorf("Expected a to be cached with width 1 but got %d, %v", width, ok)
+	}
+	if width, ok := cache.get("c"); !ok || width != 3 {
+		t.Errorf("Expected c to be cached with width 3 but got %d, %v", width, ok)
+	}
+}
+
+/* TestCachedWidth tests the cachedWidth function and its metrics */
+func TestCachedWidth(t *testing.T) {
+	counters := &Counters{}
+	SetMetricsSink(counters)
+	defer SetMetricsSink(nil)
+	widthMemo = newWidthCache(widthCacheSize)
+
+	label := "\033[1m日本 cache test\033[0m"
+	for i := 0; i < 3; i++ {
+		if width := visibleWidth(label); width != 15 {
+			t.Errorf("Expected a width of 15 but got %d", width)
+		}
+	}
+	if counters.Get(MetricWidthCacheMisses) != 1 || counters.Get(MetricWidthCacheHits) != 2 {
+		t.Errorf("Expected 1 miss and 2 hits but got %d and %d",
+			counters.Get(MetricWidthCacheMisses), counters.Get(MetricWidthCacheHits))
+	}
+
+	// long strings aren't cached
+	long := strings.Repeat("x", widthCacheMaxLen+1)
+	if visibleWidth(long) != widthCacheMaxLen+1 {
+		t.Error("Unexpected width of a long string")
+	}
+	if _, ok := widthMemo.get(long); ok {
+		t.Error("Expected a long string not to be cached")
+	}
+}
+
+/* tableCells returns the cells of a table with repeated labels, as measured by column layouts */
+func tableCells() []string {
+	cells := []string{}
+	for row := 0; row < 1000; row++ {
+		cells = append(cells, "\033[1mStatus\033[0m", "\033[32mrunning\033[0m", "worker-"+strconv.Itoa(row%20), "日本語ラベル")
+	}
+	return cells
+}
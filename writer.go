@@ -0,0 +1,416 @@
+package colorize
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+/* The BinaryPolicy type represents how writers handle binary content */
+type BinaryPolicy int
+
+const (
+	/* Binary content policies */
+	BinaryPassthrough BinaryPolicy = iota // binary content is detected and written untouched (default)
+	BinaryTransform                       // binary content is transformed like text
+	BinaryReject                          // writes of binary content fail with a BINARY error
+)
+
+const (
+	// binary detection thresholds
+	binaryMinLength      = 32  // minimum length for the invalid UTF-8 density check
+	binaryInvalidDensity = 0.3 // ratio of invalid UTF-8 bytes considered binary
+	binarySniffLength    = 512 // length of the start of a stream checked for binary content
+)
+
+/* The WriterOptions type represents the options of the writers of the package */
+type WriterOptions struct {
+	FlushTimeout time.Duration // flushes unterminated lines after this delay (0 waits for the newline or Close)
+	BinaryPolicy BinaryPolicy  // how binary content (NUL bytes, invalid UTF-8) is handled
+}
+
+/*
+The LineWriter type is a line-buffered writer: it accumulates the bytes written to it and passes
+each complete line through a transform function before writing it to the underlying writer.
+
+Write calls can split lines, escape sequences and UTF-8 characters arbitrarily (even byte by byte):
+lines are only transformed once complete, on Close, or when they stay unterminated longer than
+WriterOptions.FlushTimeout. Flushes never split an escape sequence or a UTF-8 character.
+
+Binary content (NUL bytes anywhere in the stream, or a high density of invalid UTF-8 in its first
+512 bytes) is handled according to WriterOptions.BinaryPolicy: by default, once detected, the rest
+of the stream is written untouched so that no escape sequence is ever injected into it.
+
+A LineWriter is safe for concurrent use. It must be closed to flush the last unterminated line.
+*/
+type LineWriter struct {
+	mu        sync.Mutex
+	w         io.Writer
+	transform func(line string) string
+	opts      WriterOptions
+	buf       []byte
+	timer     *time.Timer
+	err       error // error of a timed-out flush, reported by the next call
+	closed    bool
+	binary    bool   // binary content was detected, the stream is passed through
+	sniff     []byte // start of the stream checked for binary content (see binarySniffLength)
+}
+
+/*
+NewLineWriter returns a LineWriter formatting every line written to it with the given options.
+Line endings (and the carriage return of CRLF endings) are written after the reset code.
+
+Parameters:
+  - w: The writer to write the formatted lines to.
+  - opts: The formatting options.
+  - wopts: The writer options, or nil for the defaults.
+
+Return:
+  - *LineWriter: The newly created writer.
+
+Example:
+
+	w := c.NewLineWriter(os.Stderr, &c.Options{FgColor: "#FF0000"}, nil)
+	defer w.Close()
+
+	cmd := exec.Command("make")
+	cmd.Stderr = w
+	cmd.Run()
+*/
+func NewLineWriter(w io.Writer, opts *Options, wopts *WriterOptions) *LineWriter {
+	return NewLineWriterFunc(w, func(line string) string {
+		formatted, _ := formatText(line, opts, terminalAllows(w))
+		return formatted
+	}, wopts)
+}
+
+/*
+NewLineWriterFunc returns a LineWriter passing every line written to it through the given
+transform function. The function receives the line without its line ending.
+
+Parameters:
+  - w: The writer to write the transformed lines to.
+  - transform: The function transforming each line.
+  - wopts: The writer options, or nil for the defaults.
+
+Return:
+  - *LineWriter: The newly created writer.
+
+Example:
+
+	// prefix every line of a subprocess output
+	w := c.NewLineWriterFunc(os.Stdout, func(line string) string {
+		return c.StyleText("[build] ", []string{"bold"}) + line
+	}, nil)
+*/
+func NewLineWriterFunc(w io.Writer, transform func(line string) string, wopts *WriterOptions) *LineWriter {
+	lw := &LineWriter{w: w, transform: transform}
+	if wopts != nil {
+		lw.opts = *wopts
+	}
+	return lw
+}
+
+/*
+Write buffers p, transforms every line it completes and writes them to the underlying writer.
+
+Parameters:
+  - p: The bytes to write.
+
+Return:
+  - int: The number of bytes consumed (len(p) unless an error occurred).
+  - error: The error returned by the underlying writer, if any.
+*/
+func (lw *LineWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if lw.closed {
+		return 0, newColorizeErr("WRITERCLOSED", "write to a closed writer")
+	}
+	if err := lw.takeErr(); err != nil {
+		return 0, err
+	}
+
+	if lw.binary {
+		if _, err := lw.w.Write(p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	// binary content: NUL bytes are looked for in every chunk, invalid UTF-8 at the start of the stream
+	if lw.opts.BinaryPolicy != BinaryTransform {
+		binary := bytes.IndexByte(p, 0) >= 0
+		sniff := lw.sniff
+		if len(sniff) < binarySniffLength {
+			sniff = append(sniff, p[:min(len(p), binarySniffLength-len(sniff))]...)
+			binary = binary || isBinary(sniff)
+		}
+		if binary {
+			if lw.opts.BinaryPolicy == BinaryReject {
+				return 0, newColorizeErr("BINARY", "binary content rejected")
+			}
+			lw.binary = true
+		}
+		lw.sniff = sniff
+	}
+
+	lw.buf = append(lw.buf, p...)
+
+	if lw.binary {
+		if lw.timer != nil {
+			lw.timer.Stop()
+		}
+		_, err := lw.w.Write(lw.buf)
+		lw.buf = lw.buf[:0]
+		if err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	// complete lines
+	if i := bytes.LastIndexByte(lw.buf, '\n'); i >= 0 {
+		out := lw.transformLines(lw.buf[:i+1])
+		lw.buf = append(lw.buf[:0], lw.buf[i+1:]...)
+		if _, err := io.WriteString(lw.w, out); err != nil {
+			return 0, err
+		}
+	}
+
+	lw.scheduleFlush()
+
+	return len(p), nil
+}
+
+/*
+Flush transforms and writes the buffered unterminated line, if any, except for a trailing
+incomplete escape sequence or UTF-8 character, which stays buffered.
+
+Return:
+  - error: The error returned by the underlying writer, if any.
+*/
+func (lw *LineWriter) Flush() error {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if err := lw.takeErr(); err != nil {
+		return err
+	}
+	return lw.flush(false)
+}
+
+/*
+Close flushes the buffered unterminated line (including any incomplete trailing sequence) and
+stops the flush timer. The underlying writer is not closed. Writing after Close returns an error.
+
+Return:
+  - error: The error returned by the underlying writer, if any.
+*/
+func (lw *LineWriter) Close() error {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if lw.closed {
+		return nil
+	}
+	lw.closed = true
+	if lw.timer != nil {
+		lw.timer.Stop()
+	}
+
+	if err := lw.takeErr(); err != nil {
+		return err
+	}
+	return lw.flush(true)
+}
+
+/*
+transformLines transforms every line of the given chunk, which must end with a newline.
+
+Parameters:
+  - chunk: The complete lines, including their line endings.
+
+Return:
+  - string: The transformed lines, including their line endings.
+*/
+func (lw *LineWriter) transformLines(chunk []byte) string {
+	out := bytes.Buffer{}
+	for len(chunk) > 0 {
+		i := bytes.IndexByte(chunk, '\n')
+		line, ending := chunk[:i], "\n"
+		if len(line) > 0 && line[len(line)-1] == '\r' {
+			line, ending = line[:len(line)-1], "\r\n"
+		}
+		out.WriteString(lw.transform(string(line)))
+		out.WriteString(ending)
+		chunk = chunk[i+1:]
+	}
+	return out.String()
+}
+
+/*
+flush transforms and writes the buffered unterminated line. The caller must hold the lock.
+
+Parameters:
+  - all: Whether to flush incomplete trailing sequences too.
+
+Return:
+  - error: The error returned by the underlying writer, if any.
+*/
+func (lw *LineWriter) flush(all bool) error {
+	n := len(lw.buf)
+	if !all {
+		n = safeSplit(lw.buf)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	out := lw.transform(string(lw.buf[:n]))
+	lw.buf = append(lw.buf[:0], lw.buf[n:]...)
+	_, err := io.WriteString(lw.w, out)
+	return err
+}
+
+/* scheduleFlush (re)starts the flush timer if a line is pending. The caller must hold the lock */
+func (lw *LineWriter) scheduleFlush() {
+	if lw.opts.FlushTimeout <= 0 {
+		return
+	}
+	if lw.timer != nil {
+		lw.timer.Stop()
+	}
+	if len(lw.buf) == 0 {
+		return
+	}
+
+	lw.timer = time.AfterFunc(lw.opts.FlushTimeout, func() {
+		lw.mu.Lock()
+		defer lw.mu.Unlock()
+		if lw.closed {
+			return
+		}
+		if err := lw.flush(false); err != nil && lw.err == nil {
+			lw.err = err
+		}
+	})
+}
+
+/*
+takeErr returns and clears the error of a timed-out flush. The caller must hold the lock.
+
+Return:
+  - error: The pending error, if any.
+*/
+func (lw *LineWriter) takeErr() error {
+	err := lw.err
+	lw.err = nil
+	return err
+}
+
+/*
+safeSplit returns the length of the longest prefix of buf that doesn't end in the middle of an
+escape sequence or a UTF-8 character.
+
+Parameters:
+  - buf: The buffered bytes.
+
+Return:
+  - int: The length of the prefix that can be flushed.
+*/
+func safeSplit(buf []byte) int {
+	n := len(buf)
+
+	// incomplete escape sequences (an OSC may be cut right after the ESC of its terminator)
+	for i := bytes.LastIndexByte(buf, '\033'); i >= 0; i = bytes.LastIndexByte(buf[:i], '\033') {
+		if !isIncompleteEscape(buf[i:n]) {
+			break
+		}
+		n = i
+	}
+
+	// incomplete UTF-8 character
+	for back := 1; back <= 3 && back <= n; back++ {
+		b := buf[n-back]
+		if b < 0x80 {
+			break
+		}
+		if utf8.RuneStart(b) {
+			if !utf8.FullRune(buf[n-back : n]) {
+				n -= back
+			}
+			break
+		}
+	}
+
+	return n
+}
+
+/*
+isIncompleteEscape reports whether seq (starting with ESC) is the beginning of an escape sequence
+that hasn't been terminated yet.
+
+Parameters:
+  - seq: The bytes starting with ESC.
+
+Return:
+  - bool: Whether the sequence is incomplete.
+*/
+func isIncompleteEscape(seq []byte) bool {
+	if len(seq) == 1 {
+		return true
+	}
+	switch seq[1] {
+	case '[':
+		// CSI: parameters and intermediates until a final byte
+		for _, b := range seq[2:] {
+			if b >= 0x40 && b <= 0x7E {
+				return false
+			}
+		}
+		return true
+	case ']':
+		// OSC: until BEL or ST
+		return !bytes.ContainsRune(seq, '\a') && !bytes.Contains(seq, []byte("\033\\"))
+	}
+	return false
+}
+
+/*
+isBinary reports whether the given bytes look like binary content: they contain a NUL byte, or
+(for long enough content) more than 30% of them are invalid UTF-8. A trailing incomplete UTF-8
+character is not counted as invalid.
+
+Parameters:
+  - buf: The bytes to check.
+
+Return:
+  - bool: Whether the content looks binary.
+*/
+func isBinary(buf []byte) bool {
+	if bytes.IndexByte(buf, 0) >= 0 {
+		return true
+	}
+	if len(buf) < binaryMinLength {
+		return false
+	}
+
+	invalid := 0
+	for i := 0; i < len(buf); {
+		r, size := utf8.DecodeRune(buf[i:])
+		if r == utf8.RuneError && size == 1 {
+			if !utf8.FullRune(buf[i:]) {
+				// incomplete character at the end
+				break
+			}
+			invalid++
+		}
+		i += size
+	}
+
+	return float64(invalid)/float64(len(buf)) > binaryInvalidDensity
+}
@@ -0,0 +1,196 @@
+package colorize
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// brackets marks each transformed line
+func brackets(line string) string {
+	return "[" + line + "]"
+}
+
+// errWriter always fails
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+/* TestLineWriterByteAtATime tests the LineWriter with byte-at-a-time input */
+func TestLineWriterByteAtATime(t *testing.T) {
+	buf := &bytes.Buffer{}
+	lw := NewLineWriterFunc(buf, brackets, nil)
+
+	input := "first \033[1mline\033[0m\r\nsécond\n\nthird"
+	for i := 0; i < len(input); i++ {
+		if _, err := lw.Write([]byte{input[i]}); err != nil {
+			t.Fatal("Expected no error but got", err)
+		}
+	}
+
+	if buf.String() != "[first \033[1mline\033[0m]\r\n[sécond]\n[]\n" {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+
+	// close flushes the last line
+	if err := lw.Close(); err != nil {
+		t.Error("Expected no error but got", err)
+	}
+	if !strings.HasSuffix(buf.String(), "[third]") {
+		t.Errorf("Expected the last line to be flushed but got %q", buf.String())
+	}
+
+	// closing twice is safe, writing is not
+	if err := lw.Close(); err != nil {
+		t.Error("Expected no error but got", err)
+	}
+	if _, err := lw.Write([]byte("x")); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+}
+
+/* TestLineWriterFlush tests that flushes never split escape sequences or characters */
+func TestLineWriterFlush(t *testing.T) {
+	buf := &bytes.Buffer{}
+	lw := NewLineWriterFunc(buf, brackets, nil)
+
+	_, _ = lw.Write([]byte("abc\033[38;2;25"))
+	_ = lw.Flush()
+	if buf.String() != "[abc]" {
+		t.Errorf("Expected the incomplete escape sequence to stay buffered but got %q", buf.String())
+	}
+
+	buf.Reset()
+	_, _ = lw.Write([]byte("5;0;0mé"[:len("5;0;0mé")-1]))
+	_ = lw.Flush()
+	if buf.String() != "[\033[38;2;255;0;0m]" {
+		t.Errorf("Expected the incomplete character to stay buffered but got %q", buf.String())
+	}
+
+	buf.Reset()
+	_, _ = lw.Write([]byte("\033]8;;http://example.com\033"))
+	_ = lw.Flush()
+	if buf.String() != "" {
+		t.Errorf("Expected the incomplete OSC sequence to stay buffered but got %q", buf.String())
+	}
+	_ = lw.Close()
+}
+
+/* TestLineWriterTimeout tests the FlushTimeout option */
+func TestLineWriterTimeout(t *testing.T) {
+	buf := &syncBuffer{}
+	lw := NewLineWriterFunc(buf, brackets, &WriterOptions{FlushTimeout: 10 * time.Millisecond})
+	defer lw.Close()
+
+	_, _ = lw.Write([]byte("progress..."))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for buf.String() == "" && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if buf.String() != "[progress...]" {
+		t.Errorf("Expected the line to be flushed after the timeout but got %q", buf.String())
+	}
+}
+
+/* TestNewLineWriter tests the NewLineWriter function */
+func TestNewLineWriter(t *testing.T) {
+	// defer restore
+	defer restore()
+	trueColor = true
+
+	buf := &bytes.Buffer{}
+	lw := NewLineWriter(buf, &Options{Styles: []string{"bold"}}, nil)
+	_, _ = lw.Write([]byte("a\nb"))
+	_ = lw.Close()
+
+	if buf.String() != styles["bold"]+"a"+reset+"\n"+styles["bold"]+"b"+reset {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
+
+/* TestLineWriterErrors tests that write errors are reported */
+func TestLineWriterErrors(t *testing.T) {
+	lw := NewLineWriterFunc(errWriter{}, brackets, nil)
+	if _, err := lw.Write([]byte("line\n")); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+	_, _ = lw.Write([]byte("partial"))
+	if err := lw.Close(); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+}
+
+/* TestIsBinary tests the isBinary function */
+func TestIsBinary(t *testing.T) {
+	if !isBinary([]byte("text\x00")) {
+		t.Error("Expected NUL bytes to be detected")
+	}
+	if isBinary([]byte("plain text with ütf-8 characters, long enough")) {
+		t.Error("Expected text not to be detected as binary")
+	}
+	if !isBinary(bytes.Repeat([]byte{0xff, 0xfe, 'a'}, 20)) {
+		t.Error("Expected invalid UTF-8 to be detected")
+	}
+	if isBinary([]byte{0xff}) {
+		t.Error("Expected short content not to be checked for density")
+	}
+	if isBinary(append(bytes.Repeat([]byte("a"), 40), 0xe2, 0x82)) {
+		t.Error("Expected a trailing incomplete character not to be counted")
+	}
+}
+
+/* TestLineWriterBinaryPolicy tests the BinaryPolicy writer option */
+func TestLineWriterBinaryPolicy(t *testing.T) {
+	binary := []byte("\x7fELF\x00\x01\n\x02")
+
+	// passthrough (default)
+	buf := &bytes.Buffer{}
+	lw := NewLineWriterFunc(buf, brackets, nil)
+	_, _ = lw.Write([]byte("text\npartial"))
+	_, _ = lw.Write(binary)
+	_, _ = lw.Write([]byte("more\n"))
+	_ = lw.Close()
+	if buf.String() != "[text]\npartial"+string(binary)+"more\n" {
+		t.Errorf("Expected binary content to be passed through but got %q", buf.String())
+	}
+
+	// transform
+	buf.Reset()
+	lw = NewLineWriterFunc(buf, brackets, &WriterOptions{BinaryPolicy: BinaryTransform})
+	_, _ = lw.Write(binary)
+	_ = lw.Close()
+	if buf.String() != "[\x7fELF\x00\x01]\n[\x02]" {
+		t.Errorf("Expected binary content to be transformed but got %q", buf.String())
+	}
+
+	// reject
+	buf.Reset()
+	lw = NewLineWriterFunc(buf, brackets, &WriterOptions{BinaryPolicy: BinaryReject})
+	if _, err := lw.Write(binary); err == nil {
+		t.Error("Expected an error but got nil")
+	}
+	_, _ = lw.Write([]byte("text\n"))
+	_ = lw.Close()
+	if buf.String() != "[text]\n" {
+		t.Errorf("Expected binary content to be rejected but got %q", buf.String())
+	}
+
+	// NUL bytes are detected anywhere, invalid UTF-8 at the start of the stream only
+	buf.Reset()
+	lw = NewLineWriterFunc(buf, brackets, nil)
+	text := strings.Repeat("a", binarySniffLength) + "\n"
+	invalid := strings.Repeat("\xff", binaryMinLength) + "\n"
+	_, _ = lw.Write([]byte(text))
+	_, _ = lw.Write([]byte(invalid))
+	_, _ = lw.Write([]byte("late\x00\n"))
+	_, _ = lw.Write([]byte("more\n"))
+	_ = lw.Close()
+	if expected := "[" + text[:binarySniffLength] + "]\n[" + invalid[:binaryMinLength] + "]\nlate\x00\nmore\n"; buf.String() != expected {
+		t.Errorf("Expected late binary content to be passed through but got %q", buf.String())
+	}
+}